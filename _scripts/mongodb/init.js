@@ -1,5 +1,28 @@
 db = db.getSiblingDB('challenge');
 
 users = db.createCollection('users');
-db.users.createIndex( { "document.type": 1}, { unique: true})
-db.users.createIndex( { "email": 1 }, { unique: true })
\ No newline at end of file
+db.users.createIndex( { "id": 1 }, { unique: true })
+db.users.createIndex( { "document.type": 1, "document.value": 1 }, { unique: true })
+db.users.createIndex( { "email": 1 }, { unique: true })
+ledger = db.createCollection('ledger');
+db.ledger.createIndex( { "id": 1 }, { unique: true })
+db.ledger.createIndex( { "transfer_id": 1 })
+db.ledger.createIndex( { "postings.account": 1 })
+
+idempotency_keys = db.createCollection('idempotency_keys');
+db.idempotency_keys.createIndex( { "payer_id": 1, "key": 1 }, { unique: true })
+
+transfer = db.createCollection('transfer');
+db.transfer.createIndex( { "id": 1 }, { unique: true })
+db.transfer.createIndex( { "reversal_of": 1 }, { sparse: true })
+db.transfer.createIndex( { "payer": 1, "created_at": -1, "id": -1 })
+db.transfer.createIndex( { "payee": 1, "created_at": -1, "id": -1 })
+
+refresh_tokens = db.createCollection('refresh_tokens');
+db.refresh_tokens.createIndex( { "hash": 1 }, { unique: true })
+db.refresh_tokens.createIndex( { "expires_at": 1 }, { expireAfterSeconds: 0 })
+
+outbox = db.createCollection('outbox');
+db.outbox.createIndex( { "id": 1 }, { unique: true })
+db.outbox.createIndex( { "published_at": 1, "created_at": 1, "id": 1 })
+db.outbox.createIndex( { "processed_at": 1 }, { expireAfterSeconds: 604800 })
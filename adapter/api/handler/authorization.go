@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+var (
+	errUnauthenticated = fault.New(fault.Unauthenticated, "unauthenticated", "missing authenticated user")
+
+	errForbiddenPayer = fault.New(fault.Forbidden, "forbidden_payer", "payer must be the authenticated user")
+
+	errForbiddenUser = fault.New(fault.Forbidden, "forbidden_user", "access to another user is forbidden")
+)
+
+// authorizeUser checks that the authenticated user is the user the request acts on,
+// it returns the error to answer with when it is not
+func authorizeUser(r *http.Request, userID vo.Uuid, errForbidden error) error {
+	caller, ok := middleware.UserID(r.Context())
+	if !ok {
+		return errUnauthenticated
+	}
+
+	if caller != userID {
+		return errForbidden
+	}
+
+	return nil
+}
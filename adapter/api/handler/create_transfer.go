@@ -1,23 +1,45 @@
 package handler
 
 import (
-	"encoding/json"
-	"github.com/google/uuid"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 	"github.com/ofiliobi/urban-octo-fortnight/usecase"
 )
 
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+var (
+	errInvalidIdempotencyKey = fault.New(fault.Invalid, "invalid_idempotency_key", "invalid idempotency key")
+)
+
 type (
 	// Request data
 	CreateTransferRequest struct {
-		PayerID string `json:"payer_id"`
-		PayeeID string `json:"payee_id"`
-		Value   int64  `json:"value"`
+		PayerID  string `json:"payer_id"`
+		PayeeID  string `json:"payee_id"`
+		Value    int64  `json:"value"`
+		Currency string `json:"currency"`
+	}
+
+	// LimitExceededDetails tells which limit the transfer exceeds, amounts are in minor units
+	LimitExceededDetails struct {
+		Limit     string `json:"limit"`
+		Currency  string `json:"currency"`
+		Max       int64  `json:"max"`
+		Remaining int64  `json:"remaining"`
 	}
 
 	// CreateTransferHandler defines the dependencies of the HTTP handler for the use case
@@ -44,19 +66,20 @@ func (c CreateTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	})
 
 	var reqData CreateTransferRequest
-	if err := json.NewDecoder(r.Body).Decode(&reqData); err != nil {
+	if err := validation.DecodeJSON(w, r, &reqData); err != nil {
+		problem := response.NewProblem(r, err)
 		c.log.WithFields(logger.Fields{
 			"key":         c.logKey,
 			"error":       err.Error(),
-			"http_status": http.StatusBadRequest,
+			"http_status": problem.Status,
 		}).Errorf("failed to marshal message")
 
-		response.NewError(err, http.StatusBadRequest).Send(w)
+		problem.Send(w)
 		return
 	}
 	defer r.Body.Close()
 
-	input, errs := c.validate(reqData)
+	input, errs := c.validate(reqData, r.Header.Get(idempotencyKeyHeader))
 	if len(errs) > 0 {
 		c.log.WithFields(logger.Fields{
 			"key":         c.logKey,
@@ -64,19 +87,44 @@ func (c CreateTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
 			"http_status": http.StatusBadRequest,
 		}).Errorf("failed to data")
 
-		response.NewErrors(errs, http.StatusBadRequest).Send(w)
+		response.NewValidationProblem(r, errs).Send(w)
+		return
+	}
+
+	if err := authorizeUser(r, input.PayerID, errForbiddenPayer); err != nil {
+		problem := response.NewProblem(r, err)
+		c.log.WithFields(logger.Fields{
+			"key":         c.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("transfer from another user")
+
+		problem.Send(w)
 		return
 	}
 
 	output, err := c.uc.Execute(r.Context(), input)
 	if err != nil {
+		problem := response.NewProblem(r, err)
+
+		// the remaining allowance tells the payer how much can still be sent
+		var limitErr *entity.LimitExceededError
+		if errors.As(err, &limitErr) {
+			problem.WithDetails(LimitExceededDetails{
+				Limit:     limitErr.Limit,
+				Currency:  limitErr.Currency.String(),
+				Max:       limitErr.Max,
+				Remaining: limitErr.Remaining,
+			})
+		}
+
 		c.log.WithFields(logger.Fields{
 			"key":         c.logKey,
 			"error":       err.Error(),
-			"http_status": http.StatusInternalServerError,
+			"http_status": problem.Status,
 		}).Errorf("error when creating a new transfer")
 
-		response.NewError(err, http.StatusInternalServerError).Send(w)
+		problem.Send(w)
 		return
 	}
 
@@ -88,30 +136,38 @@ func (c CreateTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	response.NewSuccess(output, http.StatusCreated).Send(w)
 }
 
-func (c CreateTransferHandler) validate(i CreateTransferRequest) (usecase.CreateTransferInput, []error) {
+func (c CreateTransferHandler) validate(i CreateTransferRequest, idempotencyKey string) (usecase.CreateTransferInput, []error) {
 	var errs []error
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		errs = append(errs, validation.Field(idempotencyKeyHeader, errInvalidIdempotencyKey))
+	}
 	id, err := vo.NewUuid(uuid.New().String())
 	if err != nil {
 		errs = append(errs, err)
 	}
 	payerID, err := vo.NewUuid(i.PayerID)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("payer_id", err))
 	}
 	payeeID, err := vo.NewUuid(i.PayeeID)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("payee_id", err))
 	}
 	amount, err := vo.NewAmount(i.Value)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("value", err))
+	}
+	currency, err := vo.NewCurrency(i.Currency)
+	if err != nil {
+		errs = append(errs, validation.Field("currency", err))
 	}
 
 	return usecase.CreateTransferInput{
-		ID:        id,
-		PayerID:   payerID,
-		PayeeID:   payeeID,
-		Value:     vo.NewMoneyNGN(amount),
-		CreatedAt: time.Now(),
+		ID:             id,
+		PayerID:        payerID,
+		PayeeID:        payeeID,
+		Value:          vo.NewMoney(currency, amount),
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
 	}, errs
-}
\ No newline at end of file
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
@@ -33,7 +34,9 @@ func TestCreateTransferHandler_Handle(t *testing.T) {
 		log logger.Logger
 	}
 	type args struct {
-		rawPayload []byte
+		rawPayload     []byte
+		idempotencyKey string
+		anonymous      bool
 	}
 	tests := []struct {
 		name               string
@@ -63,11 +66,12 @@ func TestCreateTransferHandler_Handle(t *testing.T) {
 					{
 						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
 						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
-						"value": 100
+						"value": 100,
+						"currency": "NGN"
 					}`,
 				),
 			},
-			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":100,"created_at":"0001-01-01T00:00:00Z"}`,
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":100,"currency":"NGN","credited_value":100,"credited_currency":"NGN","rate":"1","status":"PENDING","created_at":"0001-01-01T00:00:00Z"}`,
 			expectedStatusCode: http.StatusCreated,
 		},
 		{
@@ -81,11 +85,12 @@ func TestCreateTransferHandler_Handle(t *testing.T) {
 					{
 						"payer_id": "0db298eb-c8e7-4829-84b7",
 						"payee_id": "0db298eb-c8e7-4829-84b7",
-						"value": -100
+						"value": -100,
+						"currency": "BRL"
 					}`,
 				),
 			},
-			expectedBody:       `{"errors":["invalid uuid","invalid uuid","invalid amount"]}`,
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/transfers","code":"invalid_request","errors":[{"field":"payer_id","code":"invalid_uuid","message":"invalid uuid"},{"field":"payee_id","code":"invalid_uuid","message":"invalid uuid"},{"field":"value","code":"invalid_amount","message":"invalid amount"},{"field":"currency","code":"invalid_currency","message":"invalid currency"}]}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
@@ -102,13 +107,147 @@ func TestCreateTransferHandler_Handle(t *testing.T) {
 					{
 						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
 						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
-						"value": 100
+						"value": 100,
+						"currency": "NGN"
 					}`,
 				),
 			},
-			expectedBody:       `{"errors":["db_error"]}`,
+			expectedBody:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"instance":"/transfers","code":"internal_error"}`,
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Error create transfer idempotency key reused with different body",
+			fields: fields{
+				uc: stubCreateTransferUseCase{
+					result: usecase.CreateTransferOutput{},
+					err:    entity.ErrIdempotencyKeyMismatch,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 100,
+						"currency": "NGN"
+					}`,
+				),
+				idempotencyKey: "key",
+			},
+			expectedBody:       `{"type":"/problems/idempotency_key_mismatch","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","instance":"/transfers","code":"idempotency_key_mismatch"}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error create transfer currency mismatch",
+			fields: fields{
+				uc: stubCreateTransferUseCase{
+					result: usecase.CreateTransferOutput{},
+					err:    entity.ErrCurrencyMismatch,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 100,
+						"currency": "USD"
+					}`,
+				),
+			},
+			expectedBody:       `{"type":"/problems/currency_mismatch","title":"Unprocessable Entity","status":422,"detail":"currency mismatch","instance":"/transfers","code":"currency_mismatch"}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error create transfer exchange rate unavailable",
+			fields: fields{
+				uc: stubCreateTransferUseCase{
+					result: usecase.CreateTransferOutput{},
+					err:    vo.ErrExchangeRateUnavailable,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 100,
+						"currency": "GBP"
+					}`,
+				),
+			},
+			expectedBody:       `{"type":"/problems/exchange_rate_unavailable","title":"Unprocessable Entity","status":422,"detail":"exchange rate unavailable","instance":"/transfers","code":"exchange_rate_unavailable"}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error create transfer limit exceeded",
+			fields: fields{
+				uc: stubCreateTransferUseCase{
+					result: usecase.CreateTransferOutput{},
+					err: &entity.LimitExceededError{
+						Limit:     entity.DailyLimit,
+						Currency:  vo.NewMoneyNGN(vo.NewAmountTest(0)).Currency(),
+						Max:       20000,
+						Remaining: 5000,
+					},
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 10000,
+						"currency": "NGN"
+					}`,
+				),
+			},
+			expectedBody:       `{"type":"/problems/limit_exceeded","title":"Unprocessable Entity","status":422,"detail":"transfer limit exceeded: daily limit of 20000 NGN, 5000 NGN remaining","instance":"/transfers","code":"limit_exceeded","details":{"limit":"daily","currency":"NGN","max":20000,"remaining":5000}}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error create transfer from another user",
+			fields: fields{
+				uc:  stubCreateTransferUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 100,
+						"currency": "NGN"
+					}`,
+				),
+			},
+			expectedBody:       `{"type":"/problems/forbidden_payer","title":"Forbidden","status":403,"detail":"payer must be the authenticated user","instance":"/transfers","code":"forbidden_payer"}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "Error create transfer without an authenticated user",
+			fields: fields{
+				uc:  stubCreateTransferUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"payer_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"payee_id": "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+						"value": 100,
+						"currency": "NGN"
+					}`,
+				),
+				anonymous: true,
+			},
+			expectedBody:       `{"type":"/problems/unauthenticated","title":"Unauthorized","status":401,"detail":"missing authenticated user","instance":"/transfers","code":"unauthenticated"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -117,6 +256,11 @@ func TestCreateTransferHandler_Handle(t *testing.T) {
 				"/transfers",
 				bytes.NewReader(tt.args.rawPayload),
 			)
+			req.Header.Set("Idempotency-Key", tt.args.idempotencyKey)
+
+			if !tt.args.anonymous {
+				req = req.WithContext(middleware.WithUserID(req.Context(), vo.NewUuidStaticTest()))
+			}
 
 			var (
 				w       = httptest.NewRecorder()
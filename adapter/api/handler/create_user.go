@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 	"github.com/ofiliobi/urban-octo-fortnight/usecase"
@@ -15,24 +16,24 @@ import (
 type (
 	// Request data
 	CreateUserRequest struct {
-		FullName string
-		Email    string
-		Password string
-		Document CreateUserDocumentRequest
-		Wallet   CreateUserWalletRequest
-		Type     string
+		FullName string                    `json:"fullname"`
+		Email    string                    `json:"email"`
+		Password string                    `json:"password"`
+		Document CreateUserDocumentRequest `json:"document"`
+		Wallet   CreateUserWalletRequest   `json:"wallet"`
+		Type     string                    `json:"type"`
 	}
 
 	// Request data
 	CreateUserDocumentRequest struct {
-		Type  string
-		Value string
+		Type  string `json:"type"`
+		Value string `json:"value"`
 	}
 
 	// Request data
 	CreateUserWalletRequest struct {
-		Currency string
-		Amount   int64
+		Currency string `json:"currency"`
+		Amount   int64  `json:"amount"`
 	}
 
 	// CreateUserHandler defines the dependencies of the HTTP handler for the use case
@@ -59,14 +60,15 @@ func (c CreateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	})
 
 	var reqData CreateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&reqData); err != nil {
+	if err := validation.DecodeJSON(w, r, &reqData); err != nil {
+		problem := response.NewProblem(r, err)
 		c.log.WithFields(logger.Fields{
 			"key":         c.logKey,
 			"error":       err.Error(),
-			"http_status": http.StatusBadRequest,
+			"http_status": problem.Status,
 		}).Errorf("failed to marshal message")
 
-		response.NewError(err, http.StatusBadRequest).Send(w)
+		problem.Send(w)
 		return
 	}
 	defer r.Body.Close()
@@ -79,19 +81,20 @@ func (c CreateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 			"http_status": http.StatusBadRequest,
 		}).Errorf("failed to data")
 
-		response.NewErrors(errs, http.StatusBadRequest).Send(w)
+		response.NewValidationProblem(r, errs).Send(w)
 		return
 	}
 
 	output, err := c.uc.Execute(r.Context(), input)
 	if err != nil {
+		problem := response.NewProblem(r, err)
 		c.log.WithFields(logger.Fields{
 			"key":         c.logKey,
 			"error":       err.Error(),
-			"http_status": http.StatusInternalServerError,
+			"http_status": problem.Status,
 		}).Errorf("error when creating a new user")
 
-		response.NewError(err, http.StatusInternalServerError).Send(w)
+		problem.Send(w)
 		return
 	}
 
@@ -110,32 +113,31 @@ func (c CreateUserHandler) validate(i CreateUserRequest) (usecase.CreateUserInpu
 		errs = append(errs, err)
 	}
 	doc, err := vo.NewDocument(vo.TypeDocument(i.Document.Type), i.Document.Value)
-	if err != nil {
-		errs = append(errs, err)
+	if errors.Is(err, vo.ErrInvalidTypeDocument) {
+		errs = append(errs, validation.Field("document.type", err))
+	} else if err != nil {
+		errs = append(errs, validation.Field("document.value", err))
 	}
 	email, err := vo.NewEmail(i.Email)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("email", err))
 	}
-	currency, err := vo.NewCurrency(i.Wallet.Currency)
+	password, err := vo.NewPassword(i.Password)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("password", err))
 	}
-	amount, err := vo.NewAmount(i.Wallet.Amount)
-	if err != nil {
-		errs = append(errs, err)
-	}
-	money := vo.NewMoney(currency, amount)
+	currency, err := vo.NewCurrency(i.Wallet.Currency)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("wallet.currency", err))
 	}
-	wallet := vo.NewWallet(money)
+	amount, err := vo.NewAmount(i.Wallet.Amount)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("wallet.amount", err))
 	}
+	wallet := vo.NewWallet(vo.NewMoney(currency, amount))
 	typeUser, err := vo.NewTypeUser(i.Type)
 	if err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.Field("type", err))
 	}
 
 	return usecase.CreateUserInput{
@@ -143,7 +145,7 @@ func (c CreateUserHandler) validate(i CreateUserRequest) (usecase.CreateUserInpu
 		FullName:  vo.NewFullName(i.FullName),
 		Document:  doc,
 		Email:     email,
-		Password:  vo.NewPassword(i.Password),
+		Password:  password,
 		Wallet:    wallet,
 		Type:      typeUser,
 		CreatedAt: time.Now(),
@@ -52,7 +52,7 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Common user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CPF, "07091054954"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Time{},
@@ -66,20 +66,20 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 					{
 						"fullname": "Gabriel Gabriel",
 						"email": "gabriel@hotmail.com",
-						"password": "passw123",
+						"password": "Passw0rd123",
 						"document": {
 							"type": "CPF",
 							"value": "070.910.549-54"
 						},
 						"wallet": {
-							"currency": "BRL",
+							"currency": "NGN",
 							"amount": 100
 						},
 						"type": "common"
 					}`,
 				),
 			},
-			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","full_name":"Common user","email":"test@testing.com","password":"passw","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"BRL","amount":100},"Roles":{"can_transfer":true},"type":"COMMON","created_at":"0001-01-01T00:00:00Z"}`,
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","full_name":"Common user","email":"test@testing.com","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"NGN","amount":100},"Roles":{"can_transfer":true},"type":"COMMON","created_at":"0001-01-01T00:00:00Z"}`,
 			expectedStatusCode: http.StatusCreated,
 		},
 		{
@@ -91,7 +91,7 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Common user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CPF, "07091054954"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Time{},
@@ -105,20 +105,20 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 					{
 						"fullname": "Gabriel Gabriel",
 						"email": "gabriel@hotmail.com",
-						"password": "passw123",
+						"password": "Passw0rd123",
 						"document": {
 							"type": "CPF",
 							"value": "070.910.549-54"
 						},
 						"wallet": {
-							"currency": "BRL",
+							"currency": "NGN",
 							"amount": 100
 						},
 						"type": "merchant"
 					}`,
 				),
 			},
-			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","full_name":"Common user","email":"test@testing.com","password":"passw","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"BRL","amount":100},"Roles":{"can_transfer":false},"type":"MERCHANT","created_at":"0001-01-01T00:00:00Z"}`,
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","full_name":"Common user","email":"test@testing.com","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"NGN","amount":100},"Roles":{"can_transfer":false},"type":"MERCHANT","created_at":"0001-01-01T00:00:00Z"}`,
 			expectedStatusCode: http.StatusCreated,
 		},
 		{
@@ -132,20 +132,41 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 					{
 						"fullname": "Gabriel Gabriel",
 						"email": "gabriel",
-						"password": "passw123",
+						"password": "password123",
 						"document": {
 							"type": "not exists",
 							"value": "070.910.549-54"
 						},
 						"wallet": {
-							"currency": "BRL",
+							"currency": "NGN",
 							"amount": 100
 						},
 						"type": "not exists"
 					}`,
 				),
 			},
-			expectedBody:       `{"errors":["invalid type document","invalid email","invalid type user"]}`,
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/users","code":"invalid_request","errors":[{"field":"document.type","code":"invalid_document_type","message":"invalid type document"},{"field":"email","code":"invalid_email","message":"invalid email"},{"field":"password","code":"password_too_weak","message":"password must have upper case and lower case letters and digits"},{"field":"type","code":"invalid_user_type","message":"invalid type user"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error create user unknown field",
+			fields: fields{
+				uc:  stubCreateUserUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				rawPayload: []byte(`
+					{
+						"fullname": "Gabriel Gabriel",
+						"email": "gabriel@hotmail.com",
+						"password": "Passw0rd123",
+						"roles": {
+							"can_transfer": true
+						}
+					}`,
+				),
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/users","code":"invalid_request","errors":[{"field":"roles","code":"unknown_field","message":"unknown field"}]}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
@@ -162,20 +183,20 @@ func TestCreateUserHandler_Handle(t *testing.T) {
 				{
 						"fullname": "Gabriel Gabriel",
 						"email": "gabriel@hotmail.com",
-						"password": "passw123",
+						"password": "Passw0rd123",
 						"document": {
 							"type": "CPF",
 							"value": "070.910.549-54"
 						},
 						"wallet": {
-							"currency": "BRL",
+							"currency": "NGN",
 							"amount": 100
 						},
 						"type": "merchant"
 					}`,
 				),
 			},
-			expectedBody:       `{"errors":["db_error"]}`,
+			expectedBody:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"instance":"/users","code":"internal_error"}`,
 			expectedStatusCode: http.StatusInternalServerError,
 		},
 	}
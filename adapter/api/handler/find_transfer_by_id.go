@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+// FindTransferByIDHandler defines the dependencies of the HTTP handler for the use case
+type FindTransferByIDHandler struct {
+	uc     usecase.FindTransferByIDUseCase
+	log    logger.Logger
+	logKey string
+}
+
+// NewFindTransferByIDHandler creates new FindTransferByIDHandler with its dependencies
+func NewFindTransferByIDHandler(uc usecase.FindTransferByIDUseCase, l logger.Logger) FindTransferByIDHandler {
+	return FindTransferByIDHandler{
+		uc:     uc,
+		log:    l,
+		logKey: "find_transfer_by_id",
+	}
+}
+
+// Handle handles http request
+func (f FindTransferByIDHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	f.log = f.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	reqID := mux.Vars(r)["transfer_id"]
+	if reqID == "" {
+		problem := response.NewProblem(r, errInvalidParameter)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       errInvalidParameter.Error(),
+			"http_status": problem.Status,
+		}).Errorf("invalid parameter")
+
+		problem.Send(w)
+		return
+	}
+
+	ID, err := vo.NewUuid(reqID)
+	if err != nil {
+		problem := response.NewProblem(r, validation.Field("transfer_id", err))
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("invalid uuid")
+
+		problem.Send(w)
+		return
+	}
+
+	caller, ok := middleware.UserID(r.Context())
+	if !ok {
+		problem := response.NewProblem(r, errUnauthenticated)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       errUnauthenticated.Error(),
+			"http_status": problem.Status,
+		}).Errorf("missing authenticated user")
+
+		problem.Send(w)
+		return
+	}
+
+	output, err := f.uc.Execute(r.Context(), usecase.FindTransferByIDInput{ID: ID, RequestedBy: caller})
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error fetching transfer by id")
+
+		problem.Send(w)
+		return
+	}
+
+	f.log.WithFields(logger.Fields{
+		"key":         f.logKey,
+		"http_status": http.StatusOK,
+	}).Infof("success when returning transfer by id")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
\ No newline at end of file
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	infralogger "github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type stubFindTransferByIDUseCase struct {
+	result usecase.FindTransferByIDOutput
+	err    error
+}
+
+func (s stubFindTransferByIDUseCase) Execute(_ context.Context, _ usecase.FindTransferByIDInput) (usecase.FindTransferByIDOutput, error) {
+	return s.result, s.err
+}
+
+type stubTransferRepoFinder struct {
+	result entity.Transfer
+}
+
+func (s stubTransferRepoFinder) FindByID(_ context.Context, _ vo.Uuid) (entity.Transfer, error) {
+	return s.result, nil
+}
+
+func (s stubTransferRepoFinder) FindByUser(_ context.Context, _ entity.TransferFilter) ([]entity.Transfer, error) {
+	return []entity.Transfer{s.result}, nil
+}
+
+func TestFindTransferByIDHandler_Handle(t *testing.T) {
+	var transfer = entity.NewTransfer(
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewMoneyNGN(vo.NewAmountTest(100)),
+		time.Time{},
+	)
+	_ = transfer.Authorize(time.Time{})
+	_ = transfer.Complete(time.Time{})
+
+	type fields struct {
+		uc  usecase.FindTransferByIDUseCase
+		log logger.Logger
+	}
+	type args struct {
+		ID        string
+		caller    vo.Uuid
+		anonymous bool
+	}
+	tests := []struct {
+		name               string
+		fields             fields
+		args               args
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "Success find transfer by id",
+			fields: fields{
+				uc: stubFindTransferByIDUseCase{
+					result: presenter.NewFindTransferByIDPresenter().Output(transfer),
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:     vo.NewUuidStaticTest().Value(),
+				caller: vo.NewUuidStaticTest(),
+			},
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":100,"currency":"NGN","credited_value":100,"credited_currency":"NGN","rate":"1","status":"COMPLETED","history":[{"to":"PENDING","at":"0001-01-01T00:00:00Z"},{"from":"PENDING","to":"AUTHORIZED","at":"0001-01-01T00:00:00Z"},{"from":"AUTHORIZED","to":"COMPLETED","at":"0001-01-01T00:00:00Z"}],"created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Error find transfer by id invalid uuid",
+			fields: fields{
+				uc:  stubFindTransferByIDUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:     "0db298eb",
+				caller: vo.NewUuidStaticTest(),
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/transfers/0db298eb","code":"invalid_request","errors":[{"field":"transfer_id","code":"invalid_uuid","message":"invalid uuid"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error find transfer by id not found",
+			fields: fields{
+				uc: stubFindTransferByIDUseCase{
+					err: entity.ErrNotFoundTransfer,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:     vo.NewUuidStaticTest().Value(),
+				caller: vo.NewUuidStaticTest(),
+			},
+			expectedBody:       `{"type":"/problems/transfer_not_found","title":"Not Found","status":404,"detail":"not found transfer","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791","code":"transfer_not_found"}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error find transfer by id requested by a stranger",
+			fields: fields{
+				uc: usecase.NewFindTransferByIDInteractor(
+					stubTransferRepoFinder{result: transfer},
+					presenter.NewFindTransferByIDPresenter(),
+				),
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:     vo.NewUuidStaticTest().Value(),
+				caller: vo.NewUuidRandom(),
+			},
+			expectedBody:       `{"type":"/problems/transfer_not_found","title":"Not Found","status":404,"detail":"not found transfer","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791","code":"transfer_not_found"}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error find transfer by id without authenticated user",
+			fields: fields{
+				uc:  stubFindTransferByIDUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:        vo.NewUuidStaticTest().Value(),
+				anonymous: true,
+			},
+			expectedBody:       `{"type":"/problems/unauthenticated","title":"Unauthorized","status":401,"detail":"missing authenticated user","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791","code":"unauthenticated"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/transfers/%s", tt.args.ID)
+			req, _ := http.NewRequest(http.MethodGet, uri, nil)
+
+			req = mux.SetURLVars(req, map[string]string{"transfer_id": tt.args.ID})
+
+			if !tt.args.anonymous {
+				req = req.WithContext(middleware.WithUserID(req.Context(), tt.args.caller))
+			}
+
+			var (
+				w       = httptest.NewRecorder()
+				handler = NewFindTransferByIDHandler(tt.fields.uc, tt.fields.log)
+			)
+
+			handler.Handle(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
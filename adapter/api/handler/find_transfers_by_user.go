@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+var (
+	errInvalidDirection = fault.New(fault.Invalid, "invalid_direction", "invalid direction, expected sent or received")
+	errInvalidFrom      = fault.New(fault.Invalid, "invalid_from", "invalid from, expected RFC3339 date")
+	errInvalidTo        = fault.New(fault.Invalid, "invalid_to", "invalid to, expected RFC3339 date")
+	errInvalidMinValue  = fault.New(fault.Invalid, "invalid_min_value", "invalid min_value")
+	errInvalidMaxValue  = fault.New(fault.Invalid, "invalid_max_value", "invalid max_value")
+	errInvalidLimit     = fault.New(fault.Invalid, "invalid_limit", "invalid limit")
+	errInvalidRange     = fault.New(fault.Invalid, "invalid_range", "invalid range, from must be before to and min_value lower than max_value")
+)
+
+// FindTransfersByUserHandler defines the dependencies of the HTTP handler for the use case
+type FindTransfersByUserHandler struct {
+	uc     usecase.FindTransfersByUserUseCase
+	log    logger.Logger
+	logKey string
+}
+
+// NewFindTransfersByUserHandler creates new FindTransfersByUserHandler with its dependencies
+func NewFindTransfersByUserHandler(uc usecase.FindTransfersByUserUseCase, l logger.Logger) FindTransfersByUserHandler {
+	return FindTransfersByUserHandler{
+		uc:     uc,
+		log:    l,
+		logKey: "find_transfers_by_user",
+	}
+}
+
+// Handle handles http request
+func (f FindTransfersByUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	f.log = f.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	input, errs := f.validate(mux.Vars(r)["user_id"], r.URL.Query())
+	if len(errs) > 0 {
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       "invalid input",
+			"http_status": http.StatusBadRequest,
+		}).Errorf("failed to data")
+
+		response.NewValidationProblem(r, errs).Send(w)
+		return
+	}
+
+	if err := authorizeUser(r, input.UserID, errForbiddenUser); err != nil {
+		problem := response.NewProblem(r, err)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("access to transfers of another user")
+
+		problem.Send(w)
+		return
+	}
+
+	output, err := f.uc.Execute(r.Context(), input)
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error fetching transfers by user")
+
+		problem.Send(w)
+		return
+	}
+
+	f.log.WithFields(logger.Fields{
+		"key":         f.logKey,
+		"http_status": http.StatusOK,
+	}).Infof("success when returning transfers by user")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+func (f FindTransfersByUserHandler) validate(userID string, q url.Values) (usecase.FindTransfersByUserInput, []error) {
+	var (
+		input = usecase.FindTransfersByUserInput{Cursor: q.Get("cursor")}
+		errs  []error
+		err   error
+	)
+
+	if input.UserID, err = vo.NewUuid(userID); err != nil {
+		errs = append(errs, validation.Field("user_id", err))
+	}
+
+	switch direction := entity.TransferDirection(q.Get("direction")); direction {
+	case "", entity.TransferSent, entity.TransferReceived:
+		input.Direction = direction
+	default:
+		errs = append(errs, validation.Field("direction", errInvalidDirection))
+	}
+
+	if v := q.Get("from"); v != "" {
+		if input.From, err = time.Parse(time.RFC3339, v); err != nil {
+			errs = append(errs, validation.Field("from", errInvalidFrom))
+		}
+	}
+
+	if v := q.Get("to"); v != "" {
+		if input.To, err = time.Parse(time.RFC3339, v); err != nil {
+			errs = append(errs, validation.Field("to", errInvalidTo))
+		}
+	}
+
+	if v := q.Get("min_value"); v != "" {
+		if input.MinValue, err = parseAmount(v); err != nil {
+			errs = append(errs, validation.Field("min_value", errInvalidMinValue))
+		}
+	}
+
+	if v := q.Get("max_value"); v != "" {
+		if input.MaxValue, err = parseAmount(v); err != nil {
+			errs = append(errs, validation.Field("max_value", errInvalidMaxValue))
+		}
+	}
+
+	if v := q.Get("status"); v != "" {
+		if input.Status, err = vo.NewTransferStatus(v); err != nil {
+			errs = append(errs, validation.Field("status", err))
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if input.Limit, err = strconv.Atoi(v); err != nil || input.Limit <= 0 {
+			errs = append(errs, validation.Field("limit", errInvalidLimit))
+		}
+	}
+
+	if (!input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To)) ||
+		(input.MaxValue.Value() > 0 && input.MinValue.Value() > input.MaxValue.Value()) {
+		errs = append(errs, errInvalidRange)
+	}
+
+	return input, errs
+}
+
+func parseAmount(value string) (vo.Amount, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return vo.Amount{}, err
+	}
+
+	return vo.NewAmount(v)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	infralogger "github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type stubFindTransfersByUserUseCase struct {
+	result usecase.FindTransfersByUserOutput
+	err    error
+}
+
+func (s stubFindTransfersByUserUseCase) Execute(_ context.Context, _ usecase.FindTransfersByUserInput) (usecase.FindTransfersByUserOutput, error) {
+	return s.result, s.err
+}
+
+func TestFindTransfersByUserHandler_Handle(t *testing.T) {
+	var transfer = entity.NewTransfer(
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewMoneyNGN(vo.NewAmountTest(100)),
+		time.Time{},
+	)
+
+	type fields struct {
+		uc  usecase.FindTransfersByUserUseCase
+		log logger.Logger
+	}
+	type args struct {
+		ID        string
+		query     string
+		anonymous bool
+	}
+	tests := []struct {
+		name               string
+		fields             fields
+		args               args
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "Success find transfers by user",
+			fields: fields{
+				uc: stubFindTransfersByUserUseCase{
+					result: presenter.NewFindTransfersByUserPresenter().Output(
+						vo.NewUuidStaticTest(),
+						[]entity.Transfer{transfer},
+						"next",
+					),
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:    vo.NewUuidStaticTest().Value(),
+				query: "direction=sent&from=2021-01-01T00:00:00Z&to=2021-02-01T00:00:00Z&min_value=10&max_value=1000&status=COMPLETED&limit=10",
+			},
+			expectedBody:       `{"transfers":[{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","direction":"sent","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":100,"currency":"NGN","status":"PENDING","created_at":"0001-01-01T00:00:00Z"}],"next_cursor":"next"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Error find transfers by user invalid filters",
+			fields: fields{
+				uc:  stubFindTransfersByUserUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:    vo.NewUuidStaticTest().Value(),
+				query: "direction=both&from=yesterday&min_value=-1&status=DONE&limit=0",
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791/transfers","code":"invalid_request","errors":[{"field":"direction","code":"invalid_direction","message":"invalid direction, expected sent or received"},{"field":"from","code":"invalid_from","message":"invalid from, expected RFC3339 date"},{"field":"min_value","code":"invalid_min_value","message":"invalid min_value"},{"field":"status","code":"invalid_transfer_status","message":"invalid transfer status"},{"field":"limit","code":"invalid_limit","message":"invalid limit"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error find transfers by user invalid range",
+			fields: fields{
+				uc:  stubFindTransfersByUserUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:    vo.NewUuidStaticTest().Value(),
+				query: "min_value=100&max_value=10",
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791/transfers","code":"invalid_request","errors":[{"code":"invalid_range","message":"invalid range, from must be before to and min_value lower than max_value"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error find transfers by user invalid cursor",
+			fields: fields{
+				uc: stubFindTransfersByUserUseCase{
+					err: entity.ErrInvalidTransferCursor,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:    vo.NewUuidStaticTest().Value(),
+				query: "cursor=abc",
+			},
+			expectedBody:       `{"type":"/problems/invalid_cursor","title":"Bad Request","status":400,"detail":"invalid transfer cursor","instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791/transfers","code":"invalid_cursor"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error find transfers by user not found",
+			fields: fields{
+				uc: stubFindTransfersByUserUseCase{
+					err: entity.ErrNotFoundUser,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: vo.NewUuidStaticTest().Value(),
+			},
+			expectedBody:       `{"type":"/problems/user_not_found","title":"Not Found","status":404,"detail":"not found user","instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791/transfers","code":"user_not_found"}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error find transfers of another user",
+			fields: fields{
+				uc:  stubFindTransfersByUserUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: "3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01",
+			},
+			expectedBody:       `{"type":"/problems/forbidden_user","title":"Forbidden","status":403,"detail":"access to another user is forbidden","instance":"/users/3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01/transfers","code":"forbidden_user"}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/users/%s/transfers?%s", tt.args.ID, tt.args.query)
+			req, _ := http.NewRequest(http.MethodGet, uri, nil)
+
+			req = mux.SetURLVars(req, map[string]string{"user_id": tt.args.ID})
+
+			if !tt.args.anonymous {
+				req = req.WithContext(middleware.WithUserID(req.Context(), vo.NewUuidStaticTest()))
+			}
+
+			var (
+				w       = httptest.NewRecorder()
+				handler = NewFindTransfersByUserHandler(tt.fields.uc, tt.fields.log)
+			)
+
+			handler.Handle(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
@@ -1,13 +1,12 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
-	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 	"github.com/ofiliobi/urban-octo-fortnight/usecase"
 )
@@ -36,51 +35,52 @@ func (f FindUserByIDHandler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	reqID := mux.Vars(r)["user_id"]
 	if reqID == "" {
-		err := errors.New("invalid parameter")
+		problem := response.NewProblem(r, errInvalidParameter)
 		f.log.WithFields(logger.Fields{
 			"key":         f.logKey,
-			"error":       err.Error(),
-			"http_status": http.StatusBadRequest,
+			"error":       errInvalidParameter.Error(),
+			"http_status": problem.Status,
 		}).Errorf("invalid parameter")
 
-		response.NewError(err, http.StatusBadRequest).Send(w)
+		problem.Send(w)
 		return
 	}
 
 	ID, err := vo.NewUuid(reqID)
 	if err != nil {
-		err := errors.New("invalid uuid")
+		problem := response.NewProblem(r, validation.Field("user_id", err))
 		f.log.WithFields(logger.Fields{
 			"key":         f.logKey,
 			"error":       err.Error(),
-			"http_status": http.StatusBadRequest,
+			"http_status": problem.Status,
 		}).Errorf("invalid uuid")
 
-		response.NewError(err, http.StatusBadRequest).Send(w)
+		problem.Send(w)
 		return
 	}
 
-	output, err := f.uc.Execute(r.Context(), usecase.FindUserByIDInput{ID: ID})
-	if err != nil {
-		switch err {
-		case entity.ErrNotFoundUser:
-			f.log.WithFields(logger.Fields{
-				"key":         f.logKey,
-				"error":       err.Error(),
-				"http_status": http.StatusNotFound,
-			}).Errorf("error fetching user by id")
+	if err := authorizeUser(r, ID, errForbiddenUser); err != nil {
+		problem := response.NewProblem(r, err)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("access to another user")
 
-			response.NewError(err, http.StatusNotFound).Send(w)
-		default:
-			f.log.WithFields(logger.Fields{
-				"key":         f.logKey,
-				"error":       err.Error(),
-				"http_status": http.StatusInternalServerError,
-			}).Errorf("error fetching user by id")
+		problem.Send(w)
+		return
+	}
 
-			response.NewError(err, http.StatusInternalServerError).Send(w)
-		}
+	output, err := f.uc.Execute(r.Context(), usecase.FindUserByIDInput{ID: ID})
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		f.log.WithFields(logger.Fields{
+			"key":         f.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error fetching user by id")
 
+		problem.Send(w)
 		return
 	}
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
@@ -34,7 +35,8 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 		log logger.Logger
 	}
 	type args struct {
-		ID string
+		ID        string
+		anonymous bool
 	}
 	tests := []struct {
 		name               string
@@ -52,7 +54,7 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Common user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CPF, "07091054954"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Time{},
@@ -64,7 +66,7 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 			args: args{
 				ID: vo.NewUuidStaticTest().Value(),
 			},
-			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","fullname":"Common user","email":"test@testing.com","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"BRL","amount":100},"roles":{"can_transfer":true},"type":"COMMON","created_at":"0001-01-01T00:00:00Z"}`,
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","fullname":"Common user","email":"test@testing.com","document":{"type":"CPF","value":"07091054954"},"wallet":{"currency":"NGN","amount":100},"roles":{"can_transfer":true},"type":"COMMON","created_at":"0001-01-01T00:00:00Z"}`,
 			expectedStatusCode: http.StatusOK,
 		},
 		{
@@ -74,7 +76,7 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 				log: infralogger.Dummy{},
 			},
 			args:               args{},
-			expectedBody:       `{"errors":["invalid parameter"]}`,
+			expectedBody:       `{"type":"/problems/invalid_parameter","title":"Bad Request","status":400,"detail":"invalid parameter","instance":"/users/","code":"invalid_parameter"}`,
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
@@ -89,7 +91,7 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 			args: args{
 				ID: vo.NewUuidStaticTest().Value(),
 			},
-			expectedBody:       `{"errors":["db_error"]}`,
+			expectedBody:       `{"type":"/problems/internal_error","title":"Internal Server Error","status":500,"instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791","code":"internal_error"}`,
 			expectedStatusCode: http.StatusInternalServerError,
 		},
 		{
@@ -104,9 +106,21 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 			args: args{
 				ID: vo.NewUuidStaticTest().Value(),
 			},
-			expectedBody:       `{"errors":["not found user"]}`,
+			expectedBody:       `{"type":"/problems/user_not_found","title":"Not Found","status":404,"detail":"not found user","instance":"/users/0db298eb-c8e7-4829-84b7-c1036b4f0791","code":"user_not_found"}`,
 			expectedStatusCode: http.StatusNotFound,
 		},
+		{
+			name: "Error find another user by id",
+			fields: fields{
+				uc:  stubFindUserByIDUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: "3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01",
+			},
+			expectedBody:       `{"type":"/problems/forbidden_user","title":"Forbidden","status":403,"detail":"access to another user is forbidden","instance":"/users/3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01","code":"forbidden_user"}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,6 +129,10 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 
 			req = mux.SetURLVars(req, map[string]string{"user_id": tt.args.ID})
 
+			if !tt.args.anonymous {
+				req = req.WithContext(middleware.WithUserID(req.Context(), vo.NewUuidStaticTest()))
+			}
+
 			var (
 				w       = httptest.NewRecorder()
 				handler = NewFindUserByIDHandler(tt.fields.uc, tt.fields.log)
@@ -142,4 +160,4 @@ func TestFindUserByIDHandler_Execute(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
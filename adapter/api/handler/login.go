@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+var (
+	errMissingPassword = fault.New(fault.Invalid, "missing_password", "missing password")
+)
+
+type (
+	// Request data
+	LoginRequest struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	// LoginHandler defines the dependencies of the HTTP handler for the use case
+	LoginHandler struct {
+		uc     usecase.LoginUseCase
+		log    logger.Logger
+		logKey string
+	}
+)
+
+// NewLoginHandler creates new LoginHandler with its dependencies
+func NewLoginHandler(uc usecase.LoginUseCase, log logger.Logger) LoginHandler {
+	return LoginHandler{
+		uc:     uc,
+		log:    log,
+		logKey: "login",
+	}
+}
+
+// Handle handles http request
+func (l LoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	l.log = l.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	var reqData LoginRequest
+	if err := validation.DecodeJSON(w, r, &reqData); err != nil {
+		problem := response.NewProblem(r, err)
+		l.log.WithFields(logger.Fields{
+			"key":         l.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("failed to marshal message")
+
+		problem.Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	input, errs := l.validate(reqData)
+	if len(errs) > 0 {
+		l.log.WithFields(logger.Fields{
+			"key":         l.logKey,
+			"error":       "invalid input",
+			"http_status": http.StatusBadRequest,
+		}).Errorf("failed to data")
+
+		response.NewValidationProblem(r, errs).Send(w)
+		return
+	}
+
+	output, err := l.uc.Execute(r.Context(), input)
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		l.log.WithFields(logger.Fields{
+			"key":         l.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error when logging in")
+
+		problem.Send(w)
+		return
+	}
+
+	l.log.WithFields(logger.Fields{
+		"key":         l.logKey,
+		"http_status": http.StatusOK,
+	}).Infof("success logging in")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
+
+func (l LoginHandler) validate(i LoginRequest) (usecase.LoginInput, []error) {
+	var errs []error
+	email, err := vo.NewEmail(i.Email)
+	if err != nil {
+		errs = append(errs, validation.Field("email", err))
+	}
+	if i.Password == "" {
+		errs = append(errs, validation.Field("password", errMissingPassword))
+	}
+
+	return usecase.LoginInput{
+		Email:     email,
+		Password:  i.Password,
+		CreatedAt: time.Now(),
+	}, errs
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+// ReconcileWalletHandler defines the dependencies of the HTTP handler for the use case
+type ReconcileWalletHandler struct {
+	uc     usecase.ReconcileWalletUseCase
+	log    logger.Logger
+	logKey string
+}
+
+// NewReconcileWalletHandler creates new ReconcileWalletHandler with its dependencies
+func NewReconcileWalletHandler(uc usecase.ReconcileWalletUseCase, l logger.Logger) ReconcileWalletHandler {
+	return ReconcileWalletHandler{
+		uc:     uc,
+		log:    l,
+		logKey: "reconcile_wallet",
+	}
+}
+
+// Handle handles http request
+func (h ReconcileWalletHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	h.log = h.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	ID, err := vo.NewUuid(mux.Vars(r)["user_id"])
+	if err != nil {
+		problem := response.NewProblem(r, validation.Field("user_id", err))
+		h.log.WithFields(logger.Fields{
+			"key":         h.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("invalid uuid")
+
+		problem.Send(w)
+		return
+	}
+
+	if err := authorizeUser(r, ID, errForbiddenUser); err != nil {
+		problem := response.NewProblem(r, err)
+		h.log.WithFields(logger.Fields{
+			"key":         h.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("access to another user")
+
+		problem.Send(w)
+		return
+	}
+
+	output, err := h.uc.Execute(r.Context(), usecase.ReconcileWalletInput{UserID: ID})
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		h.log.WithFields(logger.Fields{
+			"key":         h.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error reconciling wallet")
+
+		problem.Send(w)
+		return
+	}
+
+	h.log.WithFields(logger.Fields{
+		"key":         h.logKey,
+		"http_status": http.StatusOK,
+	}).Infof("success reconciling wallet")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+var (
+	errMissingRefreshToken = fault.New(fault.Invalid, "missing_refresh_token", "missing refresh token")
+)
+
+type (
+	// Request data
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	// RefreshTokenHandler defines the dependencies of the HTTP handler for the use case
+	RefreshTokenHandler struct {
+		uc     usecase.RefreshTokenUseCase
+		log    logger.Logger
+		logKey string
+	}
+)
+
+// NewRefreshTokenHandler creates new RefreshTokenHandler with its dependencies
+func NewRefreshTokenHandler(uc usecase.RefreshTokenUseCase, log logger.Logger) RefreshTokenHandler {
+	return RefreshTokenHandler{
+		uc:     uc,
+		log:    log,
+		logKey: "refresh_token",
+	}
+}
+
+// Handle handles http request
+func (rt RefreshTokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	rt.log = rt.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	var reqData RefreshTokenRequest
+	if err := validation.DecodeJSON(w, r, &reqData); err != nil {
+		problem := response.NewProblem(r, err)
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("failed to marshal message")
+
+		problem.Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	if reqData.RefreshToken == "" {
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       "invalid input",
+			"http_status": http.StatusBadRequest,
+		}).Errorf("failed to data")
+
+		response.NewProblem(r, validation.Field("refresh_token", errMissingRefreshToken)).Send(w)
+		return
+	}
+
+	output, err := rt.uc.Execute(r.Context(), usecase.RefreshTokenInput{
+		RefreshToken: reqData.RefreshToken,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error when refreshing the tokens")
+
+		problem.Send(w)
+		return
+	}
+
+	rt.log.WithFields(logger.Fields{
+		"key":         rt.logKey,
+		"http_status": http.StatusOK,
+	}).Infof("success refreshing the tokens")
+
+	response.NewSuccess(output, http.StatusOK).Send(w)
+}
@@ -0,0 +1,6 @@
+package handler
+
+import "github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+
+// errInvalidParameter is returned when a parameter of the path is missing
+var errInvalidParameter = fault.New(fault.Invalid, "invalid_parameter", "invalid parameter")
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/validation"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+var errInvalidRefundValue = fault.New(fault.Invalid, "invalid_refund_value", "invalid refund value")
+
+type (
+	// Request data
+	ReverseTransferRequest struct {
+		// Value is optional, when omitted the whole refundable value is reversed
+		Value int64 `json:"value"`
+	}
+
+	// ReverseTransferHandler defines the dependencies of the HTTP handler for the use case
+	ReverseTransferHandler struct {
+		uc     usecase.ReverseTransferUseCase
+		log    logger.Logger
+		logKey string
+	}
+)
+
+// NewReverseTransferHandler creates new ReverseTransferHandler with its dependencies
+func NewReverseTransferHandler(uc usecase.ReverseTransferUseCase, log logger.Logger) ReverseTransferHandler {
+	return ReverseTransferHandler{
+		uc:     uc,
+		log:    log,
+		logKey: "reverse_transfer",
+	}
+}
+
+// Handle handles http request
+func (rt ReverseTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	rt.log = rt.log.WithFields(logger.Fields{
+		"correlation_id": r.Context().Value("correlation_id"),
+	})
+
+	var reqData ReverseTransferRequest
+	if err := validation.DecodeJSON(w, r, &reqData); err != nil && err != validation.ErrEmptyBody {
+		problem := response.NewProblem(r, err)
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("failed to marshal message")
+
+		problem.Send(w)
+		return
+	}
+	defer r.Body.Close()
+
+	input, errs := rt.validate(reqData, mux.Vars(r)["transfer_id"])
+	if len(errs) > 0 {
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       "invalid input",
+			"http_status": http.StatusBadRequest,
+		}).Errorf("failed to data")
+
+		response.NewValidationProblem(r, errs).Send(w)
+		return
+	}
+
+	caller, ok := middleware.UserID(r.Context())
+	if !ok {
+		problem := response.NewProblem(r, errUnauthenticated)
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       errUnauthenticated.Error(),
+			"http_status": problem.Status,
+		}).Errorf("missing authenticated user")
+
+		problem.Send(w)
+		return
+	}
+	input.RequestedBy = caller
+
+	output, err := rt.uc.Execute(r.Context(), input)
+	if err != nil {
+		problem := response.NewProblem(r, err)
+		rt.log.WithFields(logger.Fields{
+			"key":         rt.logKey,
+			"error":       err.Error(),
+			"http_status": problem.Status,
+		}).Errorf("error when reversing a transfer")
+
+		problem.Send(w)
+		return
+	}
+
+	rt.log.WithFields(logger.Fields{
+		"key":         rt.logKey,
+		"http_status": http.StatusCreated,
+	}).Infof("success reversing transfer")
+
+	response.NewSuccess(output, http.StatusCreated).Send(w)
+}
+
+func (rt ReverseTransferHandler) validate(i ReverseTransferRequest, transferID string) (usecase.ReverseTransferInput, []error) {
+	var errs []error
+	id, err := vo.NewUuid(uuid.New().String())
+	if err != nil {
+		errs = append(errs, err)
+	}
+	originalID, err := vo.NewUuid(transferID)
+	if err != nil {
+		errs = append(errs, validation.Field("transfer_id", err))
+	}
+	amount, err := vo.NewAmount(i.Value)
+	if err != nil {
+		errs = append(errs, validation.Field("value", errInvalidRefundValue))
+	}
+
+	return usecase.ReverseTransferInput{
+		ID:         id,
+		TransferID: originalID,
+		Value:      amount,
+		CreatedAt:  time.Now(),
+	}, errs
+}
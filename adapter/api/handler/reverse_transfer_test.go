@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	infralogger "github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type stubReverseTransferUseCase struct {
+	result usecase.ReverseTransferOutput
+	err    error
+}
+
+func (s stubReverseTransferUseCase) Execute(_ context.Context, _ usecase.ReverseTransferInput) (usecase.ReverseTransferOutput, error) {
+	return s.result, s.err
+}
+
+func TestReverseTransferHandler_Handle(t *testing.T) {
+	var original = entity.NewTransfer(
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewMoneyNGN(vo.NewAmountTest(100)),
+		time.Time{},
+	)
+	_ = original.Authorize(time.Time{})
+	_ = original.Complete(time.Time{})
+	_ = original.Refund(vo.NewMoneyNGN(vo.NewAmountTest(40)), time.Time{})
+
+	reversal, _ := entity.NewReversal(vo.NewUuidStaticTest(), original, vo.NewMoneyNGN(vo.NewAmountTest(40)), time.Time{})
+	_ = reversal.Authorize(time.Time{})
+	_ = reversal.Complete(time.Time{})
+
+	type fields struct {
+		uc  usecase.ReverseTransferUseCase
+		log logger.Logger
+	}
+	type args struct {
+		ID        string
+		rawBody   []byte
+		anonymous bool
+	}
+	tests := []struct {
+		name               string
+		fields             fields
+		args               args
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			name: "Success partial reversal",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					result: presenter.NewReverseTransferPresenter().Output(reversal, original),
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:      vo.NewUuidStaticTest().Value(),
+				rawBody: []byte(`{"value": 40}`),
+			},
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","reversal_of":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":40,"currency":"NGN","credited_value":40,"credited_currency":"NGN","rate":"1","status":"COMPLETED","original":{"status":"COMPLETED","refunded":40,"refundable":60},"created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Success full reversal without body",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					result: presenter.NewReverseTransferPresenter().Output(reversal, original),
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: vo.NewUuidStaticTest().Value(),
+			},
+			expectedBody:       `{"id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","reversal_of":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":40,"currency":"NGN","credited_value":40,"credited_currency":"NGN","rate":"1","status":"COMPLETED","original":{"status":"COMPLETED","refunded":40,"refundable":60},"created_at":"0001-01-01T00:00:00Z"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Error reversal invalid value",
+			fields: fields{
+				uc:  stubReverseTransferUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:      vo.NewUuidStaticTest().Value(),
+				rawBody: []byte(`{"value": -1}`),
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"invalid_request","errors":[{"field":"value","code":"invalid_refund_value","message":"invalid refund value"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error reversal invalid uuid",
+			fields: fields{
+				uc:  stubReverseTransferUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: "0db298eb",
+			},
+			expectedBody:       `{"type":"/problems/invalid_request","title":"Bad Request","status":400,"detail":"the request has invalid fields","instance":"/transfers/0db298eb/reversals","code":"invalid_request","errors":[{"field":"transfer_id","code":"invalid_uuid","message":"invalid uuid"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error reversal not found",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					err: entity.ErrNotFoundTransfer,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: vo.NewUuidStaticTest().Value(),
+			},
+			expectedBody:       `{"type":"/problems/transfer_not_found","title":"Not Found","status":404,"detail":"not found transfer","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"transfer_not_found"}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error reversal exceeds the original value",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					err: entity.ErrRefundExceedsTransfer,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:      vo.NewUuidStaticTest().Value(),
+				rawBody: []byte(`{"value": 1000}`),
+			},
+			expectedBody:       `{"type":"/problems/refund_exceeds_transfer","title":"Unprocessable Entity","status":422,"detail":"refund exceeds the refundable value of the transfer","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"refund_exceeds_transfer"}`,
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Error reversal of a transfer already reversed",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					err: entity.ErrInvalidTransferTransition,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: vo.NewUuidStaticTest().Value(),
+			},
+			expectedBody:       `{"type":"/problems/invalid_transfer_transition","title":"Conflict","status":409,"detail":"invalid transfer status transition","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"invalid_transfer_transition"}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name: "Error reversal without an authenticated user",
+			fields: fields{
+				uc:  stubReverseTransferUseCase{},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID:        vo.NewUuidStaticTest().Value(),
+				anonymous: true,
+			},
+			expectedBody:       `{"type":"/problems/unauthenticated","title":"Unauthorized","status":401,"detail":"missing authenticated user","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"unauthenticated"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Error reversal requested by someone other than the payee",
+			fields: fields{
+				uc: stubReverseTransferUseCase{
+					err: entity.ErrReversalNotAllowed,
+				},
+				log: infralogger.Dummy{},
+			},
+			args: args{
+				ID: vo.NewUuidStaticTest().Value(),
+			},
+			expectedBody:       `{"type":"/problems/reversal_not_allowed","title":"Forbidden","status":403,"detail":"only the payee of a transfer can reverse it","instance":"/transfers/0db298eb-c8e7-4829-84b7-c1036b4f0791/reversals","code":"reversal_not_allowed"}`,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := fmt.Sprintf("/transfers/%s/reversals", tt.args.ID)
+			req, _ := http.NewRequest(http.MethodPost, uri, bytes.NewReader(tt.args.rawBody))
+
+			req = mux.SetURLVars(req, map[string]string{"transfer_id": tt.args.ID})
+
+			if !tt.args.anonymous {
+				req = req.WithContext(middleware.WithUserID(req.Context(), vo.NewUuidStaticTest()))
+			}
+
+			var (
+				w       = httptest.NewRecorder()
+				handler = NewReverseTransferHandler(tt.fields.uc, tt.fields.log)
+			)
+
+			handler.Handle(w, req)
+
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf(
+					"[TestCase '%s'] O handler retornou um HTTP status code inesperado: retornado '%v' esperado '%v'",
+					tt.name,
+					w.Code,
+					tt.expectedStatusCode,
+				)
+			}
+
+			var result = strings.TrimSpace(w.Body.String())
+			if !strings.EqualFold(result, tt.expectedBody) {
+				t.Errorf(
+					"[TestCase '%s'] Result: '%v' | Expected: '%v'",
+					tt.name,
+					result,
+					tt.expectedBody,
+				)
+			}
+		})
+	}
+}
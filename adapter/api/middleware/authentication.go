@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/response"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type userIDKey struct{}
+
+var (
+	errMissingBearerToken = fault.New(fault.Unauthenticated, "missing_bearer_token", "missing bearer token")
+
+	errInvalidAccessToken = fault.New(fault.Unauthenticated, "invalid_access_token", "invalid access token")
+)
+
+// TokenParser validates an access token and returns the ID of the user it was issued to
+type TokenParser interface {
+	Parse(token string) (vo.Uuid, error)
+}
+
+// Authentication requires a valid bearer access token on the request
+type Authentication struct {
+	tokens TokenParser
+}
+
+// NewAuthentication creates new Authentication with its dependencies
+func NewAuthentication(tokens TokenParser) *Authentication {
+	return &Authentication{tokens: tokens}
+}
+
+// Execute rejects requests without a valid bearer token and puts the authenticated user ID in the context
+func (a Authentication) Execute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			response.NewProblem(r, errMissingBearerToken).Send(w)
+			return
+		}
+
+		userID, err := a.tokens.Parse(strings.TrimSpace(header[len("Bearer "):]))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			response.NewProblem(r, errInvalidAccessToken).Send(w)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), userID)))
+	})
+}
+
+// WithUserID returns a copy of the context carrying the authenticated user ID
+func WithUserID(ctx context.Context, userID vo.Uuid) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserID returns the authenticated user ID of the context
+func UserID(ctx context.Context) (vo.Uuid, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(vo.Uuid)
+	return userID, ok
+}
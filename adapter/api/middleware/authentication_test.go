@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubTokenParser struct {
+	err error
+}
+
+func (s stubTokenParser) Parse(token string) (vo.Uuid, error) {
+	if token != "valid" {
+		return vo.Uuid{}, errors.New("invalid token")
+	}
+	return vo.NewUuidStaticTest(), s.err
+}
+
+func TestAuthentication_Execute(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		wantStatusCode int
+		wantBody       string
+		wantUserID     string
+	}{
+		{
+			name:           "Valid bearer token",
+			header:         "Bearer valid",
+			wantStatusCode: http.StatusOK,
+			wantUserID:     vo.NewUuidStaticTest().Value(),
+		},
+		{
+			name:           "Case insensitive scheme",
+			header:         "bearer valid",
+			wantStatusCode: http.StatusOK,
+			wantUserID:     vo.NewUuidStaticTest().Value(),
+		},
+		{
+			name:           "Missing authorization header",
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"type":"/problems/missing_bearer_token","title":"Unauthorized","status":401,"detail":"missing bearer token","instance":"/middleware","code":"missing_bearer_token"}`,
+		},
+		{
+			name:           "Basic authorization scheme",
+			header:         "Basic dXNlcjpwYXNz",
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"type":"/problems/missing_bearer_token","title":"Unauthorized","status":401,"detail":"missing bearer token","instance":"/middleware","code":"missing_bearer_token"}`,
+		},
+		{
+			name:           "Invalid bearer token",
+			header:         "Bearer invalid",
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       `{"type":"/problems/invalid_access_token","title":"Unauthorized","status":401,"detail":"invalid access token","instance":"/middleware","code":"invalid_access_token"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/middleware", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			var gotUserID string
+			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if userID, ok := UserID(r.Context()); ok {
+					gotUserID = userID.Value()
+				}
+			})
+
+			rr := httptest.NewRecorder()
+
+			handler := NewAuthentication(stubTokenParser{}).Execute(testHandler)
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatusCode {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, rr.Code, tt.wantStatusCode)
+			}
+
+			if body := strings.TrimSpace(rr.Body.String()); body != tt.wantBody {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, body, tt.wantBody)
+			}
+
+			if gotUserID != tt.wantUserID {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, gotUserID, tt.wantUserID)
+			}
+		})
+	}
+}
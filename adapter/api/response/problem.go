@@ -0,0 +1,139 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+const (
+	problemContentType = "application/problem+json"
+
+	// problemTypeBase prefixes the code of the error in the type of the problem
+	problemTypeBase = "/problems/"
+
+	validationCode = "invalid_request"
+)
+
+// statuses maps the kinds of error to the HTTP status codes, the errors not classified are internal errors
+var statuses = map[fault.Kind]int{
+	fault.Internal:        http.StatusInternalServerError,
+	fault.Invalid:         http.StatusBadRequest,
+	fault.Unauthenticated: http.StatusUnauthorized,
+	fault.Forbidden:       http.StatusForbidden,
+	fault.NotFound:        http.StatusNotFound,
+	fault.Conflict:        http.StatusConflict,
+	fault.Unprocessable:   http.StatusUnprocessableEntity,
+	fault.TooLarge:        http.StatusRequestEntityTooLarge,
+	fault.Unavailable:     http.StatusServiceUnavailable,
+}
+
+type (
+	// Problem defines the structure of errors for http responses, the problem details of RFC 7807
+	Problem struct {
+		Type          string       `json:"type"`
+		Title         string       `json:"title"`
+		Status        int          `json:"status"`
+		Detail        string       `json:"detail,omitempty"`
+		Instance      string       `json:"instance,omitempty"`
+		Code          string       `json:"code"`
+		CorrelationID string       `json:"correlation_id,omitempty"`
+		Errors        []FieldError `json:"errors,omitempty"`
+		Details       interface{}  `json:"details,omitempty"`
+	}
+
+	// fieldError is an error of a field of the request, e.g. a validation.FieldError
+	fieldError interface {
+		error
+		Field() string
+	}
+
+	// FieldError defines an invalid field of the request, the field is its JSON path
+	FieldError struct {
+		Field   string `json:"field,omitempty"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// NewProblem creates new Problem for the error, its kind sets the status and its code the type.
+// The message of an internal error is not sent, it is only logged
+func NewProblem(r *http.Request, err error) *Problem {
+	var f fieldError
+	if errors.As(err, &f) {
+		return NewValidationProblem(r, []error{err})
+	}
+
+	var (
+		status = StatusOf(err)
+		p      = newProblem(r, status, fault.CodeOf(err))
+	)
+
+	if status != http.StatusInternalServerError {
+		p.Detail = err.Error()
+	}
+
+	return p
+}
+
+// NewValidationProblem creates new Problem with the invalid fields of the request, the errors without a field
+// are errors of the whole request
+func NewValidationProblem(r *http.Request, errs []error) *Problem {
+	var p = newProblem(r, http.StatusBadRequest, validationCode)
+	p.Detail = "the request has invalid fields"
+
+	for _, err := range errs {
+		code := fault.CodeOf(err)
+		if fault.KindOf(err) == fault.Internal {
+			code = validationCode
+		}
+
+		var (
+			f     fieldError
+			field string
+		)
+		if errors.As(err, &f) {
+			field = f.Field()
+		}
+
+		p.Errors = append(p.Errors, FieldError{Field: field, Code: code, Message: err.Error()})
+	}
+
+	return p
+}
+
+func newProblem(r *http.Request, status int, code string) *Problem {
+	var p = &Problem{
+		Type:     problemTypeBase + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: r.URL.Path,
+		Code:     code,
+	}
+
+	if id, ok := r.Context().Value("correlation_id").(string); ok {
+		p.CorrelationID = id
+	}
+
+	return p
+}
+
+// StatusOf returns the HTTP status code of the kind of the error
+func StatusOf(err error) int {
+	return statuses[fault.KindOf(err)]
+}
+
+// WithDetails adds details about the error to the problem
+func (p *Problem) WithDetails(details interface{}) *Problem {
+	p.Details = details
+	return p
+}
+
+// Send returns a response with problem JSON format
+func (p Problem) Send(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
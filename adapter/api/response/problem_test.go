@@ -0,0 +1,115 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "Domain error",
+			err:  entity.ErrUserInsufficientBalance,
+			want: Problem{
+				Type:          "/problems/insufficient_balance",
+				Title:         "Unprocessable Entity",
+				Status:        http.StatusUnprocessableEntity,
+				Detail:        "user does not have sufficient balance",
+				Instance:      "/transfers",
+				Code:          "insufficient_balance",
+				CorrelationID: "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+			},
+		},
+		{
+			name: "Wrapped domain error",
+			err:  pkgerrors.Wrap(entity.ErrUnauthorizedTransfer, "reason code R01"),
+			want: Problem{
+				Type:          "/problems/transfer_denied",
+				Title:         "Unprocessable Entity",
+				Status:        http.StatusUnprocessableEntity,
+				Detail:        "reason code R01: unauthorized transfer",
+				Instance:      "/transfers",
+				Code:          "transfer_denied",
+				CorrelationID: "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+			},
+		},
+		{
+			name: "Internal error is not detailed",
+			err:  pkgerrors.Wrap(errors.New("connection refused"), entity.ErrCreateTransfer.Error()),
+			want: Problem{
+				Type:          "/problems/internal_error",
+				Title:         "Internal Server Error",
+				Status:        http.StatusInternalServerError,
+				Instance:      "/transfers",
+				Code:          "internal_error",
+				CorrelationID: "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "correlation_id", "0db298eb-c8e7-4829-84b7-c1036b4f0791"))
+
+			if got := NewProblem(req, tt.err); !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewValidationProblem(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+
+	got := NewValidationProblem(req, []error{vo.ErrInvalidEmail, errors.New("invalid name")})
+	want := []FieldError{
+		{Code: "invalid_email", Message: "invalid email"},
+		{Code: "invalid_request", Message: "invalid name"},
+	}
+
+	if got.Status != http.StatusBadRequest {
+		t.Errorf("[TestCase 'Validation problem'] Got: '%v' | Want: '%v'", got.Status, http.StatusBadRequest)
+	}
+
+	if !reflect.DeepEqual(got.Errors, want) {
+		t.Errorf("[TestCase 'Validation problem'] Got: '%+v' | Want: '%+v'", got.Errors, want)
+	}
+}
+
+func TestProblem_Send(t *testing.T) {
+	var (
+		w   = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/transfers/1", nil)
+	)
+
+	if err := NewProblem(req, entity.ErrNotFoundTransfer).Send(w); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("[TestCase 'Content type'] Got: '%v' | Want: '%v'", got, "application/problem+json")
+	}
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("[TestCase 'Status'] Got: '%v' | Want: '%v'", w.Code, http.StatusNotFound)
+	}
+
+	want := `{"type":"/problems/transfer_not_found","title":"Not Found","status":404,"detail":"not found transfer","instance":"/transfers/1","code":"transfer_not_found"}`
+	if got := strings.TrimSpace(w.Body.String()); got != want {
+		t.Errorf("[TestCase 'Body'] Got: '%v' | Want: '%v'", got, want)
+	}
+}
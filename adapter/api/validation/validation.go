@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+// MaxBodyBytes is the largest request body decoded, 1 MiB
+const MaxBodyBytes = 1 << 20
+
+var (
+	// ErrEmptyBody is returned when the request has no body, handlers with an optional body can accept it
+	ErrEmptyBody = fault.New(fault.Invalid, "empty_body", "empty request body")
+
+	ErrMalformedBody = fault.New(fault.Invalid, "malformed_body", "malformed JSON body")
+
+	ErrBodyTooLarge = fault.New(fault.TooLarge, "body_too_large", "request body is larger than 1 MiB")
+
+	ErrUnknownField = fault.New(fault.Invalid, "unknown_field", "unknown field")
+
+	ErrInvalidType = fault.New(fault.Invalid, "invalid_type", "invalid type")
+)
+
+// FieldError is the error of a field of the request, the field is its JSON path, e.g. document.value
+type FieldError struct {
+	field string
+	err   error
+}
+
+// Field returns the error of the field, nil when err is nil
+func Field(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &FieldError{field: field, err: err}
+}
+
+// Error returns the message of the error of the field
+func (e *FieldError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error of the field, it keeps its kind and its code
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+// Field returns the field property
+func (e *FieldError) Field() string {
+	return e.field
+}
+
+// DecodeJSON decodes the body of the request into dst. The body is at most MaxBodyBytes, holds a single JSON
+// value and has no field unknown to dst
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	var dec = json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return decodeError(err)
+		}
+		return ErrMalformedBody
+	}
+
+	return nil
+}
+
+// decodeError classifies the error of the JSON decoder
+func decodeError(err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		sizeErr   *http.MaxBytesError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrEmptyBody
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrMalformedBody
+	case errors.As(err, &typeErr):
+		return Field(typeErr.Field, ErrInvalidType)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return Field(strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`), ErrUnknownField)
+	case errors.As(err, &sizeErr):
+		return ErrBodyTooLarge
+	}
+
+	return fault.Wrap(err, fault.Invalid, ErrMalformedBody.Code())
+}
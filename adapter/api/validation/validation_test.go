@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type request struct {
+		Email  string `json:"email"`
+		Wallet struct {
+			Amount int64 `json:"amount"`
+		} `json:"wallet"`
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantErr   error
+		wantField string
+	}{
+		{
+			name: "Valid body",
+			body: `{"email":"test@testing.com","wallet":{"amount":100}}`,
+		},
+		{
+			name:    "Empty body",
+			body:    ``,
+			wantErr: ErrEmptyBody,
+		},
+		{
+			name:    "Malformed body",
+			body:    `{"email":`,
+			wantErr: ErrMalformedBody,
+		},
+		{
+			name:    "Invalid JSON",
+			body:    `{"email" "test@testing.com"}`,
+			wantErr: ErrMalformedBody,
+		},
+		{
+			name:    "Trailing data",
+			body:    `{"email":"test@testing.com"} {}`,
+			wantErr: ErrMalformedBody,
+		},
+		{
+			name:      "Unknown field",
+			body:      `{"email":"test@testing.com","admin":true}`,
+			wantErr:   ErrUnknownField,
+			wantField: "admin",
+		},
+		{
+			name:      "Invalid type of a nested field",
+			body:      `{"wallet":{"amount":"100"}}`,
+			wantErr:   ErrInvalidType,
+			wantField: "wallet.amount",
+		},
+		{
+			name:    "Body too large",
+			body:    `{"email":"` + strings.Repeat("a", MaxBodyBytes) + `"}`,
+			wantErr: ErrBodyTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				dst request
+				r   = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			)
+
+			err := DecodeJSON(httptest.NewRecorder(), r, &dst)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			var field string
+			if f, ok := err.(*FieldError); ok {
+				field = f.Field()
+			}
+
+			if field != tt.wantField {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, field, tt.wantField)
+			}
+		})
+	}
+}
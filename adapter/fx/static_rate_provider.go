@@ -0,0 +1,93 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type (
+	// RateFile defines the content of a rates file
+	RateFile struct {
+		Rates []RateFileEntry `json:"rates"`
+	}
+
+	// RateFileEntry defines a quoted rate of a rates file
+	RateFileEntry struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Rate string `json:"rate"`
+	}
+
+	staticRateProvider struct {
+		rates map[[2]vo.TypeCurrency]vo.ExchangeRate
+	}
+)
+
+// NewStaticRateProvider creates new rate provider that quotes the given rates, pairs are not inverted automatically
+func NewStaticRateProvider(rates ...vo.ExchangeRate) usecase.FXRateProvider {
+	var s = staticRateProvider{rates: make(map[[2]vo.TypeCurrency]vo.ExchangeRate)}
+	for _, r := range rates {
+		s.rates[[2]vo.TypeCurrency{r.From().Value(), r.To().Value()}] = r
+	}
+
+	return s
+}
+
+// NewFileRateProvider creates new static rate provider with the rates read from a JSON file
+func NewFileRateProvider(path string) (usecase.FXRateProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading rates file")
+	}
+
+	var file RateFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, errors.Wrap(err, "error decoding rates file")
+	}
+
+	var rates []vo.ExchangeRate
+	for _, entry := range file.Rates {
+		rate, err := entry.toExchangeRate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate %s/%s", entry.From, entry.To)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return NewStaticRateProvider(rates...), nil
+}
+
+// Rate returns the rate quoted for the pair
+func (s staticRateProvider) Rate(_ context.Context, from vo.Currency, to vo.Currency) (vo.ExchangeRate, error) {
+	if from == to {
+		return vo.NewIdentityExchangeRate(from), nil
+	}
+
+	rate, ok := s.rates[[2]vo.TypeCurrency{from.Value(), to.Value()}]
+	if !ok {
+		return vo.ExchangeRate{}, vo.ErrExchangeRateUnavailable
+	}
+
+	return rate, nil
+}
+
+func (e RateFileEntry) toExchangeRate() (vo.ExchangeRate, error) {
+	from, err := vo.NewCurrency(e.From)
+	if err != nil {
+		return vo.ExchangeRate{}, err
+	}
+
+	to, err := vo.NewCurrency(e.To)
+	if err != nil {
+		return vo.ExchangeRate{}, err
+	}
+
+	return vo.NewExchangeRate(from, to, e.Rate)
+}
@@ -0,0 +1,81 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func TestFileRateProvider_Rate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"rates":[{"from":"USD","to":"NGN","rate":"1500"}]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFileRateProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		usd, _ = vo.NewCurrency("USD")
+		ngn, _ = vo.NewCurrency("NGN")
+		gbp, _ = vo.NewCurrency("GBP")
+	)
+
+	tests := []struct {
+		name    string
+		from    vo.Currency
+		to      vo.Currency
+		want    vo.ExchangeRate
+		wantErr error
+	}{
+		{
+			name: "Quoted pair",
+			from: usd,
+			to:   ngn,
+			want: vo.NewExchangeRateTest(vo.USD, vo.NGN, "1500"),
+		},
+		{
+			name: "Same currency",
+			from: gbp,
+			to:   gbp,
+			want: vo.NewIdentityExchangeRate(gbp),
+		},
+		{
+			name:    "Inverse pair is not quoted",
+			from:    ngn,
+			to:      usd,
+			want:    vo.ExchangeRate{},
+			wantErr: vo.ErrExchangeRateUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.Rate(context.Background(), tt.from, tt.to)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFileRateProvider_InvalidRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"rates":[{"from":"USD","to":"NGN","rate":"-1"}]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileRateProvider(path); err == nil {
+		t.Errorf("[TestCase 'Invalid rate'] Got: '%v' | Want: an error", err)
+	}
+}
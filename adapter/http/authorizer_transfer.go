@@ -3,36 +3,57 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 	"github.com/ofiliobi/urban-octo-fortnight/usecase"
 	"github.com/pkg/errors"
 )
 
 const (
 	autorizado = "Autorizado"
+
+	correlationIDHeader = "X-Correlation-Id"
 )
 
 var (
 	errAuthorizationDenied = errors.New("authorization denied")
+
+	errAuthorizerUnavailable = fault.New(fault.Unavailable, "authorizer_unavailable", "authorizer unavailable")
 )
 
 type (
 	authorizer struct {
-		client HTTPGetter
+		client HTTPClient
 		log    logger.Logger
 		logKey string
 	}
 
+	// authorizerRequest is the transfer sent to the authorizer, the amount is in minor units
+	authorizerRequest struct {
+		TransferID string `json:"transfer_id"`
+		PayerID    string `json:"payer_id"`
+		PayeeID    string `json:"payee_id"`
+		Amount     int64  `json:"amount"`
+		Currency   string `json:"currency"`
+	}
+
+	// authorizerResponse is either a decision or the message of the legacy authorizer
 	authorizerResponse struct {
-		Message string
+		Decision   string `json:"decision"`
+		ReasonCode string `json:"reason_code"`
+		Message    string
 	}
 )
 
 // NewAuthorizer creates new authorizer with its dependencies
-func NewAuthorizer(client HTTPGetter, l logger.Logger) usecase.Authorizer {
+func NewAuthorizer(client HTTPClient, l logger.Logger) usecase.Authorizer {
 	return authorizer{
 		client: client,
 		log:    l,
@@ -40,37 +61,109 @@ func NewAuthorizer(client HTTPGetter, l logger.Logger) usecase.Authorizer {
 	}
 }
 
-// Authorized authorizes a transfer
-func (a authorizer) Authorized(_ context.Context, _ entity.Transfer) (bool, error) {
-	res, err := a.client.Get(os.Getenv("AUTHORIZER_URI"))
+// Authorized sends the transfer to the authorizer, with the method of AUTHORIZER_METHOD (POST by default) and
+// the correlation id of the request. A GET request carries the transfer in its query string
+func (a authorizer) Authorized(ctx context.Context, transfer entity.Transfer) (usecase.AuthorizationDecision, error) {
+	res, err := a.send(ctx, transfer)
 	if err != nil {
 		a.log.WithFields(logger.Fields{
-			"key":   a.logKey,
-			"error": err.Error(),
+			"key":            a.logKey,
+			"correlation_id": ctx.Value("correlation_id"),
+			"error":          err.Error(),
+		}).Errorf("failed to client")
+
+		return usecase.AuthorizationDecision{}, errAuthorizerUnavailable
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		a.log.WithFields(logger.Fields{
+			"key":            a.logKey,
+			"correlation_id": ctx.Value("correlation_id"),
+			"http_status":    res.StatusCode,
 		}).Errorf("failed to client")
 
-		return false, errAuthorizationDenied
+		return usecase.AuthorizationDecision{}, errAuthorizerUnavailable
 	}
 
 	b := &authorizerResponse{}
 	err = json.NewDecoder(res.Body).Decode(&b)
 	if err != nil {
 		a.log.WithFields(logger.Fields{
-			"key":   a.logKey,
-			"error": err.Error(),
+			"key":            a.logKey,
+			"correlation_id": ctx.Value("correlation_id"),
+			"error":          err.Error(),
 		}).Errorf("failed to marshal message")
 
-		return false, errAuthorizationDenied
+		return usecase.AuthorizationDecision{}, errAuthorizationDenied
 	}
 
-	if b.Message != autorizado {
-		return false, errAuthorizationDenied
-	}
+	decision := b.decision()
 
 	a.log.WithFields(logger.Fields{
-		"key":         a.logKey,
-		"http_status": res.StatusCode,
+		"key":            a.logKey,
+		"correlation_id": ctx.Value("correlation_id"),
+		"http_status":    res.StatusCode,
+		"decision":       string(decision.Outcome),
+		"reason_code":    decision.ReasonCode,
 	}).Infof("success to authorized")
 
-	return true, nil
-}
\ No newline at end of file
+	return decision, nil
+}
+
+func (a authorizer) send(ctx context.Context, transfer entity.Transfer) (*http.Response, error) {
+	var (
+		uri     = os.Getenv("AUTHORIZER_URI")
+		headers = http.Header{}
+		body    = authorizerRequest{
+			TransferID: transfer.ID().Value(),
+			PayerID:    transfer.Payer().Value(),
+			PayeeID:    transfer.Payee().Value(),
+			Amount:     transfer.Value().Amount().Value(),
+			Currency:   transfer.Value().Currency().String(),
+		}
+	)
+
+	if id, ok := ctx.Value("correlation_id").(string); ok && id != "" {
+		headers.Set(correlationIDHeader, id)
+	}
+
+	switch strings.ToUpper(os.Getenv("AUTHORIZER_METHOD")) {
+	case http.MethodGet:
+		return a.client.GetWithContext(ctx, body.withQuery(uri), headers)
+	case http.MethodPut:
+		return a.client.Put(ctx, uri, body, headers)
+	default:
+		return a.client.Post(ctx, uri, body, headers)
+	}
+}
+
+// withQuery returns uri with the transfer added to its query string
+func (r authorizerRequest) withQuery(uri string) string {
+	query := url.Values{}
+	query.Set("transfer_id", r.TransferID)
+	query.Set("payer_id", r.PayerID)
+	query.Set("payee_id", r.PayeeID)
+	query.Set("amount", strconv.FormatInt(r.Amount, 10))
+	query.Set("currency", r.Currency)
+
+	if strings.Contains(uri, "?") {
+		return uri + "&" + query.Encode()
+	}
+
+	return uri + "?" + query.Encode()
+}
+
+// decision reads the decision, the legacy message is approved only when it is "Autorizado"
+func (r authorizerResponse) decision() usecase.AuthorizationDecision {
+	switch outcome := usecase.AuthorizationOutcome(strings.ToLower(r.Decision)); outcome {
+	case usecase.AuthorizationApproved, usecase.AuthorizationReview, usecase.AuthorizationDenied:
+		return usecase.AuthorizationDecision{Outcome: outcome, ReasonCode: r.ReasonCode}
+	}
+
+	if r.Decision == "" && r.Message == autorizado {
+		return usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved}
+	}
+
+	return usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: r.ReasonCode}
+}
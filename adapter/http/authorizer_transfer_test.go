@@ -6,49 +6,120 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
 )
 
 func TestAuthorizer_Authorized(t *testing.T) {
 	type fields struct {
-		client HTTPGetter
+		client *spyHTTPClient
+		method string
 	}
 	type args struct {
 		transfer entity.Transfer
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    bool
-		wantErr bool
+		name       string
+		fields     fields
+		args       args
+		want       usecase.AuthorizationDecision
+		wantMethod string
+		wantErr    bool
 	}{
 		{
 			name: "Test authorized success",
 			fields: fields{
-				client: stubHTTPGetter{
+				client: &spyHTTPClient{
 					res: &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(
+							bytes.NewReader([]byte(`{"decision":"approved"}`)),
+						),
+					},
+					err: nil,
+				},
+			},
+			args: args{
+				transfer: entity.Transfer{},
+			},
+			want:       usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantMethod: http.MethodPost,
+			wantErr:    false,
+		},
+		{
+			name: "Test authorized denied with reason code",
+			fields: fields{
+				client: &spyHTTPClient{
+					res: &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(
+							bytes.NewReader([]byte(`{"decision":"denied","reason_code":"R01"}`)),
+						),
+					},
+					err: nil,
+				},
+				method: http.MethodPut,
+			},
+			args: args{
+				transfer: entity.Transfer{},
+			},
+			want:       usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R01"},
+			wantMethod: http.MethodPut,
+			wantErr:    false,
+		},
+		{
+			name: "Test authorized review",
+			fields: fields{
+				client: &spyHTTPClient{
+					res: &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(
+							bytes.NewReader([]byte(`{"decision":"REVIEW","reason_code":"R10"}`)),
+						),
+					},
+					err: nil,
+				},
+			},
+			args: args{
+				transfer: entity.Transfer{},
+			},
+			want:       usecase.AuthorizationDecision{Outcome: usecase.AuthorizationReview, ReasonCode: "R10"},
+			wantMethod: http.MethodPost,
+			wantErr:    false,
+		},
+		{
+			name: "Test authorized legacy message",
+			fields: fields{
+				client: &spyHTTPClient{
+					res: &http.Response{
+						StatusCode: http.StatusOK,
 						Body: ioutil.NopCloser(
 							bytes.NewReader([]byte(`{"message":"Autorizado"}`)),
 						),
 					},
 					err: nil,
 				},
+				method: http.MethodGet,
 			},
 			args: args{
 				transfer: entity.Transfer{},
 			},
-			want:    true,
-			wantErr: false,
+			want:       usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantMethod: http.MethodGet,
+			wantErr:    false,
 		},
 		{
 			name: "Test authorized error response",
 			fields: fields{
-				client: stubHTTPGetter{
+				client: &spyHTTPClient{
 					res: &http.Response{
+						StatusCode: http.StatusOK,
 						Body: ioutil.NopCloser(
 							bytes.NewReader([]byte(`{"message":"fail"}`)),
 						),
@@ -59,13 +130,32 @@ func TestAuthorizer_Authorized(t *testing.T) {
 			args: args{
 				transfer: entity.Transfer{},
 			},
-			want:    false,
-			wantErr: true,
+			want:       usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied},
+			wantMethod: http.MethodPost,
+			wantErr:    false,
+		},
+		{
+			name: "Test authorized server error",
+			fields: fields{
+				client: &spyHTTPClient{
+					res: &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					},
+					err: nil,
+				},
+			},
+			args: args{
+				transfer: entity.Transfer{},
+			},
+			want:       usecase.AuthorizationDecision{},
+			wantMethod: http.MethodPost,
+			wantErr:    true,
 		},
 		{
 			name: "Test authorized error",
 			fields: fields{
-				client: stubHTTPGetter{
+				client: &spyHTTPClient{
 					res: &http.Response{},
 					err: errors.New("failure client"),
 				},
@@ -73,12 +163,16 @@ func TestAuthorizer_Authorized(t *testing.T) {
 			args: args{
 				transfer: entity.Transfer{},
 			},
-			want:    false,
-			wantErr: true,
+			want:       usecase.AuthorizationDecision{},
+			wantMethod: http.MethodPost,
+			wantErr:    true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("AUTHORIZER_METHOD", tt.fields.method)
+			defer os.Unsetenv("AUTHORIZER_METHOD")
+
 			a := NewAuthorizer(tt.fields.client, logger.Dummy{})
 			got, err := a.Authorized(context.TODO(), tt.args.transfer)
 			if (err != nil) != tt.wantErr {
@@ -90,6 +184,86 @@ func TestAuthorizer_Authorized(t *testing.T) {
 				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
 			}
 
+			if tt.fields.client.method != tt.wantMethod {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, tt.fields.client.method, tt.wantMethod)
+			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAuthorizer_AuthorizedRequest(t *testing.T) {
+	var (
+		client = &spyHTTPClient{
+			res: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"decision":"approved"}`))),
+			},
+		}
+		transfer = entity.NewTransfer(
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			time.Now(),
+		)
+		ctx = context.WithValue(context.Background(), "correlation_id", "f9882930-1914-47d7-8b58-18bff092e081")
+	)
+
+	if _, err := NewAuthorizer(client, logger.Dummy{}).Authorized(ctx, transfer); err != nil {
+		t.Fatal(err)
+	}
+
+	want := authorizerRequest{
+		TransferID: vo.NewUuidStaticTest().Value(),
+		PayerID:    vo.NewUuidStaticTest().Value(),
+		PayeeID:    vo.NewUuidStaticTest().Value(),
+		Amount:     100,
+		Currency:   "NGN",
+	}
+	if client.body != want {
+		t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", "Send transfer details", client.body, want)
+	}
+
+	if got := client.headers.Get(correlationIDHeader); got != "f9882930-1914-47d7-8b58-18bff092e081" {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", "Propagate correlation id", got, "f9882930-1914-47d7-8b58-18bff092e081")
+	}
+}
+
+func TestAuthorizer_AuthorizedGetRequest(t *testing.T) {
+	var (
+		client = &spyHTTPClient{
+			res: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"decision":"approved"}`))),
+			},
+		}
+		transfer = entity.NewTransfer(
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			time.Now(),
+		)
+		ctx = context.WithValue(context.Background(), "correlation_id", "f9882930-1914-47d7-8b58-18bff092e081")
+	)
+
+	os.Setenv("AUTHORIZER_URI", "http://authorizer/v1/authorize?version=2")
+	defer os.Unsetenv("AUTHORIZER_URI")
+	os.Setenv("AUTHORIZER_METHOD", http.MethodGet)
+	defer os.Unsetenv("AUTHORIZER_METHOD")
+
+	if _, err := NewAuthorizer(client, logger.Dummy{}).Authorized(ctx, transfer); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "http://authorizer/v1/authorize?version=2&amount=100&currency=NGN" +
+		"&payee_id=0db298eb-c8e7-4829-84b7-c1036b4f0791&payer_id=0db298eb-c8e7-4829-84b7-c1036b4f0791" +
+		"&transfer_id=0db298eb-c8e7-4829-84b7-c1036b4f0791"
+	if client.url != want {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", "Send transfer details in the query", client.url, want)
+	}
+
+	if got := client.headers.Get(correlationIDHeader); got != "f9882930-1914-47d7-8b58-18bff092e081" {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", "Propagate correlation id", got, "f9882930-1914-47d7-8b58-18bff092e081")
+	}
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -8,12 +9,28 @@ type (
 	// HTTPClient is the http wrapper for the application
 	HTTPClient interface {
 		HTTPGetter
+		HTTPPoster
+		HTTPPutter
 	}
 
 	// HTTPGetter holds fields and dependencies for executing an http GET request
 	HTTPGetter interface {
 		// Get executes a GET http request
 		Get(url string) (*http.Response, error)
+		// GetWithContext executes a GET http request bound to ctx, the given headers are added to the request
+		GetWithContext(ctx context.Context, url string, headers http.Header) (*http.Response, error)
+	}
+
+	// HTTPPoster holds fields and dependencies for executing an http POST request
+	HTTPPoster interface {
+		// Post executes a POST http request with body encoded as JSON
+		Post(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error)
+	}
+
+	// HTTPPutter holds fields and dependencies for executing an http PUT request
+	HTTPPutter interface {
+		// Put executes a PUT http request with body encoded as JSON
+		Put(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error)
 	}
 )
 
@@ -22,8 +39,42 @@ type (
 		res *http.Response
 		err error
 	}
+
+	// spyHTTPClient records the last request sent
+	spyHTTPClient struct {
+		res     *http.Response
+		err     error
+		method  string
+		url     string
+		body    interface{}
+		headers http.Header
+	}
 )
 
 func (h stubHTTPGetter) Get(_ string) (*http.Response, error) {
 	return h.res, h.err
-}
\ No newline at end of file
+}
+
+func (h stubHTTPGetter) GetWithContext(_ context.Context, _ string, _ http.Header) (*http.Response, error) {
+	return h.res, h.err
+}
+
+func (h *spyHTTPClient) Get(url string) (*http.Response, error) {
+	h.method, h.url = http.MethodGet, url
+	return h.res, h.err
+}
+
+func (h *spyHTTPClient) GetWithContext(_ context.Context, url string, headers http.Header) (*http.Response, error) {
+	h.method, h.url, h.headers = http.MethodGet, url, headers
+	return h.res, h.err
+}
+
+func (h *spyHTTPClient) Post(_ context.Context, _ string, body interface{}, headers http.Header) (*http.Response, error) {
+	h.method, h.body, h.headers = http.MethodPost, body, headers
+	return h.res, h.err
+}
+
+func (h *spyHTTPClient) Put(_ context.Context, _ string, body interface{}, headers http.Header) (*http.Response, error) {
+	h.method, h.body, h.headers = http.MethodPut, body, headers
+	return h.res, h.err
+}
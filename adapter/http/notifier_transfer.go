@@ -45,38 +45,43 @@ func NewNotifier(c HTTPGetter, p queue.Producer, l logger.Logger) usecase.Notifi
 
 // Notify send a notification
 func (n notifier) Notify(_ context.Context, _ entity.Transfer) {
-	res, err := n.client.Get(os.Getenv("NOTIFY_URI"))
+	status, err := send(n.client, os.Getenv("NOTIFY_URI"))
 	if err != nil {
 		n.log.WithFields(logger.Fields{
 			"key":   n.logKey,
 			"error": err.Error(),
-		}).Errorf("failed to client")
+		}).Errorf("failed to notify")
 
 		n.publish(err)
 		return
 	}
 
-	b := &notifierResponse{}
-	err = json.NewDecoder(res.Body).Decode(&b)
+	n.log.WithFields(logger.Fields{
+		"key":         n.logKey,
+		"http_status": status,
+	}).Infof("success to notify")
+}
+
+// send requests the notification service and returns its http status code
+func send(client HTTPGetter, uri string) (int, error) {
+	res, err := client.Get(uri)
 	if err != nil {
-		n.log.WithFields(logger.Fields{
-			"key":   n.logKey,
-			"error": err.Error(),
-		}).Errorf("failed to marshal message")
+		return 0, err
+	}
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
 
-		n.publish(err)
-		return
+	b := &notifierResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&b); err != nil {
+		return res.StatusCode, err
 	}
 
 	if b.Message != enviado {
-		n.publish(errFailedToNotify)
-		return
+		return res.StatusCode, errFailedToNotify
 	}
 
-	n.log.WithFields(logger.Fields{
-		"key":         n.logKey,
-		"http_status": res.StatusCode,
-	}).Infof("success to notify")
+	return res.StatusCode, nil
 }
 
 func (n notifier) publish(err error) {
@@ -24,6 +24,12 @@ func (s *spyProducer) Publish(_ []byte) error {
 	return s.err
 }
 
+func (s *spyProducer) PublishWithID(_ string, _ []byte) error {
+	s.invoked = true
+
+	return s.err
+}
+
 func TestNotifier_Notify(t *testing.T) {
 	type fields struct {
 		client   HTTPGetter
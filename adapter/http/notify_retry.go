@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+	"github.com/pkg/errors"
+)
+
+type (
+	notifyRetry struct {
+		client        HTTPGetter
+		outboxFinder  entity.OutboxRepositoryFinder
+		outboxUpdater entity.OutboxRepositoryUpdater
+		events        usecase.EventPublisher
+		log           logger.Logger
+		logKey        string
+	}
+
+	// notifyMessage is either published by the notifier when a notification fails or relayed
+	// from the outbox for a transfer
+	notifyMessage struct {
+		URI        string `json:"uri"`
+		Error      string `json:"error"`
+		ID         string `json:"id"`
+		TransferID string `json:"transfer_id"`
+	}
+)
+
+// NewNotifyRetry creates new notifyRetry with its dependencies
+func NewNotifyRetry(
+	c HTTPGetter,
+	outboxFinder entity.OutboxRepositoryFinder,
+	outboxUpdater entity.OutboxRepositoryUpdater,
+	e usecase.EventPublisher,
+	l logger.Logger,
+) queue.Handler {
+	return notifyRetry{
+		client:        c,
+		outboxFinder:  outboxFinder,
+		outboxUpdater: outboxUpdater,
+		events:        e,
+		log:           l,
+		logKey:        "retry_notify",
+	}
+}
+
+// Handle sends again a notification published by the notifier, a message relayed from the outbox is published
+// as a TransferCompleted event to the subscribers of the worker once, the copies of a processed message are skipped
+func (n notifyRetry) Handle(ctx context.Context, message []byte) error {
+	var m notifyMessage
+	if err := json.Unmarshal(message, &m); err != nil || (m.URI == "" && m.TransferID == "") {
+		return errors.Wrapf(queue.ErrUnprocessableMessage, "invalid notify message: %s", message)
+	}
+
+	if m.URI == "" {
+		event, err := usecase.DecodeTransferMessage(message)
+		if err != nil {
+			return errors.Wrapf(queue.ErrUnprocessableMessage, "invalid transfer message: %v", err)
+		}
+
+		return n.dispatch(ctx, m, event)
+	}
+
+	status, err := send(n.client, m.URI)
+	if err != nil {
+		return err
+	}
+
+	n.log.WithFields(logger.Fields{
+		"key":         n.logKey,
+		"http_status": status,
+	}).Infof("success to notify")
+
+	return nil
+}
+
+// dispatch publishes the event of a transfer message not processed yet and marks it processed. The relay publishes
+// a message at least once, a copy delivered after the message was processed is acknowledged without being published
+func (n notifyRetry) dispatch(ctx context.Context, m notifyMessage, event entity.Event) error {
+	ID, err := vo.NewUuid(m.ID)
+	if err != nil {
+		return errors.Wrapf(queue.ErrUnprocessableMessage, "invalid transfer message id: %v", err)
+	}
+
+	stored, err := n.outboxFinder.FindByID(ctx, ID)
+	if err != nil {
+		return err
+	}
+
+	if stored.Processed() {
+		n.log.WithFields(logger.Fields{
+			"key":         n.logKey,
+			"message_id":  m.ID,
+			"transfer_id": m.TransferID,
+		}).Infof("skipping transfer message already processed")
+
+		return nil
+	}
+
+	n.log.WithFields(logger.Fields{
+		"key":         n.logKey,
+		"message_id":  m.ID,
+		"transfer_id": m.TransferID,
+	}).Infof("dispatching transfer message")
+
+	if err := n.events.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	// the event is out, retrying the message for a failed mark would publish it again
+	if err := n.outboxUpdater.MarkProcessed(ctx, ID, time.Now()); err != nil && !errors.Is(err, entity.ErrOutboxMessageProcessed) {
+		n.log.WithFields(logger.Fields{
+			"key":        n.logKey,
+			"message_id": m.ID,
+			"error":      err.Error(),
+		}).Errorf("error marking transfer message processed")
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+)
+
+const transferMessageIDTest = "7a0a2d55-3a0b-4c54-9a3e-8c1b8e0c4f10"
+
+type spyEventPublisher struct {
+	events []entity.Event
+	err    error
+}
+
+func (s *spyEventPublisher) Publish(_ context.Context, events ...entity.Event) error {
+	s.events = append(s.events, events...)
+	return s.err
+}
+
+// stubOutboxRepo finds the message it holds and records the messages marked processed
+type stubOutboxRepo struct {
+	message   entity.OutboxMessage
+	err       error
+	processed *[]vo.Uuid
+}
+
+func (s stubOutboxRepo) FindPending(context.Context, int) ([]entity.OutboxMessage, error) {
+	return nil, nil
+}
+
+func (s stubOutboxRepo) FindByID(context.Context, vo.Uuid) (entity.OutboxMessage, error) {
+	if s.err != nil {
+		return entity.OutboxMessage{}, s.err
+	}
+	return s.message, nil
+}
+
+func (s stubOutboxRepo) MarkPublished(context.Context, vo.Uuid, time.Time) error {
+	return nil
+}
+
+func (s stubOutboxRepo) MarkProcessed(_ context.Context, ID vo.Uuid, _ time.Time) error {
+	*s.processed = append(*s.processed, ID)
+	return nil
+}
+
+func TestNotifyRetry_Handle(t *testing.T) {
+	var (
+		ID          = vo.NewUuidStaticTest()
+		processedAt = time.Now()
+		pending     = entity.NewOutboxMessage(ID, ID, entity.TransferCompletedMessage, nil, processedAt)
+		processed   = entity.NewPublishedOutboxMessage(ID, ID, entity.TransferCompletedMessage, nil, processedAt, &processedAt, &processedAt)
+		transfer    = []byte(`{"id":"` + transferMessageIDTest + `","type":"transfer.completed","transfer_id":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payer":"0db298eb-c8e7-4829-84b7-c1036b4f0791","payee":"0db298eb-c8e7-4829-84b7-c1036b4f0791","value":100,"currency":"NGN","credited_value":100,"credited_currency":"NGN","rate":"1","status":"COMPLETED","created_at":"2021-05-10T12:00:00Z","occurred_at":"2021-05-10T12:00:01Z"}`)
+	)
+
+	tests := []struct {
+		name          string
+		client        HTTPGetter
+		outbox        stubOutboxRepo
+		message       []byte
+		wantEvents    int
+		wantProcessed int
+		wantErr       error
+	}{
+		{
+			name: "Retry notify success",
+			client: stubHTTPGetter{
+				res: &http.Response{
+					Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"Enviado"}`))),
+				},
+			},
+			message: []byte(`{"uri":"http://notify","error":"failed to notify"}`),
+		},
+		{
+			name:          "Relayed transfer message is published as an event",
+			client:        stubHTTPGetter{err: errors.New("must not notify")},
+			outbox:        stubOutboxRepo{message: pending},
+			message:       transfer,
+			wantEvents:    1,
+			wantProcessed: 1,
+		},
+		{
+			name:    "Relayed transfer message already processed is skipped",
+			client:  stubHTTPGetter{err: errors.New("must not notify")},
+			outbox:  stubOutboxRepo{message: processed},
+			message: transfer,
+		},
+		{
+			name:    "Relayed transfer message missing from the outbox",
+			client:  stubHTTPGetter{err: errors.New("must not notify")},
+			outbox:  stubOutboxRepo{err: entity.ErrNotFoundOutboxMessage},
+			message: transfer,
+			wantErr: entity.ErrNotFoundOutboxMessage,
+		},
+		{
+			name:    "Relayed transfer message of an unknown type",
+			client:  stubHTTPGetter{},
+			message: []byte(`{"id":"` + transferMessageIDTest + `","type":"transfer.unknown","transfer_id":"0db298eb-c8e7-4829-84b7-c1036b4f0791"}`),
+			wantErr: queue.ErrUnprocessableMessage,
+		},
+		{
+			name: "Retry notify error response",
+			client: stubHTTPGetter{
+				res: &http.Response{
+					Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"error"}`))),
+				},
+			},
+			message: []byte(`{"uri":"http://notify","error":"failed to notify"}`),
+			wantErr: errFailedToNotify,
+		},
+		{
+			name:    "Retry notify client error",
+			client:  stubHTTPGetter{err: errors.New("failure client")},
+			message: []byte(`{"uri":"http://notify","error":"failed to notify"}`),
+			wantErr: errors.New("failure client"),
+		},
+		{
+			name:    "Retry notify invalid message",
+			client:  stubHTTPGetter{},
+			message: []byte(`not json`),
+			wantErr: queue.ErrUnprocessableMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				events    = &spyEventPublisher{}
+				processed []vo.Uuid
+			)
+			tt.outbox.processed = &processed
+
+			err := NewNotifyRetry(tt.client, tt.outbox, tt.outbox, events, logger.Dummy{}).Handle(context.Background(), tt.message)
+
+			if (err == nil) != (tt.wantErr == nil) ||
+				(err != nil && !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if len(events.events) != tt.wantEvents {
+				t.Errorf("[TestCase '%s'] Got: '%d events' | Want: '%d events'", tt.name, len(events.events), tt.wantEvents)
+			}
+
+			if len(processed) != tt.wantProcessed {
+				t.Errorf("[TestCase '%s'] Got: '%d processed' | Want: '%d processed'", tt.name, len(processed), tt.wantProcessed)
+			}
+		})
+	}
+}
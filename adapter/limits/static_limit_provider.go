@@ -0,0 +1,130 @@
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+var errNegativeLimit = errors.New("limit must not be negative")
+
+type (
+	// LimitFile defines the content of a limits file
+	LimitFile struct {
+		Limits []LimitFileEntry `json:"limits"`
+	}
+
+	// LimitFileEntry defines the limits of a KYC tier in a currency, amounts are in minor units and an absent
+	// limit does not apply
+	LimitFileEntry struct {
+		Tier           string `json:"tier"`
+		Currency       string `json:"currency"`
+		PerTransaction *int64 `json:"per_transaction"`
+		Daily          *int64 `json:"daily"`
+		Monthly        *int64 `json:"monthly"`
+		DailyCount     *int   `json:"daily_count"`
+		MonthlyCount   *int   `json:"monthly_count"`
+	}
+
+	staticLimitProvider struct {
+		limits map[vo.KYCTier]map[vo.TypeCurrency]entity.TransferLimit
+	}
+)
+
+// NewStaticLimitProvider creates new limit provider with the given limits, a tier and currency without limits is unlimited
+func NewStaticLimitProvider(limits ...entity.TransferLimit) usecase.TransferLimitProvider {
+	var s = staticLimitProvider{limits: make(map[vo.KYCTier]map[vo.TypeCurrency]entity.TransferLimit)}
+	for _, l := range limits {
+		if s.limits[l.Tier] == nil {
+			s.limits[l.Tier] = make(map[vo.TypeCurrency]entity.TransferLimit)
+		}
+		s.limits[l.Tier][l.Currency.Value()] = l
+	}
+
+	return s
+}
+
+// NewFileLimitProvider creates new static limit provider with the limits read from a JSON file
+func NewFileLimitProvider(path string) (usecase.TransferLimitProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading limits file")
+	}
+
+	var file LimitFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, errors.Wrap(err, "error decoding limits file")
+	}
+
+	var limits []entity.TransferLimit
+	for _, entry := range file.Limits {
+		limit, err := entry.toTransferLimit()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid limit %s/%s", entry.Tier, entry.Currency)
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return NewStaticLimitProvider(limits...), nil
+}
+
+// Limit returns the limits of the tier in the currency
+func (s staticLimitProvider) Limit(_ context.Context, tier vo.KYCTier, currency vo.Currency) (entity.TransferLimit, error) {
+	limit, ok := s.limits[tier][currency.Value()]
+	if !ok {
+		return entity.NewUnlimitedTransferLimit(tier, currency), nil
+	}
+
+	return limit, nil
+}
+
+func (e LimitFileEntry) toTransferLimit() (entity.TransferLimit, error) {
+	tier, err := vo.NewKYCTier(e.Tier)
+	if err != nil {
+		return entity.TransferLimit{}, err
+	}
+
+	currency, err := vo.NewCurrency(e.Currency)
+	if err != nil {
+		return entity.TransferLimit{}, err
+	}
+
+	var limit = entity.NewUnlimitedTransferLimit(tier, currency)
+	for _, l := range []struct {
+		value *int64
+		set   func(int64)
+	}{
+		{value: e.PerTransaction, set: func(v int64) { limit.PerTransaction = v }},
+		{value: e.Daily, set: func(v int64) { limit.Daily = v }},
+		{value: e.Monthly, set: func(v int64) { limit.Monthly = v }},
+		{value: count(e.DailyCount), set: func(v int64) { limit.DailyCount = int(v) }},
+		{value: count(e.MonthlyCount), set: func(v int64) { limit.MonthlyCount = int(v) }},
+	} {
+		if l.value == nil {
+			continue
+		}
+
+		if *l.value < 0 {
+			return entity.TransferLimit{}, errNegativeLimit
+		}
+		l.set(*l.value)
+	}
+
+	return limit, nil
+}
+
+func count(c *int) *int64 {
+	if c == nil {
+		return nil
+	}
+
+	v := int64(*c)
+	return &v
+}
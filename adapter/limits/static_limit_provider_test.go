@@ -0,0 +1,124 @@
+package limits
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func TestFileLimitProvider_Limit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.json")
+	err := os.WriteFile(path, []byte(`{"limits":[
+		{"tier":"TIER_1","currency":"NGN","per_transaction":5000000,"daily":10000000,"daily_count":10},
+		{"tier":"tier_0","currency":"NGN","per_transaction":0}
+	]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFileLimitProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ngn, _ = vo.NewCurrency("NGN")
+		usd, _ = vo.NewCurrency("USD")
+	)
+
+	tests := []struct {
+		name     string
+		tier     vo.KYCTier
+		currency vo.Currency
+		want     entity.TransferLimit
+	}{
+		{
+			name:     "Configured tier and currency",
+			tier:     vo.TIER1,
+			currency: ngn,
+			want: entity.TransferLimit{
+				Tier:           vo.TIER1,
+				Currency:       ngn,
+				PerTransaction: 5000000,
+				Daily:          10000000,
+				Monthly:        entity.NoLimit,
+				DailyCount:     10,
+				MonthlyCount:   entity.NoLimit,
+			},
+		},
+		{
+			name:     "Zero limit blocks the transfers",
+			tier:     vo.TIER0,
+			currency: ngn,
+			want: entity.TransferLimit{
+				Tier:           vo.TIER0,
+				Currency:       ngn,
+				PerTransaction: 0,
+				Daily:          entity.NoLimit,
+				Monthly:        entity.NoLimit,
+				DailyCount:     entity.NoLimit,
+				MonthlyCount:   entity.NoLimit,
+			},
+		},
+		{
+			name:     "Currency not configured is unlimited",
+			tier:     vo.TIER1,
+			currency: usd,
+			want:     entity.NewUnlimitedTransferLimit(vo.TIER1, usd),
+		},
+		{
+			name:     "Tier not configured is unlimited",
+			tier:     vo.TIER3,
+			currency: ngn,
+			want:     entity.NewUnlimitedTransferLimit(vo.TIER3, ngn),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.Limit(context.Background(), tt.tier, tt.currency)
+			if err != nil {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, nil)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFileLimitProvider_InvalidLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "Invalid tier",
+			content: `{"limits":[{"tier":"TIER_9","currency":"NGN","daily":100}]}`,
+		},
+		{
+			name:    "Invalid currency",
+			content: `{"limits":[{"tier":"TIER_1","currency":"XYZ","daily":100}]}`,
+		},
+		{
+			name:    "Negative limit",
+			content: `{"limits":[{"tier":"TIER_1","currency":"NGN","monthly_count":-1}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "limits.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := NewFileLimitProvider(path); err == nil {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: an error", tt.name, err)
+			}
+		})
+	}
+}
@@ -16,10 +16,15 @@ func NewCreateTransferPresenter() usecase.CreateTransferPresenter {
 // Output returns the transfer creation response
 func (c createTransferPresenter) Output(t entity.Transfer) usecase.CreateTransferOutput {
 	return usecase.CreateTransferOutput{
-		ID:        t.ID().Value(),
-		PayerID:   t.Payer().Value(),
-		PayeeID:   t.Payee().Value(),
-		Value:     t.Value().Amount().Value(),
-		CreatedAt: t.CreatedAt().Format(time.RFC3339),
+		ID:               t.ID().Value(),
+		PayerID:          t.Payer().Value(),
+		PayeeID:          t.Payee().Value(),
+		Value:            t.Value().Amount().Value(),
+		Currency:         t.Value().Currency().String(),
+		CreditedValue:    t.Credit().Amount().Value(),
+		CreditedCurrency: t.Credit().Currency().String(),
+		Rate:             t.Rate().String(),
+		Status:           t.Status().String(),
+		CreatedAt:        t.CreatedAt().Format(time.RFC3339),
 	}
 }
\ No newline at end of file
@@ -31,11 +31,16 @@ func Test_createTransferPresenter_Output(t *testing.T) {
 				),
 			},
 			want: usecase.CreateTransferOutput{
-				ID:        "0db298eb-c8e7-4829-84b7-c1036b4f0791",
-				PayerID:   "0db298eb-c8e7-4829-84b7-c1036b4f0791",
-				PayeeID:   "0db298eb-c8e7-4829-84b7-c1036b4f0791",
-				Value:     100,
-				CreatedAt: time.Time{}.Format(time.RFC3339),
+				ID:               "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				PayerID:          "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				PayeeID:          "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				Value:            100,
+				Currency:         "NGN",
+				CreditedValue:    100,
+				CreditedCurrency: "NGN",
+				Rate:             "1",
+				Status:           "PENDING",
+				CreatedAt:        time.Time{}.Format(time.RFC3339),
 			},
 		},
 	}
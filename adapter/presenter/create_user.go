@@ -18,7 +18,6 @@ func (c createUserPresenter) Output(u entity.User) usecase.CreateUserOutput {
 	return usecase.CreateUserOutput{
 		ID:       u.ID().Value(),
 		FullName: u.FullName().Value(),
-		Password: u.Password().Value(),
 		Email:    u.Email().Value(),
 		Document: usecase.CreateUserDocumentOutput{
 			Type:  u.Document().Type().String(),
@@ -26,7 +26,7 @@ func Test_createUserPresenter_Output(t *testing.T) {
 					vo.NewUuidStaticTest(),
 					vo.NewFullName("Test testing"),
 					vo.NewEmailTest("test@testing.com"),
-					vo.NewPassword("passw"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
 					vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 					time.Time{},
@@ -36,13 +36,12 @@ func Test_createUserPresenter_Output(t *testing.T) {
 				ID:       "0db298eb-c8e7-4829-84b7-c1036b4f0791",
 				FullName: "Test testing",
 				Email:    "test@testing.com",
-				Password: "passw",
 				Document: usecase.CreateUserDocumentOutput{
 					Type:  "CNPJ",
 					Value: "98.521.079/0001-09",
 				},
 				Wallet: usecase.CreateUserWalletOutput{
-					Currency: "BRL",
+					Currency: "NGN",
 					Amount:   100,
 				},
 				Roles: usecase.CreateUserRolesOutput{
@@ -59,7 +58,7 @@ func Test_createUserPresenter_Output(t *testing.T) {
 					vo.NewUuidStaticTest(),
 					vo.NewFullName("Test testing"),
 					vo.NewEmailTest("test@testing.com"),
-					vo.NewPassword("passw"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
 					vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 					time.Time{},
@@ -69,13 +68,12 @@ func Test_createUserPresenter_Output(t *testing.T) {
 				ID:       "0db298eb-c8e7-4829-84b7-c1036b4f0791",
 				FullName: "Test testing",
 				Email:    "test@testing.com",
-				Password: "passw",
 				Document: usecase.CreateUserDocumentOutput{
 					Type:  "CNPJ",
 					Value: "20.770.438/0001-66",
 				},
 				Wallet: usecase.CreateUserWalletOutput{
-					Currency: "BRL",
+					Currency: "NGN",
 					Amount:   100,
 				},
 				Roles: usecase.CreateUserRolesOutput{
@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type findTransferByIDPresenter struct{}
+
+// NewFindTransferByIDPresenter creates new findTransferByIDPresenter
+func NewFindTransferByIDPresenter() usecase.FindTransferByIDPresenter {
+	return findTransferByIDPresenter{}
+}
+
+// Output returns the transfer fetch response by ID
+func (f findTransferByIDPresenter) Output(t entity.Transfer) usecase.FindTransferByIDOutput {
+	var history = make([]usecase.FindTransferByIDHistoryOutput, 0)
+	for _, h := range t.History() {
+		history = append(history, usecase.FindTransferByIDHistoryOutput{
+			From:   h.From().String(),
+			To:     h.To().String(),
+			Reason: h.Reason(),
+			At:     h.At().Format(time.RFC3339),
+		})
+	}
+
+	return usecase.FindTransferByIDOutput{
+		ID:               t.ID().Value(),
+		PayerID:          t.Payer().Value(),
+		PayeeID:          t.Payee().Value(),
+		Value:            t.Value().Amount().Value(),
+		Currency:         t.Value().Currency().String(),
+		CreditedValue:    t.Credit().Amount().Value(),
+		CreditedCurrency: t.Credit().Currency().String(),
+		Rate:             t.Rate().String(),
+		Status:           t.Status().String(),
+		History:          history,
+		CreatedAt:        t.CreatedAt().Format(time.RFC3339),
+	}
+}
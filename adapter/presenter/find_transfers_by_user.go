@@ -0,0 +1,44 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type findTransfersByUserPresenter struct{}
+
+// NewFindTransfersByUserPresenter creates new findTransfersByUserPresenter
+func NewFindTransfersByUserPresenter() usecase.FindTransfersByUserPresenter {
+	return findTransfersByUserPresenter{}
+}
+
+// Output returns the transfer history of the user
+func (f findTransfersByUserPresenter) Output(userID vo.Uuid, transfers []entity.Transfer, nextCursor string) usecase.FindTransfersByUserOutput {
+	var output = make([]usecase.FindTransfersByUserTransferOutput, 0)
+	for _, t := range transfers {
+		var direction = entity.TransferReceived
+		if t.Payer() == userID {
+			direction = entity.TransferSent
+		}
+
+		output = append(output, usecase.FindTransfersByUserTransferOutput{
+			ID:         t.ID().Value(),
+			Direction:  string(direction),
+			PayerID:    t.Payer().Value(),
+			PayeeID:    t.Payee().Value(),
+			Value:      t.Value().Amount().Value(),
+			Currency:   t.Value().Currency().String(),
+			Status:     t.Status().String(),
+			ReversalOf: t.ReversalOf().Value(),
+			CreatedAt:  t.CreatedAt().Format(time.RFC3339),
+		})
+	}
+
+	return usecase.FindTransfersByUserOutput{
+		Transfers:  output,
+		NextCursor: nextCursor,
+	}
+}
@@ -26,7 +26,7 @@ func Test_findUserByIDPresenter_Output(t *testing.T) {
 					vo.NewUuidStaticTest(),
 					vo.NewFullName("Test testing"),
 					vo.NewEmailTest("test@testing.com"),
-					vo.NewPassword("passw"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
 					vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 					time.Time{},
@@ -41,7 +41,7 @@ func Test_findUserByIDPresenter_Output(t *testing.T) {
 					Value: "98.521.079/0001-09",
 				},
 				Wallet: usecase.FindUserByIDWalletOutput{
-					Currency: "BRL",
+					Currency: "NGN",
 					Amount:   100,
 				},
 				Roles: usecase.FindUserByIDRolesOutput{
@@ -58,7 +58,7 @@ func Test_findUserByIDPresenter_Output(t *testing.T) {
 					vo.NewUuidStaticTest(),
 					vo.NewFullName("Test testing"),
 					vo.NewEmailTest("test@testing.com"),
-					vo.NewPassword("passw"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
 					vo.NewDocumentTest(vo.CPF, "07091054965"),
 					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 					time.Time{},
@@ -73,7 +73,7 @@ func Test_findUserByIDPresenter_Output(t *testing.T) {
 					Value: "07091054965",
 				},
 				Wallet: usecase.FindUserByIDWalletOutput{
-					Currency: "BRL",
+					Currency: "NGN",
 					Amount:   100,
 				},
 				Roles: usecase.FindUserByIDRolesOutput{
@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type reconcileWalletPresenter struct{}
+
+// NewReconcileWalletPresenter creates new reconcileWalletPresenter
+func NewReconcileWalletPresenter() usecase.ReconcileWalletPresenter {
+	return reconcileWalletPresenter{}
+}
+
+// Output returns the wallet reconciliation response
+func (r reconcileWalletPresenter) Output(u entity.User, ledger vo.Money, postings []entity.Posting) usecase.ReconcileWalletOutput {
+	if u.Wallet() == nil {
+		return usecase.ReconcileWalletOutput{}
+	}
+
+	var wallet = u.Wallet().Money()
+	return usecase.ReconcileWalletOutput{
+		UserID:        u.ID().Value(),
+		Currency:      wallet.Currency().String(),
+		WalletBalance: wallet.Amount().Value(),
+		LedgerBalance: ledger.Amount().Value(),
+		Postings:      len(postings),
+		Reconciled:    wallet.Equals(ledger),
+	}
+}
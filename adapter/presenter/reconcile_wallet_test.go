@@ -0,0 +1,61 @@
+package presenter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+func Test_reconcileWalletPresenter_Output(t *testing.T) {
+	type args struct {
+		user   entity.User
+		ledger vo.Money
+	}
+	tests := []struct {
+		name string
+		args args
+		want usecase.ReconcileWalletOutput
+	}{
+		{
+			name: "Reconcile wallet output",
+			args: args{
+				user: entity.NewCommonUser(
+					vo.NewUuidStaticTest(),
+					vo.NewFullName("Test testing"),
+					vo.NewEmailTest("test@testing.com"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
+					vo.NewDocumentTest(vo.CPF, "07091054954"),
+					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
+					time.Time{},
+				),
+				ledger: vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			},
+			want: usecase.ReconcileWalletOutput{
+				UserID:        "0db298eb-c8e7-4829-84b7-c1036b4f0791",
+				Currency:      "NGN",
+				WalletBalance: 100,
+				LedgerBalance: 100,
+				Reconciled:    true,
+			},
+		},
+		{
+			name: "Reconcile wallet empty output",
+			args: args{
+				user: entity.User{},
+			},
+			want: usecase.ReconcileWalletOutput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconcileWalletPresenter()
+			if got := r.Output(tt.args.user, tt.args.ledger, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type reverseTransferPresenter struct{}
+
+// NewReverseTransferPresenter creates new reverseTransferPresenter
+func NewReverseTransferPresenter() usecase.ReverseTransferPresenter {
+	return reverseTransferPresenter{}
+}
+
+// Output returns the transfer reversal response
+func (r reverseTransferPresenter) Output(reversal entity.Transfer, original entity.Transfer) usecase.ReverseTransferOutput {
+	return usecase.ReverseTransferOutput{
+		ID:               reversal.ID().Value(),
+		ReversalOf:       reversal.ReversalOf().Value(),
+		PayerID:          reversal.Payer().Value(),
+		PayeeID:          reversal.Payee().Value(),
+		Value:            reversal.Value().Amount().Value(),
+		Currency:         reversal.Value().Currency().String(),
+		CreditedValue:    reversal.Credit().Amount().Value(),
+		CreditedCurrency: reversal.Credit().Currency().String(),
+		Rate:             reversal.Rate().String(),
+		Status:           reversal.Status().String(),
+		Original: usecase.ReverseTransferOriginalOutput{
+			Status:     original.Status().String(),
+			Refunded:   original.Refunded().Amount().Value(),
+			Refundable: original.Refundable().Amount().Value(),
+		},
+		CreatedAt: reversal.CreatedAt().Format(time.RFC3339),
+	}
+}
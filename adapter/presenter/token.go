@@ -0,0 +1,26 @@
+package presenter
+
+import (
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type tokenPresenter struct{}
+
+// NewTokenPresenter creates new tokenPresenter
+func NewTokenPresenter() usecase.TokenPresenter {
+	return tokenPresenter{}
+}
+
+// Output returns the issued tokens as an OAuth 2.0 bearer token response
+func (t tokenPresenter) Output(tokens usecase.Tokens) usecase.TokenOutput {
+	if tokens.AccessToken == "" {
+		return usecase.TokenOutput{}
+	}
+
+	return usecase.TokenOutput{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(tokens.AccessTokenExpiresAt.Sub(tokens.IssuedAt).Seconds()),
+		RefreshToken: tokens.RefreshToken,
+	}
+}
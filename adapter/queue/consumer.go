@@ -0,0 +1,237 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/streadway/amqp"
+)
+
+const (
+	attemptsHeader  = "x-attempts"
+	lastErrorHeader = "x-last-error"
+
+	defaultBackoff = time.Second
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	errDeliveriesClosed = errors.New("deliveries channel closed by the server")
+)
+
+type (
+	// ConsumerConfig defines the queues and the retry policy of the consumer
+	ConsumerConfig struct {
+		Queue           string
+		RetryQueue      string
+		DeadLetterQueue string
+		Prefetch        int
+		Concurrency     int
+		MaxAttempts     int
+		Backoff         time.Duration
+		MaxBackoff      time.Duration
+		// Timeout bounds the handling of a delivery
+		Timeout time.Duration
+	}
+
+	// Channel is the subset of *amqp.Channel used by the producer and the consumer, an in-process broker implements it
+	// to run them without RabbitMQ
+	Channel interface {
+		Qos(prefetchCount, prefetchSize int, global bool) error
+		Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+		Cancel(consumer string, noWait bool) error
+		Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	}
+
+	consumer struct {
+		channel Channel
+		cfg     ConsumerConfig
+		handler Handler
+		log     logger.Logger
+		logKey  string
+	}
+)
+
+// NewConsumer creates new consumer with its dependencies
+func NewConsumer(ch Channel, cfg ConsumerConfig, h Handler, l logger.Logger) Consumer {
+	return newConsumer(ch, cfg, h, l)
+}
+
+func newConsumer(ch Channel, cfg ConsumerConfig, h Handler, l logger.Logger) consumer {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Prefetch < cfg.Concurrency {
+		cfg.Prefetch = cfg.Concurrency
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return consumer{
+		channel: ch,
+		cfg:     cfg,
+		handler: h,
+		log:     l,
+		logKey:  "queue_consumer",
+	}
+}
+
+// Consume handles the messages of the queue until the context is done, in-flight messages are finished before returning
+func (c consumer) Consume(ctx context.Context) error {
+	if err := c.channel.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return err
+	}
+
+	var tag = uuid.New().String()
+	deliveries, err := c.channel.Consume(c.cfg.Queue, tag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+		// the messages received before the shutdown are still handled, their context is not canceled with ctx
+		handleCtx = context.WithoutCancel(ctx)
+	)
+	for i := 0; i < c.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range deliveries {
+				c.handle(handleCtx, d)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	c.log.WithFields(logger.Fields{
+		"key":         c.logKey,
+		"queue":       c.cfg.Queue,
+		"prefetch":    c.cfg.Prefetch,
+		"concurrency": c.cfg.Concurrency,
+	}).Infof("consuming messages")
+
+	select {
+	case <-done:
+		return errDeliveriesClosed
+	case <-ctx.Done():
+	}
+
+	// the server stops delivering and closes deliveries, the messages already received are still handled
+	if err := c.channel.Cancel(tag, false); err != nil {
+		return err
+	}
+	<-done
+
+	c.log.WithFields(logger.Fields{
+		"key":   c.logKey,
+		"queue": c.cfg.Queue,
+	}).Infof("consumer stopped")
+
+	return nil
+}
+
+func (c consumer) handle(ctx context.Context, d amqp.Delivery) {
+	var attempt = attempts(d.Headers) + 1
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	err := c.handler.Handle(ctx, d.Body)
+	if err == nil {
+		c.log.WithFields(logger.Fields{
+			"key":     c.logKey,
+			"attempt": attempt,
+		}).Infof("success to handle message")
+
+		_ = d.Ack(false)
+		return
+	}
+
+	var (
+		queue      = c.cfg.RetryQueue
+		expiration string
+	)
+	if attempt >= c.cfg.MaxAttempts || errors.Is(err, ErrUnprocessableMessage) {
+		queue = c.cfg.DeadLetterQueue
+	} else {
+		expiration = strconv.FormatInt(c.backoff(attempt).Milliseconds(), 10)
+	}
+
+	c.log.WithFields(logger.Fields{
+		"key":     c.logKey,
+		"error":   err.Error(),
+		"attempt": attempt,
+		"queue":   queue,
+	}).Errorf("failed to handle message")
+
+	if err := c.channel.Publish(
+		"",
+		queue,
+		false,
+		false,
+		amqp.Publishing{
+			Headers: amqp.Table{
+				attemptsHeader:  int64(attempt),
+				lastErrorHeader: err.Error(),
+			},
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Expiration:   expiration,
+			Body:         d.Body,
+		}); err != nil {
+		c.log.WithFields(logger.Fields{
+			"key":   c.logKey,
+			"error": err.Error(),
+		}).Errorf("failed to publish message, returning it to the queue")
+
+		_ = d.Nack(false, true)
+		return
+	}
+
+	_ = d.Ack(false)
+}
+
+// backoff doubles the delay on every attempt up to MaxBackoff.
+// The retry queue only expires the message at its head, so a message may wait for a longer delay queued before it.
+func (c consumer) backoff(attempt int) time.Duration {
+	var delay = c.cfg.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if c.cfg.MaxBackoff > 0 && delay >= c.cfg.MaxBackoff {
+			return c.cfg.MaxBackoff
+		}
+	}
+
+	return delay
+}
+
+// attempts returns how many times the message was already handled
+func attempts(headers amqp.Table) int {
+	switch v := headers[attemptsHeader].(type) {
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
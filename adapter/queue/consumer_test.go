@@ -0,0 +1,256 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/streadway/amqp"
+)
+
+type stubHandler struct {
+	err error
+}
+
+func (s stubHandler) Handle(context.Context, []byte) error {
+	return s.err
+}
+
+// spyHandler records the error and the deadline of the context of every delivery
+type spyHandler struct {
+	mu        sync.Mutex
+	errs      []error
+	deadlines int
+}
+
+func (s *spyHandler) Handle(ctx context.Context, _ []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errs = append(s.errs, ctx.Err())
+	if _, ok := ctx.Deadline(); ok {
+		s.deadlines++
+	}
+	return nil
+}
+
+type spyAcknowledger struct {
+	mu      sync.Mutex
+	acked   int
+	nacked  int
+	requeue bool
+}
+
+func (s *spyAcknowledger) Ack(uint64, bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acked++
+	return nil
+}
+
+func (s *spyAcknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nacked++
+	s.requeue = requeue
+	return nil
+}
+
+func (s *spyAcknowledger) Reject(uint64, bool) error {
+	return nil
+}
+
+type spyChannel struct {
+	deliveries chan amqp.Delivery
+	publishErr error
+	published  []struct {
+		queue string
+		msg   amqp.Publishing
+	}
+}
+
+func (s *spyChannel) Qos(int, int, bool) error {
+	return nil
+}
+
+func (s *spyChannel) Consume(string, string, bool, bool, bool, bool, amqp.Table) (<-chan amqp.Delivery, error) {
+	return s.deliveries, nil
+}
+
+func (s *spyChannel) Cancel(string, bool) error {
+	close(s.deliveries)
+	return nil
+}
+
+func (s *spyChannel) Publish(_ string, key string, _ bool, _ bool, msg amqp.Publishing) error {
+	s.published = append(s.published, struct {
+		queue string
+		msg   amqp.Publishing
+	}{key, msg})
+	return s.publishErr
+}
+
+func TestConsumer_handle(t *testing.T) {
+	var cfg = ConsumerConfig{
+		Queue:           "notify",
+		RetryQueue:      "notify.retry",
+		DeadLetterQueue: "notify.dlq",
+		MaxAttempts:     3,
+		Backoff:         time.Second,
+		MaxBackoff:      5 * time.Second,
+	}
+
+	tests := []struct {
+		name           string
+		handler        stubHandler
+		headers        amqp.Table
+		publishErr     error
+		wantQueue      string
+		wantExpiration string
+		wantAttempts   int64
+		wantAcked      int
+		wantNacked     int
+	}{
+		{
+			name:      "Handled message is acked",
+			handler:   stubHandler{},
+			wantAcked: 1,
+		},
+		{
+			name:           "First failure is retried after the backoff",
+			handler:        stubHandler{err: errors.New("fail")},
+			wantQueue:      "notify.retry",
+			wantExpiration: "1000",
+			wantAttempts:   1,
+			wantAcked:      1,
+		},
+		{
+			name:           "Second failure doubles the backoff",
+			handler:        stubHandler{err: errors.New("fail")},
+			headers:        amqp.Table{attemptsHeader: int32(1)},
+			wantQueue:      "notify.retry",
+			wantExpiration: "2000",
+			wantAttempts:   2,
+			wantAcked:      1,
+		},
+		{
+			name:         "Last attempt is dead-lettered",
+			handler:      stubHandler{err: errors.New("fail")},
+			headers:      amqp.Table{attemptsHeader: int64(2)},
+			wantQueue:    "notify.dlq",
+			wantAttempts: 3,
+			wantAcked:    1,
+		},
+		{
+			name:         "Unprocessable message is dead-lettered at once",
+			handler:      stubHandler{err: ErrUnprocessableMessage},
+			wantQueue:    "notify.dlq",
+			wantAttempts: 1,
+			wantAcked:    1,
+		},
+		{
+			name:           "Publish error returns the message to the queue",
+			handler:        stubHandler{err: errors.New("fail")},
+			publishErr:     errors.New("channel closed"),
+			wantQueue:      "notify.retry",
+			wantExpiration: "1000",
+			wantAttempts:   1,
+			wantNacked:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ack = &spyAcknowledger{}
+				ch  = &spyChannel{publishErr: tt.publishErr}
+				c   = newConsumer(ch, cfg, tt.handler, logger.Dummy{})
+			)
+
+			c.handle(context.Background(), amqp.Delivery{Acknowledger: ack, Headers: tt.headers, Body: []byte(`{}`)})
+
+			if ack.acked != tt.wantAcked || ack.nacked != tt.wantNacked {
+				t.Errorf("[TestCase '%s'] Got: '%d acked, %d nacked' | Want: '%d acked, %d nacked'", tt.name, ack.acked, ack.nacked, tt.wantAcked, tt.wantNacked)
+			}
+
+			if tt.wantNacked > 0 && !ack.requeue {
+				t.Errorf("[TestCase '%s'] Got: 'nack without requeue' | Want: 'nack with requeue'", tt.name)
+			}
+
+			if tt.wantQueue == "" {
+				if len(ch.published) != 0 {
+					t.Errorf("[TestCase '%s'] Got: '%d published' | Want: '0 published'", tt.name, len(ch.published))
+				}
+				return
+			}
+
+			if len(ch.published) != 1 {
+				t.Fatalf("[TestCase '%s'] Got: '%d published' | Want: '1 published'", tt.name, len(ch.published))
+			}
+
+			got := ch.published[0]
+			if got.queue != tt.wantQueue {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got.queue, tt.wantQueue)
+			}
+
+			if got.msg.Expiration != tt.wantExpiration {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got.msg.Expiration, tt.wantExpiration)
+			}
+
+			if got.msg.Headers[attemptsHeader] != tt.wantAttempts {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got.msg.Headers[attemptsHeader], tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestConsumer_backoff(t *testing.T) {
+	var c = newConsumer(&spyChannel{}, ConsumerConfig{Backoff: time.Second, MaxBackoff: 5 * time.Second}, stubHandler{}, logger.Dummy{})
+
+	for attempt, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if got := c.backoff(attempt); got != want {
+			t.Errorf("[TestCase 'attempt %d'] Got: '%v' | Want: '%v'", attempt, got, want)
+		}
+	}
+}
+
+func TestConsumer_Consume(t *testing.T) {
+	var (
+		ack     = &spyAcknowledger{}
+		handler = &spyHandler{}
+		ch      = &spyChannel{deliveries: make(chan amqp.Delivery, 3)}
+		c       = newConsumer(ch, ConsumerConfig{Concurrency: 2}, handler, logger.Dummy{})
+	)
+	for i := 0; i < 3; i++ {
+		ch.deliveries <- amqp.Delivery{Acknowledger: ack}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.Consume(ctx); err != nil {
+		t.Fatalf("[TestCase 'graceful shutdown'] Got: '%v' | Want: '%v'", err, nil)
+	}
+
+	if ack.acked != 3 {
+		t.Errorf("[TestCase 'graceful shutdown'] Got: '%d acked' | Want: '3 acked'", ack.acked)
+	}
+
+	for _, err := range handler.errs {
+		if err != nil {
+			t.Errorf("[TestCase 'graceful shutdown'] Got: '%v' | Want: '%v'", err, nil)
+		}
+	}
+
+	if handler.deadlines != 3 {
+		t.Errorf("[TestCase 'delivery timeout'] Got: '%d deadlines' | Want: '3 deadlines'", handler.deadlines)
+	}
+}
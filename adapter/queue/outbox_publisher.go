@@ -0,0 +1,22 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type outboxPublisher struct {
+	producer Producer
+}
+
+// NewOutboxPublisher creates new outboxPublisher with its dependencies
+func NewOutboxPublisher(p Producer) usecase.OutboxPublisher {
+	return outboxPublisher{producer: p}
+}
+
+// Publish sends the payload of the message identified by the message ID
+func (o outboxPublisher) Publish(_ context.Context, m entity.OutboxMessage) error {
+	return o.producer.PublishWithID(m.ID().Value(), m.Payload())
+}
@@ -1,19 +1,26 @@
 package queue
 
 import (
+	"errors"
+
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
 	"github.com/streadway/amqp"
 )
 
+var (
+	errPublishNotConfirmed = errors.New("publish not confirmed by the server")
+)
+
 type producer struct {
-	channel   *amqp.Channel
+	channel   Channel
+	confirms  chan amqp.Confirmation
 	queueName string
 	log       logger.Logger
 	logKey    string
 }
 
 // NewProducer creates new producer with its dependencies
-func NewProducer(ch *amqp.Channel, qn string, l logger.Logger) Producer {
+func NewProducer(ch Channel, qn string, l logger.Logger) Producer {
 	return producer{
 		channel:   ch,
 		queueName: qn,
@@ -22,29 +29,75 @@ func NewProducer(ch *amqp.Channel, qn string, l logger.Logger) Producer {
 	}
 }
 
+// NewConfirmedProducer creates new producer which puts the channel in confirm mode and waits for the server to
+// confirm every message, a message is only reported as published once the server took responsibility for it.
+// It must not be used concurrently
+func NewConfirmedProducer(ch *amqp.Channel, qn string, l logger.Logger) (Producer, error) {
+	if err := ch.Confirm(false); err != nil {
+		return nil, err
+	}
+
+	p := producer{
+		channel:   ch,
+		confirms:  ch.NotifyPublish(make(chan amqp.Confirmation, 1)),
+		queueName: qn,
+		log:       l,
+		logKey:    "queue_producer",
+	}
+
+	return p, nil
+}
+
 // Publish sends a Publishing from the client to an exchange on the server
 func (p producer) Publish(message []byte) error {
+	return p.publish(amqp.Publishing{
+		Headers:     amqp.Table{},
+		ContentType: "text/plain",
+		Body:        message,
+	})
+}
+
+// PublishWithID sends a persistent message identified by ID, consumers use it to discard the copies
+// of a message delivered more than once
+func (p producer) PublishWithID(ID string, message []byte) error {
+	return p.publish(amqp.Publishing{
+		Headers:      amqp.Table{},
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    ID,
+		Body:         message,
+	})
+}
+
+func (p producer) publish(msg amqp.Publishing) error {
 	if err := p.channel.Publish(
 		"",
 		p.queueName,
 		false,
 		false,
-		amqp.Publishing{
-			Headers:     amqp.Table{},
-			ContentType: "text/plain",
-			Body:        message,
-		}); err != nil {
+		msg); err != nil {
 		p.log.WithFields(logger.Fields{
 			"key":   p.logKey,
 			"error": err.Error(),
-		}).Errorf("failed to publish message: %s", message)
+		}).Errorf("failed to publish message: %s", msg.Body)
 
 		return err
 	}
 
+	if p.confirms != nil {
+		if confirmation, ok := <-p.confirms; !ok || !confirmation.Ack {
+			p.log.WithFields(logger.Fields{
+				"key":   p.logKey,
+				"error": errPublishNotConfirmed.Error(),
+			}).Errorf("failed to publish message: %s", msg.Body)
+
+			return errPublishNotConfirmed
+		}
+	}
+
 	p.log.WithFields(logger.Fields{
 		"key": p.logKey,
-	}).Infof("new message publish: %s", message)
+	}).Infof("new message publish: %s", msg.Body)
 
 	return nil
-}
\ No newline at end of file
+}
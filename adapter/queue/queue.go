@@ -1,13 +1,27 @@
 package queue
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnprocessableMessage marks messages that fail on every attempt, they are dead-lettered without being retried
+var ErrUnprocessableMessage = errors.New("unprocessable message")
+
 type (
 	// Producer port
 	Producer interface {
 		Publish([]byte) error
+		PublishWithID(ID string, message []byte) error
 	}
 
 	// Consumer port
 	Consumer interface {
-		Consume() error
+		Consume(context.Context) error
+	}
+
+	// Handler processes a consumed message with the context of its delivery, an error makes the message be retried
+	Handler interface {
+		Handle(context.Context, []byte) error
 	}
-)
\ No newline at end of file
+)
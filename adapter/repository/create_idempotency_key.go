@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type (
+	// Bson data
+	idempotencyKeyBSON struct {
+		PayerID     string             `bson:"payer_id"`
+		Key         string             `bson:"key"`
+		Fingerprint string             `bson:"fingerprint"`
+		Transfer    createTransferBSON `bson:"transfer"`
+		CreatedAt   time.Time          `bson:"created_at"`
+	}
+
+	createIdempotencyKeyRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewCreateIdempotencyKeyRepository creates new createIdempotencyKeyRepository with its dependencies
+func NewCreateIdempotencyKeyRepository(handler *database.MongoHandler) entity.IdempotencyRepositoryCreator {
+	return createIdempotencyKeyRepository{
+		handler:    handler,
+		collection: "idempotency_keys",
+	}
+}
+
+// Create performs insertOne into the database, the unique index on payer_id and key rejects concurrent uses of the
+// same key by the payer
+func (c createIdempotencyKeyRepository) Create(ctx context.Context, i entity.IdempotencyKey) error {
+	var bson = idempotencyKeyBSON{
+		PayerID:     i.Payer().Value(),
+		Key:         i.Key(),
+		Fingerprint: i.Fingerprint(),
+		Transfer:    transferToBSON(i.Transfer()),
+		CreatedAt:   i.CreatedAt(),
+	}
+
+	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return entity.ErrIdempotencyKeyInUse
+		}
+
+		return errors.Wrap(err, entity.ErrCreateIdempotencyKey.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Bson data
+	journalEntryBSON struct {
+		ID          string        `bson:"id"`
+		TransferID  string        `bson:"transfer_id"`
+		Description string        `bson:"description"`
+		Postings    []postingBSON `bson:"postings"`
+		CreatedAt   time.Time     `bson:"created_at"`
+	}
+
+	// Bson data
+	postingBSON struct {
+		Account   string `bson:"account"`
+		Direction string `bson:"direction"`
+		Currency  string `bson:"currency"`
+		Amount    int64  `bson:"amount"`
+	}
+
+	createJournalEntryRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewCreateJournalEntryRepository creates new createJournalEntryRepository with its dependencies
+func NewCreateJournalEntryRepository(handler *database.MongoHandler) entity.LedgerRepositoryCreator {
+	return createJournalEntryRepository{
+		handler:    handler,
+		collection: "ledger",
+	}
+}
+
+// Create performs insertOne into the database
+func (c createJournalEntryRepository) Create(ctx context.Context, j entity.JournalEntry) (entity.JournalEntry, error) {
+	var bson = journalEntryBSON{
+		ID:          j.ID().Value(),
+		TransferID:  j.TransferID().Value(),
+		Description: j.Description(),
+		CreatedAt:   j.CreatedAt(),
+	}
+
+	for _, p := range j.Postings() {
+		bson.Postings = append(bson.Postings, postingBSON{
+			Account:   p.Account().Value(),
+			Direction: p.Direction().String(),
+			Currency:  p.Money().Currency().String(),
+			Amount:    p.Money().Amount().Value(),
+		})
+	}
+
+	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+		return entity.JournalEntry{}, errors.Wrap(err, entity.ErrCreateJournalEntry.Error())
+	}
+
+	return j, nil
+}
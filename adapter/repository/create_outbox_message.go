@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Bson data
+	outboxMessageBSON struct {
+		ID          string     `bson:"id"`
+		AggregateID string     `bson:"aggregate_id"`
+		Kind        string     `bson:"kind"`
+		Payload     []byte     `bson:"payload"`
+		CreatedAt   time.Time  `bson:"created_at"`
+		PublishedAt *time.Time `bson:"published_at"`
+		ProcessedAt *time.Time `bson:"processed_at"`
+	}
+
+	createOutboxMessageRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewCreateOutboxMessageRepository creates new createOutboxMessageRepository with its dependencies
+func NewCreateOutboxMessageRepository(handler *database.MongoHandler) entity.OutboxRepositoryCreator {
+	return createOutboxMessageRepository{
+		handler:    handler,
+		collection: "outbox",
+	}
+}
+
+// Create performs insertOne into the database, called with a session context it joins the transaction
+func (c createOutboxMessageRepository) Create(ctx context.Context, m entity.OutboxMessage) error {
+	var bson = outboxMessageBSON{
+		ID:          m.ID().Value(),
+		AggregateID: m.AggregateID().Value(),
+		Kind:        m.Kind(),
+		Payload:     m.Payload(),
+		CreatedAt:   m.CreatedAt(),
+		PublishedAt: m.PublishedAt(),
+		ProcessedAt: m.ProcessedAt(),
+	}
+
+	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+		return errors.Wrap(err, entity.ErrCreateOutboxMessage.Error())
+	}
+
+	return nil
+}
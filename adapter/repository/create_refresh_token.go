@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Bson data
+	refreshTokenBSON struct {
+		ID        string     `bson:"id"`
+		UserID    string     `bson:"user_id"`
+		Hash      string     `bson:"hash"`
+		ExpiresAt time.Time  `bson:"expires_at"`
+		RevokedAt *time.Time `bson:"revoked_at"`
+		CreatedAt time.Time  `bson:"created_at"`
+	}
+
+	createRefreshTokenRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewCreateRefreshTokenRepository creates new createRefreshTokenRepository with its dependencies
+func NewCreateRefreshTokenRepository(handler *database.MongoHandler) entity.RefreshTokenRepositoryCreator {
+	return createRefreshTokenRepository{
+		handler:    handler,
+		collection: "refresh_tokens",
+	}
+}
+
+// Create performs insertOne into the database
+func (c createRefreshTokenRepository) Create(ctx context.Context, r entity.RefreshToken) error {
+	var bson = refreshTokenBSON{
+		ID:        r.ID().Value(),
+		UserID:    r.UserID().Value(),
+		Hash:      r.Hash(),
+		ExpiresAt: r.ExpiresAt(),
+		RevokedAt: r.RevokedAt(),
+		CreatedAt: r.CreatedAt(),
+	}
+
+	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+		return errors.Wrap(err, entity.ErrCreateRefreshToken.Error())
+	}
+
+	return nil
+}
@@ -2,72 +2,103 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"github.com/pkg/errors"
 
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
-
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type (
+	// Bson data
+	transferBSON struct {
+		ID             string                   `bson:"id"`
+		PayerID        string                   `bson:"payer"`
+		PayeeID        string                   `bson:"payee"`
+		Value          int64                    `bson:"value"`
+		Currency       string                   `bson:"currency"`
+		Credit         int64                    `bson:"credited_value"`
+		CreditCurrency string                   `bson:"credited_currency"`
+		Rate           string                   `bson:"rate"`
+		Refunded       int64                    `bson:"refunded"`
+		ReversalOf     string                   `bson:"reversal_of,omitempty"`
+		Status         string                   `bson:"status"`
+		History        []transferTransitionBSON `bson:"history"`
+	}
+
 	// Bson data
 	createTransferBSON struct {
-		ID		string	`bson:"id"`
-		PayerID	string	`bson:"payer"`
-		PayeeID	string	`bson:"payee"`
-		Value	int64	`bson:"value"`
-		CreatedAt string	`bson:"created_at"`
+		transferBSON `bson:",inline"`
+		CreatedAt    time.Time `bson:"created_at"`
+	}
+
+	// Bson data
+	transferTransitionBSON struct {
+		From   string    `bson:"from"`
+		To     string    `bson:"to"`
+		Reason string    `bson:"reason,omitempty"`
+		At     time.Time `bson:"at"`
 	}
 
 	createTransferRepository struct {
-		handler		*database.MongoHandler
-		collection	string
+		handler    *database.MongoHandler
+		collection string
 	}
 )
 
 // NewCreateTransferRepository creates new createTransferRepository with its dependencies
 func NewCreateTransferRepository(handler *database.MongoHandler) entity.TransferRepositoryCreator {
 	return createTransferRepository{
-		handler: handler,
+		handler:    handler,
 		collection: "transfer",
 	}
 }
 
+// Create performs insertOne into the database
 func (c createTransferRepository) Create(ctx context.Context, t entity.Transfer) (entity.Transfer, error) {
-	var bson = createTransferBSON{
-		ID: 		t.ID().Value(),
-		PayerID: 	t.Payer().Value(),
-		PayeeID: 	t.Payee().Value(),
-		Value: 		t.Value().Amount().Value(),
-		CreatedAt:	t.CreatedAt().String(),
-	}
-
-	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, transferToBSON(t)); err != nil {
 		return entity.Transfer{}, errors.Wrap(err, entity.ErrCreateTransfer.Error())
 	}
 
 	return t, nil
 }
 
+// WithTransaction runs fn inside a database transaction
 func (c createTransferRepository) WithTransaction(ctx context.Context, fn func(ctx2 context.Context) error) error {
-	callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
-		err := fn(sessCtx)
-		if err != nil {
-			return nil, err
-		}
-		return nil, err
-	}
+	return withTransaction(ctx, c.handler, fn)
+}
 
-	session, err := c.handler.Client().StartSession()
-	if err != nil {
-		return err
+func transferToBSON(t entity.Transfer) createTransferBSON {
+	return createTransferBSON{
+		transferBSON: transferBSON{
+			ID:             t.ID().Value(),
+			PayerID:        t.Payer().Value(),
+			PayeeID:        t.Payee().Value(),
+			Value:          t.Value().Amount().Value(),
+			Currency:       t.Value().Currency().String(),
+			Credit:         t.Credit().Amount().Value(),
+			CreditCurrency: t.Credit().Currency().String(),
+			Rate:           t.Rate().String(),
+			Refunded:       t.Refunded().Amount().Value(),
+			ReversalOf:     t.ReversalOf().Value(),
+			Status:         t.Status().String(),
+			History:        historyToBSON(t.History()),
+		},
+		CreatedAt: t.CreatedAt(),
 	}
-	defer session.EndSession(ctx)
+}
 
-	_, err = session.WithTransaction(ctx, callback)
-	if err != nil {
-		return err
+func historyToBSON(history []entity.TransferTransition) []transferTransitionBSON {
+	var result []transferTransitionBSON
+	for _, h := range history {
+		result = append(result, transferTransitionBSON{
+			From:   h.From().String(),
+			To:     h.To().String(),
+			Reason: h.Reason(),
+			At:     h.At(),
+		})
 	}
-	return nil
+
+	return result
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type (
@@ -20,6 +21,7 @@ type (
 		Wallet    createUserWalletBSON   `bson:"wallet"`
 		Roles     createUserRolesBSON    `bson:"roles"`
 		Type      string                 `bson:"type"`
+		KYCTier   string                 `bson:"kyc_tier"`
 		CreatedAt time.Time              `bson:"created_at"`
 	}
 
@@ -33,6 +35,7 @@ type (
 	createUserWalletBSON struct {
 		Currency string `bson:"currency"`
 		Amount   int64  `bson:"amount"`
+		Version  int64  `bson:"version"`
 	}
 
 	// Bson data
@@ -68,14 +71,23 @@ func (c createUserRepository) Create(ctx context.Context, u entity.User) (entity
 		Wallet: createUserWalletBSON{
 			Currency: u.Wallet().Money().Currency().String(),
 			Amount:   u.Wallet().Money().Amount().Value(),
+			Version:  u.Wallet().Version(),
 		},
 		Type:      u.TypeUser().String(),
+		KYCTier:   u.KYCTier().String(),
 		CreatedAt: u.CreatedAt(),
 	}
 
 	if _, err := c.handler.Db().Collection(c.collection).InsertOne(ctx, bson); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return entity.User{}, errors.Wrap(entity.ErrUserAlreadyExists, entity.ErrCreateUser.Error())
+		}
 		return entity.User{}, errors.Wrap(err, entity.ErrCreateUser.Error())
 	}
 
 	return u, nil
-}
\ No newline at end of file
+}
+// WithTransaction runs fn inside a database transaction
+func (c createUserRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return withTransaction(ctx, c.handler, fn)
+}
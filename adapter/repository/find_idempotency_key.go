@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type findIdempotencyKeyRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewFindIdempotencyKeyRepository creates new findIdempotencyKeyRepository with its dependencies
+func NewFindIdempotencyKeyRepository(handler *database.MongoHandler) entity.IdempotencyRepositoryFinder {
+	return findIdempotencyKeyRepository{
+		handler:    handler,
+		collection: "idempotency_keys",
+	}
+}
+
+// FindByKey performs findOne into the database
+func (f findIdempotencyKeyRepository) FindByKey(ctx context.Context, payer vo.Uuid, key string) (entity.IdempotencyKey, error) {
+	var (
+		keyBSON = &idempotencyKeyBSON{}
+		query   = bson.M{"payer_id": payer.Value(), "key": key}
+	)
+
+	err := f.handler.Db().Collection(f.collection).FindOne(ctx, query).Decode(keyBSON)
+	if err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return entity.IdempotencyKey{}, entity.ErrNotFoundIdempotencyKey
+		default:
+			return entity.IdempotencyKey{}, errors.Wrap(err, entity.ErrFindIdempotencyKey.Error())
+		}
+	}
+
+	transfer, err := transferFromBSON(keyBSON.Transfer.transferBSON, keyBSON.Transfer.CreatedAt)
+	if err != nil {
+		return entity.IdempotencyKey{}, err
+	}
+
+	return entity.NewIdempotencyKey(keyBSON.Key, keyBSON.Fingerprint, transfer, keyBSON.CreatedAt), nil
+}
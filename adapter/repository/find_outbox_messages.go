@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type findOutboxMessagesRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewFindOutboxMessagesRepository creates new findOutboxMessagesRepository with its dependencies
+func NewFindOutboxMessagesRepository(handler *database.MongoHandler) entity.OutboxRepositoryFinder {
+	return findOutboxMessagesRepository{
+		handler:    handler,
+		collection: "outbox",
+	}
+}
+
+// FindPending performs find into the database for the messages without a publication date
+func (f findOutboxMessagesRepository) FindPending(ctx context.Context, limit int) ([]entity.OutboxMessage, error) {
+	var (
+		query = bson.M{"published_at": nil}
+		opts  = options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}}).
+			SetLimit(int64(limit))
+	)
+
+	cursor, err := f.handler.Db().Collection(f.collection).Find(ctx, query, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+	}
+
+	var messagesBSON []outboxMessageBSON
+	if err = cursor.All(ctx, &messagesBSON); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+	}
+
+	var messages = make([]entity.OutboxMessage, 0, len(messagesBSON))
+	for _, m := range messagesBSON {
+		message, err := m.outboxMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// FindByID performs findOne into the database for the message of the ID
+func (f findOutboxMessagesRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.OutboxMessage, error) {
+	var m outboxMessageBSON
+
+	err := f.handler.Db().Collection(f.collection).FindOne(ctx, bson.M{"id": ID.Value()}).Decode(&m)
+	if err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return entity.OutboxMessage{}, entity.ErrNotFoundOutboxMessage
+		default:
+			return entity.OutboxMessage{}, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+		}
+	}
+
+	return m.outboxMessage()
+}
+
+// outboxMessage restores the message stored in the document
+func (m outboxMessageBSON) outboxMessage() (entity.OutboxMessage, error) {
+	ID, err := vo.NewUuid(m.ID)
+	if err != nil {
+		return entity.OutboxMessage{}, err
+	}
+
+	aggregateID, err := vo.NewUuid(m.AggregateID)
+	if err != nil {
+		return entity.OutboxMessage{}, err
+	}
+
+	return entity.NewPublishedOutboxMessage(
+		ID,
+		aggregateID,
+		m.Kind,
+		m.Payload,
+		m.CreatedAt,
+		m.PublishedAt,
+		m.ProcessedAt,
+	), nil
+}
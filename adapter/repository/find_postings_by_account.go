@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type findPostingsByAccountRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewFindPostingsByAccountRepository creates new findPostingsByAccountRepository with its dependencies
+func NewFindPostingsByAccountRepository(handler *database.MongoHandler) entity.LedgerRepositoryFinder {
+	return findPostingsByAccountRepository{
+		handler:    handler,
+		collection: "ledger",
+	}
+}
+
+// FindPostingsByAccount performs find into the database and returns the postings of the account
+func (f findPostingsByAccountRepository) FindPostingsByAccount(ctx context.Context, account vo.Uuid) ([]entity.Posting, error) {
+	var query = bson.M{"postings.account": account.Value()}
+
+	cursor, err := f.handler.Db().Collection(f.collection).Find(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+	}
+	defer cursor.Close(ctx)
+
+	var entries []journalEntryBSON
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+	}
+
+	var postings []entity.Posting
+	for _, entry := range entries {
+		for _, p := range entry.Postings {
+			if p.Account != account.Value() {
+				continue
+			}
+
+			posting, err := postingFromBSON(p)
+			if err != nil {
+				return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+			}
+
+			postings = append(postings, posting)
+		}
+	}
+
+	return postings, nil
+}
+
+func postingFromBSON(p postingBSON) (entity.Posting, error) {
+	account, err := vo.NewUuid(p.Account)
+	if err != nil {
+		return entity.Posting{}, err
+	}
+
+	currency, err := vo.NewCurrency(p.Currency)
+	if err != nil {
+		return entity.Posting{}, err
+	}
+
+	amount, err := vo.NewAmount(p.Amount)
+	if err != nil {
+		return entity.Posting{}, err
+	}
+
+	return entity.NewPosting(account, entity.PostingDirection(p.Direction), vo.NewMoney(currency, amount))
+}
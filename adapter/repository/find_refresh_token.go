@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type findRefreshTokenRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewFindRefreshTokenRepository creates new findRefreshTokenRepository with its dependencies
+func NewFindRefreshTokenRepository(handler *database.MongoHandler) entity.RefreshTokenRepositoryFinder {
+	return findRefreshTokenRepository{
+		handler:    handler,
+		collection: "refresh_tokens",
+	}
+}
+
+// FindByHash performs findOne into the database, an unknown token is reported as an invalid one
+func (f findRefreshTokenRepository) FindByHash(ctx context.Context, hash string) (entity.RefreshToken, error) {
+	var tokenBSON refreshTokenBSON
+
+	err := f.handler.Db().Collection(f.collection).FindOne(ctx, bson.M{"hash": hash}).Decode(&tokenBSON)
+	if err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return entity.RefreshToken{}, entity.ErrInvalidRefreshToken
+		default:
+			return entity.RefreshToken{}, errors.Wrap(err, entity.ErrFindRefreshToken.Error())
+		}
+	}
+
+	ID, err := vo.NewUuid(tokenBSON.ID)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	userID, err := vo.NewUuid(tokenBSON.UserID)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return entity.NewRefreshTokenFromHash(
+		ID,
+		userID,
+		tokenBSON.Hash,
+		tokenBSON.ExpiresAt,
+		tokenBSON.RevokedAt,
+		tokenBSON.CreatedAt,
+	), nil
+}
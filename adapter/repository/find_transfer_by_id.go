@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var errInvalidCreatedAt = errors.New("invalid created_at")
+
+type (
+	// Bson data, created_at is a BSON date or, for legacy transfers, a time.Time.String value
+	findTransferBSON struct {
+		transferBSON `bson:",inline"`
+		CreatedAt    bson.RawValue `bson:"created_at"`
+	}
+
+	findTransferRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewFindTransferRepository creates new findTransferRepository with its dependencies
+func NewFindTransferRepository(handler *database.MongoHandler) entity.TransferRepositoryFinder {
+	return findTransferRepository{
+		handler:    handler,
+		collection: "transfer",
+	}
+}
+
+// FindByID performs findOne into the database
+func (f findTransferRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.Transfer, error) {
+	var (
+		result = &findTransferBSON{}
+		query  = bson.M{"id": ID.Value()}
+	)
+
+	err := f.handler.Db().Collection(f.collection).FindOne(ctx, query).Decode(result)
+	if err != nil {
+		switch err {
+		case mongo.ErrNoDocuments:
+			return entity.Transfer{}, entity.ErrNotFoundTransfer
+		default:
+			return entity.Transfer{}, errors.Wrap(err, entity.ErrFindTransferByID.Error())
+		}
+	}
+
+	transfer, err := result.toEntity()
+	if err != nil {
+		return entity.Transfer{}, errors.Wrap(err, entity.ErrFindTransferByID.Error())
+	}
+
+	return transfer, nil
+}
+
+func (t findTransferBSON) toEntity() (entity.Transfer, error) {
+	createdAt, err := createdAtFromBSON(t.CreatedAt)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	return transferFromBSON(t.transferBSON, createdAt)
+}
+
+func transferFromBSON(t transferBSON, createdAt time.Time) (entity.Transfer, error) {
+	ID, err := vo.NewUuid(t.ID)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	payerID, err := vo.NewUuid(t.PayerID)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	payeeID, err := vo.NewUuid(t.PayeeID)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	// transfers stored before the currency was persisted were always NGN
+	if t.Currency == "" {
+		t.Currency = vo.NGN.String()
+	}
+
+	currency, err := vo.NewCurrency(t.Currency)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	amount, err := vo.NewAmount(t.Value)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	refunded, err := vo.NewAmount(t.Refunded)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	transfer := entity.NewTransfer(ID, payerID, payeeID, vo.NewMoney(currency, amount), createdAt).
+		WithRefunded(vo.NewMoney(currency, refunded))
+
+	// transfers stored before cross-currency transfers credited the payee with the value debited from the payer
+	if t.CreditCurrency != "" && t.CreditCurrency != currency.String() {
+		creditCurrency, err := vo.NewCurrency(t.CreditCurrency)
+		if err != nil {
+			return entity.Transfer{}, err
+		}
+
+		credit, err := vo.NewAmount(t.Credit)
+		if err != nil {
+			return entity.Transfer{}, err
+		}
+
+		rate, err := vo.NewExchangeRate(currency, creditCurrency, t.Rate)
+		if err != nil {
+			return entity.Transfer{}, err
+		}
+
+		transfer = transfer.WithConversion(vo.NewMoney(creditCurrency, credit), rate)
+	}
+
+	if t.ReversalOf != "" {
+		reversalOf, err := vo.NewUuid(t.ReversalOf)
+		if err != nil {
+			return entity.Transfer{}, err
+		}
+
+		transfer = transfer.WithReversalOf(reversalOf)
+	}
+
+	if t.Status == "" {
+		return transfer, nil
+	}
+
+	status, err := vo.NewTransferStatus(t.Status)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	var history []entity.TransferTransition
+	for _, h := range t.History {
+		history = append(history, entity.NewTransferTransition(
+			vo.TransferStatus(h.From),
+			vo.TransferStatus(h.To),
+			h.Reason,
+			h.At,
+		))
+	}
+
+	return transfer.WithStatus(status, history), nil
+}
+
+// createdAtFromBSON reads created_at stored either as a BSON date or as a legacy string
+func createdAtFromBSON(value bson.RawValue) (time.Time, error) {
+	switch value.Type {
+	case bsontype.DateTime:
+		return value.Time().UTC(), nil
+	case bsontype.String:
+		return database.ParseLegacyTime(value.StringValue())
+	default:
+		return time.Time{}, errInvalidCreatedAt
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindByUser performs find into the database, ordered from the newest transfer and paginated by the cursor
+func (f findTransferRepository) FindByUser(ctx context.Context, filter entity.TransferFilter) ([]entity.Transfer, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(filter.Limit))
+
+	cur, err := f.handler.Db().Collection(f.collection).Find(ctx, transferFilterToBSON(filter), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+	}
+	defer cur.Close(ctx)
+
+	var transfers = make([]entity.Transfer, 0)
+	for cur.Next(ctx) {
+		var result findTransferBSON
+		if err := cur.Decode(&result); err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+		}
+
+		transfer, err := result.toEntity()
+		if err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+	}
+
+	return transfers, nil
+}
+
+func transferFilterToBSON(filter entity.TransferFilter) bson.M {
+	var (
+		userID = filter.UserID.Value()
+		and    bson.A
+	)
+
+	switch filter.Direction {
+	case entity.TransferSent:
+		and = append(and, bson.M{"payer": userID})
+	case entity.TransferReceived:
+		and = append(and, bson.M{"payee": userID})
+	default:
+		and = append(and, bson.M{"$or": bson.A{bson.M{"payer": userID}, bson.M{"payee": userID}}})
+	}
+
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		and = append(and, bson.M{"created_at": createdAt})
+	}
+
+	value := bson.M{}
+	if filter.MinValue.Value() > 0 {
+		value["$gte"] = filter.MinValue.Value()
+	}
+	if filter.MaxValue.Value() > 0 {
+		value["$lte"] = filter.MaxValue.Value()
+	}
+	if len(value) > 0 {
+		and = append(and, bson.M{"value": value})
+	}
+
+	if filter.Status != "" {
+		and = append(and, bson.M{"status": filter.Status.String()})
+	}
+
+	if filter.After != nil {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"created_at": bson.M{"$lt": filter.After.CreatedAt}},
+			bson.M{"created_at": filter.After.CreatedAt, "id": bson.M{"$lt": filter.After.ID.Value()}},
+		}})
+	}
+
+	return bson.M{"$and": and}
+}
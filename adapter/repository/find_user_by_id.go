@@ -23,6 +23,7 @@ type (
 		Wallet    findUserByIDWalletBSON   `bson:"wallet"`
 		Roles     findUserByIDRolesBSON    `bson:"roles"`
 		Type      string                   `bson:"type"`
+		KYCTier   string                   `bson:"kyc_tier"`
 		CreatedAt time.Time                `bson:"created_at"`
 	}
 
@@ -36,6 +37,7 @@ type (
 	findUserByIDWalletBSON struct {
 		Currency string `bson:"currency"`
 		Amount   int64  `bson:"amount"`
+		Version  int64  `bson:"version"`
 	}
 
 	// Bson data
@@ -59,10 +61,16 @@ func NewFindUserByIDUserRepository(handler *database.MongoHandler) entity.UserRe
 
 // FindByID performs findOne into the database
 func (f findUserByIDRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.User, error) {
-	var (
-		userBSON = &findUserByIDBSON{}
-		query    = bson.M{"id": ID.Value()}
-	)
+	return f.findOne(ctx, bson.M{"id": ID.Value()}, entity.ErrFindUserByID)
+}
+
+// FindByEmail performs findOne into the database
+func (f findUserByIDRepository) FindByEmail(ctx context.Context, email vo.Email) (entity.User, error) {
+	return f.findOne(ctx, bson.M{"email": email.Value()}, entity.ErrFindUserByEmail)
+}
+
+func (f findUserByIDRepository) findOne(ctx context.Context, query bson.M, errFind error) (entity.User, error) {
+	var userBSON = &findUserByIDBSON{}
 
 	var err = f.handler.Db().Collection(f.collection).
 		FindOne(
@@ -74,10 +82,14 @@ func (f findUserByIDRepository) FindByID(ctx context.Context, ID vo.Uuid) (entit
 		case mongo.ErrNoDocuments:
 			return entity.User{}, entity.ErrNotFoundUser
 		default:
-			return entity.User{}, errors.Wrap(err, entity.ErrFindUserByID.Error())
+			return entity.User{}, errors.Wrap(err, errFind.Error())
 		}
 	}
 
+	return userBSON.toEntity()
+}
+
+func (userBSON findUserByIDBSON) toEntity() (entity.User, error) {
 	uuid, err := vo.NewUuid(userBSON.ID)
 	if err != nil {
 		return entity.User{}, err
@@ -103,13 +115,17 @@ func (f findUserByIDRepository) FindByID(ctx context.Context, ID vo.Uuid) (entit
 		return entity.User{}, err
 	}
 
-	wallet := vo.NewWallet(vo.NewMoney(currency, amount))
+	// users stored before the wallet versions are at version 0
+	wallet := vo.NewVersionedWallet(vo.NewMoney(currency, amount), userBSON.Wallet.Version)
+
+	// legacy plaintext passwords are not loaded, they never verify and those users must reset their password
+	password, _ := vo.NewHashedPassword(userBSON.Password)
 
 	u, err := entity.NewUser(
 		uuid,
 		vo.NewFullName(userBSON.FullName),
 		email,
-		vo.NewPassword(userBSON.Password),
+		password,
 		doc,
 		wallet,
 		vo.TypeUser(userBSON.Type),
@@ -119,5 +135,14 @@ func (f findUserByIDRepository) FindByID(ctx context.Context, ID vo.Uuid) (entit
 		return entity.User{}, err
 	}
 
+	// users stored before the KYC tiers keep the default tier
+	if userBSON.KYCTier != "" {
+		tier, err := vo.NewKYCTier(userBSON.KYCTier)
+		if err != nil {
+			return entity.User{}, err
+		}
+		u = u.WithKYCTier(tier)
+	}
+
 	return u, nil
 }
\ No newline at end of file
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository/repositorytest"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+)
+
+// TestMongo_Conformance runs against the replica set of MONGODB_TEST_URI, transactions are not available on
+// a standalone server. Every test gets its own database, dropped once it is done
+func TestMongo_Conformance(t *testing.T) {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		t.Setenv("MONGODB_URI", uri)
+		t.Setenv("MONGODB_DATABASE", "conformance_"+vo.NewUuidRandom().Value()[:8])
+
+		handler := database.NewMongoHandler()
+		t.Cleanup(func() {
+			_ = handler.Db().Drop(context.Background())
+			_ = handler.Client().Disconnect(context.Background())
+		})
+
+		if err := handler.Bootstrap(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		return repositorytest.Repositories{
+			UserCreator:        repository.NewCreateUserRepository(handler),
+			UserFinder:         repository.NewFindUserByIDUserRepository(handler),
+			UserUpdater:        repository.NewUpdateUserRepository(handler),
+			TransferCreator:    repository.NewCreateTransferRepository(handler),
+			TransferFinder:     repository.NewFindTransferRepository(handler),
+			TransferUpdater:    repository.NewUpdateTransferRepository(handler),
+			TransferAggregator: repository.NewSumTransfersRepository(handler),
+			IdempotencyCreator: repository.NewCreateIdempotencyKeyRepository(handler),
+			IdempotencyFinder:  repository.NewFindIdempotencyKeyRepository(handler),
+			LedgerCreator:      repository.NewCreateJournalEntryRepository(handler),
+			OutboxCreator:      repository.NewCreateOutboxMessageRepository(handler),
+			OutboxFinder:       repository.NewFindOutboxMessagesRepository(handler),
+			OutboxUpdater:      repository.NewUpdateOutboxMessageRepository(handler),
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package repositorytest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type (
+	approveAuthorizer struct{}
+
+	// dailyCountLimits limits the number of transfers of a day only
+	dailyCountLimits struct {
+		count int
+	}
+
+	noFXRates struct{}
+)
+
+func (approveAuthorizer) Authorized(_ context.Context, _ entity.Transfer) (usecase.AuthorizationDecision, error) {
+	return usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved}, nil
+}
+
+func (l dailyCountLimits) Limit(_ context.Context, tier vo.KYCTier, currency vo.Currency) (entity.TransferLimit, error) {
+	limit := entity.NewUnlimitedTransferLimit(tier, currency)
+	limit.DailyCount = l.count
+	return limit, nil
+}
+
+func (noFXRates) Rate(_ context.Context, _ vo.Currency, _ vo.Currency) (vo.ExchangeRate, error) {
+	return vo.ExchangeRate{}, vo.ErrExchangeRateUnavailable
+}
+
+// RunCreateTransfer checks the transfers created at once from the same payer wallet against the transactions of the
+// backend, an attempt aborted by a concurrent update leaves neither a debit, a credit nor a completed transfer. The
+// limits of the payer hold against concurrent transfers
+func RunCreateTransfer(t *testing.T, factory Factory) {
+	const (
+		transfers = 20
+		value     = 10
+	)
+
+	tests := []struct {
+		name          string
+		dailyCount    int
+		wantCompleted int64
+	}{
+		{
+			name:          "Concurrent transfers",
+			dailyCount:    entity.NoLimit,
+			wantCompleted: transfers,
+		},
+		{
+			name:          "Concurrent transfers above the daily count limit",
+			dailyCount:    5,
+			wantCompleted: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repos     = factory(t)
+				payer     = newUser(transfers * value)
+				payees    = make([]entity.User, transfers)
+				mu        sync.Mutex
+				completed int64
+				wg        sync.WaitGroup
+			)
+
+			if _, err := repos.UserCreator.Create(context.Background(), payer); err != nil {
+				t.Fatal(err)
+			}
+			for i := range payees {
+				payees[i] = newUser(0)
+				if _, err := repos.UserCreator.Create(context.Background(), payees[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			uc := usecase.NewCreateTransferInteractor(
+				repos.TransferCreator,
+				repos.TransferUpdater,
+				repos.UserUpdater,
+				repos.UserFinder,
+				repos.LedgerCreator,
+				repos.IdempotencyCreator,
+				repos.IdempotencyFinder,
+				repos.OutboxCreator,
+				repos.TransferAggregator,
+				noFXRates{},
+				dailyCountLimits{count: tt.dailyCount},
+				approveAuthorizer{},
+				usecase.NewEventBus(nil),
+				presenter.NewCreateTransferPresenter(),
+			)
+
+			// every transfer debits the same payer wallet at once, each one either completes or is given up
+			for _, payee := range payees {
+				wg.Add(1)
+				go func(payee entity.User) {
+					defer wg.Done()
+
+					_, err := uc.Execute(context.Background(), usecase.CreateTransferInput{
+						ID:        vo.NewUuidRandom(),
+						PayerID:   payer.ID(),
+						PayeeID:   payee.ID(),
+						Value:     vo.NewMoneyNGN(vo.NewAmountTest(value)),
+						CreatedAt: now(),
+					})
+					if err == nil {
+						mu.Lock()
+						completed++
+						mu.Unlock()
+					}
+				}(payee)
+			}
+			wg.Wait()
+
+			if completed == 0 || completed > tt.wantCompleted {
+				t.Fatalf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, completed, tt.wantCompleted)
+			}
+
+			stored, err := repos.UserFinder.FindByID(context.Background(), payer.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := stored.Wallet().Money().Amount().Value(), int64(transfers*value)-completed*value; got != want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, want)
+			}
+
+			var credited int64
+			for _, payee := range payees {
+				stored, err := repos.UserFinder.FindByID(context.Background(), payee.ID())
+				if err != nil {
+					t.Fatal(err)
+				}
+				credited += stored.Wallet().Money().Amount().Value()
+			}
+			if got, want := credited, completed*value; got != want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, want)
+			}
+
+			sent, err := repos.TransferFinder.FindByUser(context.Background(), entity.TransferFilter{
+				UserID:    payer.ID(),
+				Direction: entity.TransferSent,
+				Status:    vo.COMPLETED,
+				Limit:     transfers,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := int64(len(sent)); got != completed {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, completed)
+			}
+		})
+	}
+}
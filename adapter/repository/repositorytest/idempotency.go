@@ -0,0 +1,65 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// RunIdempotency checks a key is found with the transfer it was stored with, is rejected with ErrIdempotencyKeyInUse
+// once stored for the payer and reported missing with ErrNotFoundIdempotencyKey. The same key is free for another
+// payer
+func RunIdempotency(t *testing.T, factory Factory) {
+	var (
+		repos    = factory(t)
+		transfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		key      = entity.NewIdempotencyKey(vo.NewUuidRandom().Value(), "fingerprint", transfer, now())
+	)
+
+	if _, err := repos.IdempotencyFinder.FindByKey(context.Background(), key.Payer(), key.Key()); !errors.Is(err, entity.ErrNotFoundIdempotencyKey) {
+		t.Errorf("[TestCase 'Not found key'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundIdempotencyKey)
+	}
+
+	if err := repos.IdempotencyCreator.Create(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	var duplicate = entity.NewIdempotencyKey(key.Key(), "other", transfer, now())
+	if err := repos.IdempotencyCreator.Create(context.Background(), duplicate); !errors.Is(err, entity.ErrIdempotencyKeyInUse) {
+		t.Errorf("[TestCase 'Duplicate key'] Err: '%v' | WantErr: '%v'", err, entity.ErrIdempotencyKeyInUse)
+	}
+
+	stored, err := repos.IdempotencyFinder.FindByKey(context.Background(), key.Payer(), key.Key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Fingerprint() != key.Fingerprint() {
+		t.Errorf("[TestCase 'Found key'] Got: '%v' | Want: '%v'", stored.Fingerprint(), key.Fingerprint())
+	}
+	if !stored.CreatedAt().Equal(key.CreatedAt()) {
+		t.Errorf("[TestCase 'Found key'] Got: '%v' | Want: '%v'", stored.CreatedAt(), key.CreatedAt())
+	}
+	assertTransfer(t, "Found key", stored.Transfer(), transfer)
+
+	var (
+		otherTransfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		otherKey      = entity.NewIdempotencyKey(key.Key(), "other", otherTransfer, now())
+	)
+	if _, err := repos.IdempotencyFinder.FindByKey(context.Background(), otherKey.Payer(), otherKey.Key()); !errors.Is(err, entity.ErrNotFoundIdempotencyKey) {
+		t.Errorf("[TestCase 'Key of another payer'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundIdempotencyKey)
+	}
+	if err := repos.IdempotencyCreator.Create(context.Background(), otherKey); err != nil {
+		t.Errorf("[TestCase 'Key of another payer'] Err: '%v' | WantErr: '%v'", err, nil)
+	}
+
+	stored, err = repos.IdempotencyFinder.FindByKey(context.Background(), otherKey.Payer(), otherKey.Key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Fingerprint() != otherKey.Fingerprint() {
+		t.Errorf("[TestCase 'Key of another payer'] Got: '%v' | Want: '%v'", stored.Fingerprint(), otherKey.Fingerprint())
+	}
+}
@@ -0,0 +1,55 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// RunOutbox checks a message is found by its ID with its payload, is marked processed once and is reported missing
+// with ErrNotFoundOutboxMessage
+func RunOutbox(t *testing.T, factory Factory) {
+	var (
+		repos   = factory(t)
+		message = entity.NewOutboxMessage(vo.NewUuidRandom(), vo.NewUuidRandom(), entity.TransferCompletedMessage, []byte(`{}`), now())
+	)
+
+	if err := repos.OutboxCreator.Create(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repos.OutboxFinder.FindByID(context.Background(), message.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stored.Payload()) != string(message.Payload()) || stored.Processed() {
+		t.Errorf("[TestCase 'Found message'] Got: '%s %v' | Want: '%s %v'", stored.Payload(), stored.Processed(), message.Payload(), false)
+	}
+
+	if err := repos.OutboxUpdater.MarkProcessed(context.Background(), message.ID(), now()); err != nil {
+		t.Errorf("[TestCase 'Processed message'] Err: '%v' | WantErr: '%v'", err, nil)
+	}
+
+	stored, err = repos.OutboxFinder.FindByID(context.Background(), message.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Processed() {
+		t.Errorf("[TestCase 'Processed message'] Got: '%v' | Want: '%v'", stored.Processed(), true)
+	}
+
+	if err := repos.OutboxUpdater.MarkProcessed(context.Background(), message.ID(), now()); !errors.Is(err, entity.ErrOutboxMessageProcessed) {
+		t.Errorf("[TestCase 'Message processed twice'] Err: '%v' | WantErr: '%v'", err, entity.ErrOutboxMessageProcessed)
+	}
+
+	if _, err := repos.OutboxFinder.FindByID(context.Background(), vo.NewUuidRandom()); !errors.Is(err, entity.ErrNotFoundOutboxMessage) {
+		t.Errorf("[TestCase 'Not found message'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundOutboxMessage)
+	}
+
+	if err := repos.OutboxUpdater.MarkProcessed(context.Background(), vo.NewUuidRandom(), now()); !errors.Is(err, entity.ErrNotFoundOutboxMessage) {
+		t.Errorf("[TestCase 'Not found message'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundOutboxMessage)
+	}
+}
@@ -0,0 +1,86 @@
+// Package repositorytest holds the contract every storage backend of the repository ports is held to. A backend
+// runs the suite from its own tests with a factory of its repositories:
+//
+//	func TestConformance(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+//			...
+//		})
+//	}
+package repositorytest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// documents seeds the CPFs of the users with the start time, the users created by earlier runs against the same
+// storage keep their own documents
+var documents = uint64(time.Now().UnixNano()/1e6) % 1e11
+
+type (
+	// Repositories gathers the repository ports of a backend held to the contract, the ports of a single factory
+	// call share the same storage
+	Repositories struct {
+		UserCreator        entity.UserRepositoryCreator
+		UserFinder         entity.UserRepositoryFinder
+		UserUpdater        entity.UserRepositoryUpdater
+		TransferCreator    entity.TransferRepositoryCreator
+		TransferFinder     entity.TransferRepositoryFinder
+		TransferUpdater    entity.TransferRepositoryUpdater
+		TransferAggregator entity.TransferRepositoryAggregator
+		IdempotencyCreator entity.IdempotencyRepositoryCreator
+		IdempotencyFinder  entity.IdempotencyRepositoryFinder
+		LedgerCreator      entity.LedgerRepositoryCreator
+		OutboxCreator      entity.OutboxRepositoryCreator
+		OutboxFinder       entity.OutboxRepositoryFinder
+		OutboxUpdater      entity.OutboxRepositoryUpdater
+	}
+
+	// Factory returns the repositories of an empty storage, it is called once by test
+	Factory func(t *testing.T) Repositories
+)
+
+// Run runs the whole contract against the repositories of the factory
+func Run(t *testing.T, factory Factory) {
+	t.Run("User", func(t *testing.T) { RunUser(t, factory) })
+	t.Run("Transfer", func(t *testing.T) { RunTransfer(t, factory) })
+	t.Run("Idempotency", func(t *testing.T) { RunIdempotency(t, factory) })
+	t.Run("Outbox", func(t *testing.T) { RunOutbox(t, factory) })
+	t.Run("Transaction", func(t *testing.T) { RunTransaction(t, factory) })
+	t.Run("CreateTransfer", func(t *testing.T) { RunCreateTransfer(t, factory) })
+}
+
+// newUser creates a user with its own email and document so the unique indexes of a backend are not hit
+func newUser(amount int64) entity.User {
+	var ID = vo.NewUuidRandom()
+
+	return entity.NewCommonUser(
+		ID,
+		vo.NewFullName("Test testing"),
+		vo.NewEmailTest(fmt.Sprintf("%s@testing.com", ID.Value())),
+		vo.NewHashedPasswordTest("$2a$10$passw"),
+		vo.NewDocumentTest(vo.CPF, newCPF()),
+		vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(amount))),
+		now(),
+	)
+}
+
+// newCPF returns a well formed CPF not returned before
+func newCPF() string {
+	n := atomic.AddUint64(&documents, 1)
+	return fmt.Sprintf("%03d.%03d.%03d-%02d", n/1e8%1000, n/1e5%1000, n/100%1000, n%100)
+}
+
+func newTransfer(payer vo.Uuid, payee vo.Uuid, amount int64) entity.Transfer {
+	return entity.NewTransfer(vo.NewUuidRandom(), payer, payee, vo.NewMoneyNGN(vo.NewAmountTest(amount)), now())
+}
+
+// now is truncated to the millisecond, the precision of the dates stored by MongoDB
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,145 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// RunTransaction checks the writes of a transaction are committed together or rolled back together, and that
+// concurrent transactions moving the same wallet do not lose an update
+func RunTransaction(t *testing.T, factory Factory) {
+	var errRollback = errors.New("rollback")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAmount int64
+		wantFound  bool
+	}{
+		{
+			name:       "Commit",
+			wantAmount: 40,
+			wantFound:  true,
+		},
+		{
+			name:       "Rollback",
+			err:        errRollback,
+			wantAmount: 100,
+			wantFound:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repos    = factory(t)
+				payer    = newUser(100)
+				transfer = newTransfer(payer.ID(), vo.NewUuidRandom(), 60)
+			)
+
+			if _, err := repos.UserCreator.Create(context.Background(), payer); err != nil {
+				t.Fatal(err)
+			}
+
+			err := repos.TransferCreator.WithTransaction(context.Background(), func(ctx context.Context) error {
+				var wallet = vo.NewVersionedWallet(vo.NewMoneyNGN(vo.NewAmountTest(40)), payer.Wallet().Version())
+				if err := repos.UserUpdater.UpdateWallet(ctx, payer.ID(), wallet); err != nil {
+					return err
+				}
+
+				if _, err := repos.TransferCreator.Create(ctx, transfer); err != nil {
+					return err
+				}
+
+				// the transaction sees its own writes
+				user, err := repos.UserFinder.FindByID(ctx, payer.ID())
+				if err != nil {
+					return err
+				}
+				if got := user.Wallet().Money().Amount().Value(); got != 40 {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, 40)
+				}
+
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.err)
+			}
+
+			user, err := repos.UserFinder.FindByID(context.Background(), payer.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := user.Wallet().Money().Amount().Value(); got != tt.wantAmount {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantAmount)
+			}
+
+			_, err = repos.TransferFinder.FindByID(context.Background(), transfer.ID())
+			if got := err == nil; got != tt.wantFound {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantFound)
+			}
+			if err != nil && !errors.Is(err, entity.ErrNotFoundTransfer) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, entity.ErrNotFoundTransfer)
+			}
+		})
+	}
+
+	t.Run("Concurrent wallet updates", func(t *testing.T) {
+		const workers = 20
+
+		var (
+			repos     = factory(t)
+			user      = newUser(0)
+			mu        sync.Mutex
+			committed int64
+			wg        sync.WaitGroup
+		)
+
+		if _, err := repos.UserCreator.Create(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		// every transaction reads the wallet and writes it back with a deposit. A backend may abort some of them,
+		// the deposits of the ones committed must all be kept
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repos.UserCreator.WithTransaction(context.Background(), func(ctx context.Context) error {
+					stored, err := repos.UserFinder.FindByID(ctx, user.ID())
+					if err != nil {
+						return err
+					}
+
+					if _, err := stored.Wallet().Add(vo.NewMoneyNGN(vo.NewAmountTest(1))); err != nil {
+						return err
+					}
+
+					return repos.UserUpdater.UpdateWallet(ctx, user.ID(), stored.Wallet())
+				})
+				if err == nil {
+					mu.Lock()
+					committed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if committed == 0 {
+			t.Fatalf("[TestCase 'Concurrent wallet updates'] Got: '%v' | Want: '%v'", committed, "at least one commit")
+		}
+
+		stored, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := stored.Wallet().Money().Amount().Value(); got != committed {
+			t.Errorf("[TestCase 'Concurrent wallet updates'] Got: '%v' | Want: '%v'", got, committed)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// RunTransfer checks the transfers are found as created, their status and refunds updated, unique by ID and reported
+// missing with ErrNotFoundTransfer
+func RunTransfer(t *testing.T, factory Factory) {
+	t.Run("Create and find", func(t *testing.T) {
+		var (
+			repos    = factory(t)
+			transfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		)
+
+		if _, err := repos.TransferCreator.Create(context.Background(), transfer); err != nil {
+			t.Fatal(err)
+		}
+
+		stored, err := repos.TransferFinder.FindByID(context.Background(), transfer.ID())
+		if err != nil {
+			t.Fatalf("[TestCase 'FindByID'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+		assertTransfer(t, "FindByID", stored, transfer)
+
+		found, err := repos.TransferFinder.FindByUser(context.Background(), entity.TransferFilter{UserID: transfer.Payer()})
+		if err != nil {
+			t.Fatalf("[TestCase 'FindByUser'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+		if len(found) != 1 {
+			t.Fatalf("[TestCase 'FindByUser'] Got: '%v' | Want: '%v'", len(found), 1)
+		}
+		assertTransfer(t, "FindByUser", found[0], transfer)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		var (
+			repos    = factory(t)
+			transfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		)
+
+		if _, err := repos.TransferFinder.FindByID(context.Background(), transfer.ID()); !errors.Is(err, entity.ErrNotFoundTransfer) {
+			t.Errorf("[TestCase 'FindByID'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundTransfer)
+		}
+
+		if err := repos.TransferUpdater.Update(context.Background(), transfer); !errors.Is(err, entity.ErrNotFoundTransfer) {
+			t.Errorf("[TestCase 'Update'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundTransfer)
+		}
+
+		found, err := repos.TransferFinder.FindByUser(context.Background(), entity.TransferFilter{UserID: transfer.Payer()})
+		if err != nil || len(found) != 0 {
+			t.Errorf("[TestCase 'FindByUser'] Got: '%v' | Want: '%v'", len(found), 0)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		var (
+			repos    = factory(t)
+			transfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		)
+
+		if _, err := repos.TransferCreator.Create(context.Background(), transfer); err != nil {
+			t.Fatal(err)
+		}
+
+		var duplicate = entity.NewTransfer(
+			transfer.ID(),
+			transfer.Payer(),
+			transfer.Payee(),
+			vo.NewMoneyNGN(vo.NewAmountTest(500)),
+			transfer.CreatedAt(),
+		)
+		if _, err := repos.TransferCreator.Create(context.Background(), duplicate); err == nil {
+			t.Errorf("[TestCase 'Duplicate ID'] Err: '%v' | WantErr: '%v'", err, "duplicate key")
+		}
+
+		stored, err := repos.TransferFinder.FindByID(context.Background(), transfer.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertTransfer(t, "Duplicate ID", stored, transfer)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var (
+			repos    = factory(t)
+			transfer = newTransfer(vo.NewUuidRandom(), vo.NewUuidRandom(), 100)
+		)
+
+		if _, err := repos.TransferCreator.Create(context.Background(), transfer); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := transfer.Authorize(now()); err != nil {
+			t.Fatal(err)
+		}
+		if err := transfer.Complete(now()); err != nil {
+			t.Fatal(err)
+		}
+		if err := transfer.Refund(vo.NewMoneyNGN(vo.NewAmountTest(30)), now()); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repos.TransferUpdater.Update(context.Background(), transfer); err != nil {
+			t.Fatalf("[TestCase 'Update'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+
+		stored, err := repos.TransferFinder.FindByID(context.Background(), transfer.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertTransfer(t, "Update", stored, transfer)
+	})
+}
+
+func assertTransfer(t *testing.T, name string, got entity.Transfer, want entity.Transfer) {
+	t.Helper()
+
+	if got.ID() != want.ID() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.ID(), want.ID())
+	}
+	if got.Payer() != want.Payer() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Payer(), want.Payer())
+	}
+	if got.Payee() != want.Payee() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Payee(), want.Payee())
+	}
+	if got.Value() != want.Value() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Value(), want.Value())
+	}
+	if got.Credit() != want.Credit() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Credit(), want.Credit())
+	}
+	if got.Refunded() != want.Refunded() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Refunded(), want.Refunded())
+	}
+	if got.Status() != want.Status() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Status(), want.Status())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.CreatedAt(), want.CreatedAt())
+	}
+
+	if len(got.History()) != len(want.History()) {
+		t.Fatalf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.History(), want.History())
+	}
+	for i := range got.History() {
+		g, w := got.History()[i], want.History()[i]
+		if g.From() != w.From() || g.To() != w.To() || g.Reason() != w.Reason() || !g.At().Equal(w.At()) {
+			t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, g, w)
+		}
+	}
+}
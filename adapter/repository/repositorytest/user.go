@@ -0,0 +1,279 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// RunUser checks the users are found as created, updated in place, unique by ID, email and document with
+// ErrUserAlreadyExists and reported missing with ErrNotFoundUser. A wallet is only updated at the version it was read at
+func RunUser(t *testing.T, factory Factory) {
+	t.Run("Create and find", func(t *testing.T) {
+		var (
+			repos = factory(t)
+			user  = newUser(100).WithKYCTier(vo.TIER2)
+		)
+
+		if _, err := repos.UserCreator.Create(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		byID, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+		if err != nil {
+			t.Fatalf("[TestCase 'FindByID'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+		assertUser(t, "FindByID", byID, user)
+
+		byEmail, err := repos.UserFinder.FindByEmail(context.Background(), user.Email())
+		if err != nil {
+			t.Fatalf("[TestCase 'FindByEmail'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+		assertUser(t, "FindByEmail", byEmail, user)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		var (
+			repos = factory(t)
+			user  = newUser(100)
+		)
+
+		tests := []struct {
+			name string
+			call func() error
+		}{
+			{
+				name: "FindByID",
+				call: func() error {
+					_, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+					return err
+				},
+			},
+			{
+				name: "FindByEmail",
+				call: func() error {
+					_, err := repos.UserFinder.FindByEmail(context.Background(), user.Email())
+					return err
+				},
+			},
+			{
+				name: "UpdateWallet",
+				call: func() error {
+					return repos.UserUpdater.UpdateWallet(context.Background(), user.ID(), user.Wallet())
+				},
+			},
+			{
+				name: "UpdatePassword",
+				call: func() error {
+					return repos.UserUpdater.UpdatePassword(context.Background(), user.ID(), user.Password())
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := tt.call(); !errors.Is(err, entity.ErrNotFoundUser) {
+					t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, entity.ErrNotFoundUser)
+				}
+			})
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		var (
+			repos = factory(t)
+			user  = newUser(100)
+			other = newUser(100)
+		)
+
+		if _, err := repos.UserCreator.Create(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name      string
+			duplicate entity.User
+		}{
+			{
+				name: "Duplicate ID",
+				duplicate: entity.NewCommonUser(
+					user.ID(),
+					user.FullName(),
+					user.Email(),
+					user.Password(),
+					user.Document(),
+					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(500))),
+					user.CreatedAt(),
+				),
+			},
+			{
+				name: "Duplicate email",
+				duplicate: entity.NewCommonUser(
+					vo.NewUuidRandom(),
+					user.FullName(),
+					user.Email(),
+					user.Password(),
+					other.Document(),
+					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(500))),
+					user.CreatedAt(),
+				),
+			},
+			{
+				name: "Duplicate document",
+				duplicate: entity.NewCommonUser(
+					vo.NewUuidRandom(),
+					user.FullName(),
+					other.Email(),
+					user.Password(),
+					user.Document(),
+					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(500))),
+					user.CreatedAt(),
+				),
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := repos.UserCreator.Create(context.Background(), tt.duplicate); !errors.Is(err, entity.ErrUserAlreadyExists) {
+					t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, entity.ErrUserAlreadyExists)
+				}
+
+				stored, err := repos.UserFinder.FindByEmail(context.Background(), tt.duplicate.Email())
+				if tt.duplicate.Email() != user.Email() {
+					if !errors.Is(err, entity.ErrNotFoundUser) {
+						t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, entity.ErrNotFoundUser)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertUser(t, tt.name, stored, user)
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var (
+			repos    = factory(t)
+			user     = newUser(100)
+			other    = newUser(100)
+			money    = vo.NewMoneyNGN(vo.NewAmountTest(40))
+			wallet   = vo.NewVersionedWallet(money, user.Wallet().Version())
+			password = vo.NewHashedPasswordTest("$2a$10$other")
+		)
+
+		for _, u := range []entity.User{user, other} {
+			if _, err := repos.UserCreator.Create(context.Background(), u); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := repos.UserUpdater.UpdateWallet(context.Background(), user.ID(), wallet); err != nil {
+			t.Fatalf("[TestCase 'UpdateWallet'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+		if err := repos.UserUpdater.UpdatePassword(context.Background(), user.ID(), password); err != nil {
+			t.Fatalf("[TestCase 'UpdatePassword'] Err: '%v' | WantErr: '%v'", err, nil)
+		}
+
+		stored, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := stored.Wallet().Money(); got != money {
+			t.Errorf("[TestCase 'UpdateWallet'] Got: '%v' | Want: '%v'", got, money)
+		}
+		if got := stored.Wallet().Version(); got != wallet.Version()+1 {
+			t.Errorf("[TestCase 'UpdateWallet'] Got: '%v' | Want: '%v'", got, wallet.Version()+1)
+		}
+		if got := stored.Password(); got != password {
+			t.Errorf("[TestCase 'UpdatePassword'] Got: '%v' | Want: '%v'", got, password)
+		}
+
+		// the other users are left untouched
+		stored, err = repos.UserFinder.FindByID(context.Background(), other.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertUser(t, "Other user", stored, other)
+	})
+
+	t.Run("Stale wallet", func(t *testing.T) { runStaleWallet(t, factory) })
+}
+
+// runStaleWallet checks a wallet update made from a stale read is rejected with ErrConcurrentModification and
+// leaves the wallet untouched
+func runStaleWallet(t *testing.T, factory Factory) {
+	var (
+		repos = factory(t)
+		user  = newUser(100)
+	)
+
+	if _, err := repos.UserCreator.Create(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := first.Wallet().Sub(vo.NewMoneyNGN(vo.NewAmountTest(30))); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.UserUpdater.UpdateWallet(context.Background(), user.ID(), first.Wallet()); err != nil {
+		t.Fatalf("[TestCase 'First update'] Err: '%v' | WantErr: '%v'", err, nil)
+	}
+
+	if _, err := second.Wallet().Sub(vo.NewMoneyNGN(vo.NewAmountTest(50))); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.UserUpdater.UpdateWallet(context.Background(), user.ID(), second.Wallet()); !errors.Is(err, entity.ErrConcurrentModification) {
+		t.Errorf("[TestCase 'Stale update'] Err: '%v' | WantErr: '%v'", err, entity.ErrConcurrentModification)
+	}
+
+	stored, err := repos.UserFinder.FindByID(context.Background(), user.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stored.Wallet().Money().Amount().Value(); got != 70 {
+		t.Errorf("[TestCase 'Stale update'] Got: '%v' | Want: '%v'", got, 70)
+	}
+}
+
+func assertUser(t *testing.T, name string, got entity.User, want entity.User) {
+	t.Helper()
+
+	if got.ID() != want.ID() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.ID(), want.ID())
+	}
+	if got.FullName() != want.FullName() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.FullName(), want.FullName())
+	}
+	if got.Email() != want.Email() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Email(), want.Email())
+	}
+	if got.Password() != want.Password() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Password(), want.Password())
+	}
+	if got.Document() != want.Document() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Document(), want.Document())
+	}
+	if got.Wallet().Money() != want.Wallet().Money() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.Wallet().Money(), want.Wallet().Money())
+	}
+	if got.TypeUser() != want.TypeUser() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.TypeUser(), want.TypeUser())
+	}
+	if got.KYCTier() != want.KYCTier() {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.KYCTier(), want.KYCTier())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", name, got.CreatedAt(), want.CreatedAt())
+	}
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type revokeRefreshTokenRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewRevokeRefreshTokenRepository creates new revokeRefreshTokenRepository with its dependencies
+func NewRevokeRefreshTokenRepository(handler *database.MongoHandler) entity.RefreshTokenRepositoryRevoker {
+	return revokeRefreshTokenRepository{
+		handler:    handler,
+		collection: "refresh_tokens",
+	}
+}
+
+// Revoke performs updateOne into the database, only a token that is not revoked yet is matched
+func (r revokeRefreshTokenRepository) Revoke(ctx context.Context, ID vo.Uuid, revokedAt time.Time) error {
+	var (
+		query  = bson.M{"id": ID.Value(), "revoked_at": nil}
+		update = bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+	)
+
+	result, err := r.handler.Db().Collection(r.collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrRevokeRefreshToken.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
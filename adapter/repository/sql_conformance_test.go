@@ -0,0 +1,44 @@
+//go:build sqlite
+
+package repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository/repositorytest"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+)
+
+// TestSQL_Conformance runs against a SQLite file migrated like the PostgreSQL database, go test -tags sqlite
+func TestSQL_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		handler, err := database.NewSQLHandler("sqlite", filepath.Join(t.TempDir(), "conformance.db")+"?_pragma=busy_timeout(5000)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = handler.DB().Close() })
+
+		if _, err := handler.Migrate(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		return repositorytest.Repositories{
+			UserCreator:        repository.NewCreateUserSQLRepository(handler),
+			UserFinder:         repository.NewFindUserByIDUserSQLRepository(handler),
+			UserUpdater:        repository.NewUpdateUserSQLRepository(handler),
+			TransferCreator:    repository.NewCreateTransferSQLRepository(handler),
+			TransferFinder:     repository.NewFindTransferSQLRepository(handler),
+			TransferUpdater:    repository.NewUpdateTransferSQLRepository(handler),
+			TransferAggregator: repository.NewSumTransfersSQLRepository(handler),
+			IdempotencyCreator: repository.NewCreateIdempotencyKeySQLRepository(handler),
+			IdempotencyFinder:  repository.NewFindIdempotencyKeySQLRepository(handler),
+			LedgerCreator:      repository.NewCreateJournalEntrySQLRepository(handler),
+			OutboxCreator:      repository.NewCreateOutboxMessageSQLRepository(handler),
+			OutboxFinder:       repository.NewFindOutboxMessagesSQLRepository(handler),
+			OutboxUpdater:      repository.NewUpdateOutboxMessageSQLRepository(handler),
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type (
+	// JSON data of the transfer column
+	idempotencyTransferJSON struct {
+		ID             string                   `json:"id"`
+		PayerID        string                   `json:"payer"`
+		PayeeID        string                   `json:"payee"`
+		Value          int64                    `json:"value"`
+		Currency       string                   `json:"currency"`
+		Credit         int64                    `json:"credited_value"`
+		CreditCurrency string                   `json:"credited_currency"`
+		Rate           string                   `json:"rate"`
+		Refunded       int64                    `json:"refunded"`
+		ReversalOf     string                   `json:"reversal_of,omitempty"`
+		Status         string                   `json:"status"`
+		History        []transferTransitionJSON `json:"history"`
+		CreatedAt      time.Time                `json:"created_at"`
+	}
+
+	createIdempotencyKeySQLRepository struct {
+		handler *database.SQLHandler
+	}
+)
+
+// NewCreateIdempotencyKeySQLRepository creates new createIdempotencyKeySQLRepository with its dependencies
+func NewCreateIdempotencyKeySQLRepository(handler *database.SQLHandler) entity.IdempotencyRepositoryCreator {
+	return createIdempotencyKeySQLRepository{handler: handler}
+}
+
+// Create performs insert into the idempotency_keys table, the primary key rejects concurrent uses of the same key
+// by the payer
+func (c createIdempotencyKeySQLRepository) Create(ctx context.Context, i entity.IdempotencyKey) error {
+	var (
+		t        = transferToBSON(i.Transfer())
+		transfer = idempotencyTransferJSON{
+			ID:             t.ID,
+			PayerID:        t.PayerID,
+			PayeeID:        t.PayeeID,
+			Value:          t.Value,
+			Currency:       t.Currency,
+			Credit:         t.Credit,
+			CreditCurrency: t.CreditCurrency,
+			Rate:           t.Rate,
+			Refunded:       t.Refunded,
+			ReversalOf:     t.ReversalOf,
+			Status:         t.Status,
+			History:        newHistoryJSON(i.Transfer().History()),
+			CreatedAt:      t.CreatedAt.UTC(),
+		}
+	)
+
+	b, err := json.Marshal(transfer)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrCreateIdempotencyKey.Error())
+	}
+
+	_, err = sqlConn(ctx, c.handler).ExecContext(
+		ctx,
+		`INSERT INTO idempotency_keys (payer_id, idempotency_key, fingerprint, transfer, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		i.Payer().Value(),
+		i.Key(),
+		i.Fingerprint(),
+		string(b),
+		i.CreatedAt().UTC(),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrIdempotencyKeyInUse
+		}
+
+		return errors.Wrap(err, entity.ErrCreateIdempotencyKey.Error())
+	}
+
+	return nil
+}
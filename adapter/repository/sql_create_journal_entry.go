@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type createJournalEntrySQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewCreateJournalEntrySQLRepository creates new createJournalEntrySQLRepository with its dependencies
+func NewCreateJournalEntrySQLRepository(handler *database.SQLHandler) entity.LedgerRepositoryCreator {
+	return createJournalEntrySQLRepository{handler: handler}
+}
+
+// Create performs insert into the journal_entries and postings tables, joining the transaction of ctx or
+// running its own one so an entry is never stored without its postings
+func (c createJournalEntrySQLRepository) Create(ctx context.Context, j entity.JournalEntry) (entity.JournalEntry, error) {
+	err := withSQLTransaction(ctx, c.handler, func(ctx context.Context) error {
+		var conn = sqlConn(ctx, c.handler)
+
+		if _, err := conn.ExecContext(
+			ctx,
+			`INSERT INTO journal_entries (id, transfer_id, description, created_at) VALUES ($1, $2, $3, $4)`,
+			j.ID().Value(),
+			j.TransferID().Value(),
+			j.Description(),
+			j.CreatedAt().UTC(),
+		); err != nil {
+			return err
+		}
+
+		for i, p := range j.Postings() {
+			if _, err := conn.ExecContext(
+				ctx,
+				`INSERT INTO postings (entry_id, position, account, direction, currency, amount) VALUES ($1, $2, $3, $4, $5, $6)`,
+				j.ID().Value(),
+				i,
+				p.Account().Value(),
+				p.Direction().String(),
+				p.Money().Currency().String(),
+				p.Money().Amount().Value(),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.JournalEntry{}, errors.Wrap(err, entity.ErrCreateJournalEntry.Error())
+	}
+
+	return j, nil
+}
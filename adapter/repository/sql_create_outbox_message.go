@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type createOutboxMessageSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewCreateOutboxMessageSQLRepository creates new createOutboxMessageSQLRepository with its dependencies
+func NewCreateOutboxMessageSQLRepository(handler *database.SQLHandler) entity.OutboxRepositoryCreator {
+	return createOutboxMessageSQLRepository{handler: handler}
+}
+
+// Create performs insert into the outbox table, called with the context of a transaction it joins it
+func (c createOutboxMessageSQLRepository) Create(ctx context.Context, m entity.OutboxMessage) error {
+	var publishedAt, processedAt sql.NullTime
+	if m.PublishedAt() != nil {
+		publishedAt = sql.NullTime{Time: m.PublishedAt().UTC(), Valid: true}
+	}
+	if m.ProcessedAt() != nil {
+		processedAt = sql.NullTime{Time: m.ProcessedAt().UTC(), Valid: true}
+	}
+
+	_, err := sqlConn(ctx, c.handler).ExecContext(
+		ctx,
+		`INSERT INTO outbox (id, aggregate_id, kind, payload, created_at, published_at, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		m.ID().Value(),
+		m.AggregateID().Value(),
+		m.Kind(),
+		string(m.Payload()),
+		m.CreatedAt().UTC(),
+		publishedAt,
+		processedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrCreateOutboxMessage.Error())
+	}
+
+	return nil
+}
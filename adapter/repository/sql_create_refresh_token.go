@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type createRefreshTokenSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewCreateRefreshTokenSQLRepository creates new createRefreshTokenSQLRepository with its dependencies
+func NewCreateRefreshTokenSQLRepository(handler *database.SQLHandler) entity.RefreshTokenRepositoryCreator {
+	return createRefreshTokenSQLRepository{handler: handler}
+}
+
+// Create performs insert into the refresh_tokens table
+func (c createRefreshTokenSQLRepository) Create(ctx context.Context, r entity.RefreshToken) error {
+	var revokedAt sql.NullTime
+	if r.RevokedAt() != nil {
+		revokedAt = sql.NullTime{Time: r.RevokedAt().UTC(), Valid: true}
+	}
+
+	_, err := sqlConn(ctx, c.handler).ExecContext(
+		ctx,
+		`INSERT INTO refresh_tokens (id, user_id, hash, expires_at, revoked_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		r.ID().Value(),
+		r.UserID().Value(),
+		r.Hash(),
+		r.ExpiresAt().UTC(),
+		revokedAt,
+		r.CreatedAt().UTC(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrCreateRefreshToken.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+const transferColumns = `id, payer, payee, value, currency, credited_value, credited_currency,
+	rate, refunded, reversal_of, status, history, created_at`
+
+type (
+	// JSON data of the history column
+	transferTransitionJSON struct {
+		From   string    `json:"from"`
+		To     string    `json:"to"`
+		Reason string    `json:"reason,omitempty"`
+		At     time.Time `json:"at"`
+	}
+
+	createTransferSQLRepository struct {
+		handler *database.SQLHandler
+	}
+)
+
+// NewCreateTransferSQLRepository creates new createTransferSQLRepository with its dependencies
+func NewCreateTransferSQLRepository(handler *database.SQLHandler) entity.TransferRepositoryCreator {
+	return createTransferSQLRepository{handler: handler}
+}
+
+// Create performs insert into the transfers table
+func (c createTransferSQLRepository) Create(ctx context.Context, t entity.Transfer) (entity.Transfer, error) {
+	history, err := historyToJSON(t.History())
+	if err != nil {
+		return entity.Transfer{}, errors.Wrap(err, entity.ErrCreateTransfer.Error())
+	}
+
+	var reversalOf sql.NullString
+	if t.ReversalOf().Value() != "" {
+		reversalOf = sql.NullString{String: t.ReversalOf().Value(), Valid: true}
+	}
+
+	_, err = sqlConn(ctx, c.handler).ExecContext(
+		ctx,
+		`INSERT INTO transfers (`+transferColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		t.ID().Value(),
+		t.Payer().Value(),
+		t.Payee().Value(),
+		t.Value().Amount().Value(),
+		t.Value().Currency().String(),
+		t.Credit().Amount().Value(),
+		t.Credit().Currency().String(),
+		t.Rate().String(),
+		t.Refunded().Amount().Value(),
+		reversalOf,
+		t.Status().String(),
+		history,
+		t.CreatedAt().UTC(),
+	)
+	if err != nil {
+		return entity.Transfer{}, errors.Wrap(err, entity.ErrCreateTransfer.Error())
+	}
+
+	return t, nil
+}
+
+// WithTransaction runs fn inside a database transaction
+func (c createTransferSQLRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return withSQLTransaction(ctx, c.handler, fn)
+}
+
+func historyToJSON(history []entity.TransferTransition) (string, error) {
+	b, err := json.Marshal(newHistoryJSON(history))
+	return string(b), err
+}
+
+func newHistoryJSON(history []entity.TransferTransition) []transferTransitionJSON {
+	var result = make([]transferTransitionJSON, 0, len(history))
+	for _, h := range history {
+		result = append(result, transferTransitionJSON{
+			From:   h.From().String(),
+			To:     h.To().String(),
+			Reason: h.Reason(),
+			At:     h.At().UTC(),
+		})
+	}
+
+	return result
+}
+
+func historyFromJSON(history []transferTransitionJSON) []transferTransitionBSON {
+	var result []transferTransitionBSON
+	for _, h := range history {
+		result = append(result, transferTransitionBSON{From: h.From, To: h.To, Reason: h.Reason, At: h.At})
+	}
+
+	return result
+}
+
+// scanTransfer reads a row of transferColumns
+func scanTransfer(row rowScanner) (entity.Transfer, error) {
+	var (
+		t          transferBSON
+		reversalOf sql.NullString
+		history    string
+		createdAt  time.Time
+	)
+
+	if err := row.Scan(
+		&t.ID,
+		&t.PayerID,
+		&t.PayeeID,
+		&t.Value,
+		&t.Currency,
+		&t.Credit,
+		&t.CreditCurrency,
+		&t.Rate,
+		&t.Refunded,
+		&reversalOf,
+		&t.Status,
+		&history,
+		&createdAt,
+	); err != nil {
+		return entity.Transfer{}, err
+	}
+	t.ReversalOf = reversalOf.String
+
+	var transitions []transferTransitionJSON
+	if err := json.Unmarshal([]byte(history), &transitions); err != nil {
+		return entity.Transfer{}, err
+	}
+	t.History = historyFromJSON(transitions)
+
+	return transferFromBSON(t, createdAt.UTC())
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type createUserSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewCreateUserSQLRepository creates new createUserSQLRepository with its dependencies
+func NewCreateUserSQLRepository(handler *database.SQLHandler) entity.UserRepositoryCreator {
+	return createUserSQLRepository{handler: handler}
+}
+
+// Create performs insert into the users table, the unique indexes reject a taken ID, email or document
+func (c createUserSQLRepository) Create(ctx context.Context, u entity.User) (entity.User, error) {
+	_, err := sqlConn(ctx, c.handler).ExecContext(
+		ctx,
+		`INSERT INTO users (
+			id, full_name, email, password, document_type, document_value,
+			wallet_currency, wallet_amount, wallet_version, type, kyc_tier, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		u.ID().Value(),
+		u.FullName().Value(),
+		u.Email().Value(),
+		u.Password().Value(),
+		u.Document().Type().String(),
+		u.Document().Value(),
+		u.Wallet().Money().Currency().String(),
+		u.Wallet().Money().Amount().Value(),
+		u.Wallet().Version(),
+		u.TypeUser().String(),
+		u.KYCTier().String(),
+		u.CreatedAt().UTC(),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.User{}, errors.Wrap(entity.ErrUserAlreadyExists, entity.ErrCreateUser.Error())
+		}
+		return entity.User{}, errors.Wrap(err, entity.ErrCreateUser.Error())
+	}
+
+	return u, nil
+}
+
+// WithTransaction runs fn inside a database transaction
+func (c createUserSQLRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return withSQLTransaction(ctx, c.handler, fn)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type findIdempotencyKeySQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindIdempotencyKeySQLRepository creates new findIdempotencyKeySQLRepository with its dependencies
+func NewFindIdempotencyKeySQLRepository(handler *database.SQLHandler) entity.IdempotencyRepositoryFinder {
+	return findIdempotencyKeySQLRepository{handler: handler}
+}
+
+// FindByKey performs select from the idempotency_keys table
+func (f findIdempotencyKeySQLRepository) FindByKey(ctx context.Context, payer vo.Uuid, key string) (entity.IdempotencyKey, error) {
+	var (
+		fingerprint string
+		transfer    string
+		createdAt   time.Time
+	)
+
+	err := sqlConn(ctx, f.handler).QueryRowContext(
+		ctx,
+		`SELECT fingerprint, transfer, created_at FROM idempotency_keys WHERE payer_id = $1 AND idempotency_key = $2`,
+		payer.Value(),
+		key,
+	).Scan(&fingerprint, &transfer, &createdAt)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return entity.IdempotencyKey{}, entity.ErrNotFoundIdempotencyKey
+		default:
+			return entity.IdempotencyKey{}, errors.Wrap(err, entity.ErrFindIdempotencyKey.Error())
+		}
+	}
+
+	var t idempotencyTransferJSON
+	if err := json.Unmarshal([]byte(transfer), &t); err != nil {
+		return entity.IdempotencyKey{}, errors.Wrap(err, entity.ErrFindIdempotencyKey.Error())
+	}
+
+	result, err := transferFromBSON(transferBSON{
+		ID:             t.ID,
+		PayerID:        t.PayerID,
+		PayeeID:        t.PayeeID,
+		Value:          t.Value,
+		Currency:       t.Currency,
+		Credit:         t.Credit,
+		CreditCurrency: t.CreditCurrency,
+		Rate:           t.Rate,
+		Refunded:       t.Refunded,
+		ReversalOf:     t.ReversalOf,
+		Status:         t.Status,
+		History:        historyFromJSON(t.History),
+	}, t.CreatedAt.UTC())
+	if err != nil {
+		return entity.IdempotencyKey{}, err
+	}
+
+	return entity.NewIdempotencyKey(key, fingerprint, result, createdAt.UTC()), nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type findOutboxMessagesSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindOutboxMessagesSQLRepository creates new findOutboxMessagesSQLRepository with its dependencies
+func NewFindOutboxMessagesSQLRepository(handler *database.SQLHandler) entity.OutboxRepositoryFinder {
+	return findOutboxMessagesSQLRepository{handler: handler}
+}
+
+// FindPending performs select from the outbox table for the messages without a publication date
+func (f findOutboxMessagesSQLRepository) FindPending(ctx context.Context, limit int) ([]entity.OutboxMessage, error) {
+	rows, err := sqlConn(ctx, f.handler).QueryContext(
+		ctx,
+		`SELECT id, aggregate_id, kind, payload, created_at FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+	}
+	defer rows.Close()
+
+	var messages = make([]entity.OutboxMessage, 0)
+	for rows.Next() {
+		var (
+			m         outboxMessageBSON
+			payload   string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&m.ID, &m.AggregateID, &m.Kind, &payload, &createdAt); err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+		}
+
+		ID, err := vo.NewUuid(m.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		aggregateID, err := vo.NewUuid(m.AggregateID)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, entity.NewOutboxMessage(ID, aggregateID, m.Kind, []byte(payload), createdAt.UTC()))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+	}
+
+	return messages, nil
+}
+
+// FindByID performs select from the outbox table for the message of the ID
+func (f findOutboxMessagesSQLRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.OutboxMessage, error) {
+	var (
+		m                        outboxMessageBSON
+		payload                  string
+		createdAt                time.Time
+		publishedAt, processedAt sql.NullTime
+	)
+
+	err := sqlConn(ctx, f.handler).QueryRowContext(
+		ctx,
+		`SELECT aggregate_id, kind, payload, created_at, published_at, processed_at FROM outbox WHERE id = $1`,
+		ID.Value(),
+	).Scan(&m.AggregateID, &m.Kind, &payload, &createdAt, &publishedAt, &processedAt)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return entity.OutboxMessage{}, entity.ErrNotFoundOutboxMessage
+		default:
+			return entity.OutboxMessage{}, errors.Wrap(err, entity.ErrFindOutboxMessages.Error())
+		}
+	}
+
+	aggregateID, err := vo.NewUuid(m.AggregateID)
+	if err != nil {
+		return entity.OutboxMessage{}, err
+	}
+
+	return entity.NewPublishedOutboxMessage(
+		ID,
+		aggregateID,
+		m.Kind,
+		[]byte(payload),
+		createdAt.UTC(),
+		utcTime(publishedAt),
+		utcTime(processedAt),
+	), nil
+}
+
+// utcTime returns the date of a nullable column, nil when it is NULL
+func utcTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	at := t.Time.UTC()
+	return &at
+}
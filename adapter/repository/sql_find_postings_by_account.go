@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type findPostingsByAccountSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindPostingsByAccountSQLRepository creates new findPostingsByAccountSQLRepository with its dependencies
+func NewFindPostingsByAccountSQLRepository(handler *database.SQLHandler) entity.LedgerRepositoryFinder {
+	return findPostingsByAccountSQLRepository{handler: handler}
+}
+
+// FindPostingsByAccount performs select from the postings table and returns the postings of the account
+func (f findPostingsByAccountSQLRepository) FindPostingsByAccount(ctx context.Context, account vo.Uuid) ([]entity.Posting, error) {
+	rows, err := sqlConn(ctx, f.handler).QueryContext(
+		ctx,
+		`SELECT p.account, p.direction, p.currency, p.amount FROM postings p
+		JOIN journal_entries e ON e.id = p.entry_id
+		WHERE p.account = $1
+		ORDER BY e.created_at, e.id, p.position`,
+		account.Value(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+	}
+	defer rows.Close()
+
+	var postings []entity.Posting
+	for rows.Next() {
+		var p postingBSON
+		if err := rows.Scan(&p.Account, &p.Direction, &p.Currency, &p.Amount); err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+		}
+
+		posting, err := postingFromBSON(p)
+		if err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+		}
+
+		postings = append(postings, posting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindPostings.Error())
+	}
+
+	return postings, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type findRefreshTokenSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindRefreshTokenSQLRepository creates new findRefreshTokenSQLRepository with its dependencies
+func NewFindRefreshTokenSQLRepository(handler *database.SQLHandler) entity.RefreshTokenRepositoryFinder {
+	return findRefreshTokenSQLRepository{handler: handler}
+}
+
+// FindByHash performs select from the refresh_tokens table, an unknown token is reported as an invalid one
+func (f findRefreshTokenSQLRepository) FindByHash(ctx context.Context, hash string) (entity.RefreshToken, error) {
+	var (
+		t         refreshTokenBSON
+		revokedAt sql.NullTime
+	)
+
+	err := sqlConn(ctx, f.handler).QueryRowContext(
+		ctx,
+		`SELECT id, user_id, hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE hash = $1`,
+		hash,
+	).Scan(&t.ID, &t.UserID, &t.Hash, &t.ExpiresAt, &revokedAt, &t.CreatedAt)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return entity.RefreshToken{}, entity.ErrInvalidRefreshToken
+		default:
+			return entity.RefreshToken{}, errors.Wrap(err, entity.ErrFindRefreshToken.Error())
+		}
+	}
+
+	ID, err := vo.NewUuid(t.ID)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	userID, err := vo.NewUuid(t.UserID)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	var revoked *time.Time
+	if revokedAt.Valid {
+		at := revokedAt.Time.UTC()
+		revoked = &at
+	}
+
+	return entity.NewRefreshTokenFromHash(ID, userID, t.Hash, t.ExpiresAt.UTC(), revoked, t.CreatedAt.UTC()), nil
+}
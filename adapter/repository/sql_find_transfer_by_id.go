@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type findTransferSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindTransferSQLRepository creates new findTransferSQLRepository with its dependencies
+func NewFindTransferSQLRepository(handler *database.SQLHandler) entity.TransferRepositoryFinder {
+	return findTransferSQLRepository{handler: handler}
+}
+
+// FindByID performs select from the transfers table
+func (f findTransferSQLRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.Transfer, error) {
+	row := sqlConn(ctx, f.handler).QueryRowContext(
+		ctx,
+		`SELECT `+transferColumns+` FROM transfers WHERE id = $1`,
+		ID.Value(),
+	)
+
+	transfer, err := scanTransfer(row)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return entity.Transfer{}, entity.ErrNotFoundTransfer
+		default:
+			return entity.Transfer{}, errors.Wrap(err, entity.ErrFindTransferByID.Error())
+		}
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/pkg/errors"
+)
+
+// FindByUser performs select from the transfers table, ordered from the newest transfer and paginated by the cursor
+func (f findTransferSQLRepository) FindByUser(ctx context.Context, filter entity.TransferFilter) ([]entity.Transfer, error) {
+	where, args := transferFilterToSQL(filter)
+
+	query := `SELECT ` + transferColumns + ` FROM transfers WHERE ` + where + ` ORDER BY created_at DESC, id DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := sqlConn(ctx, f.handler).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+	}
+	defer rows.Close()
+
+	var transfers = make([]entity.Transfer, 0)
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, entity.ErrFindTransfersByUser.Error())
+	}
+
+	return transfers, nil
+}
+
+func transferFilterToSQL(filter entity.TransferFilter) (string, []interface{}) {
+	var (
+		and  []string
+		args []interface{}
+	)
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	switch filter.Direction {
+	case entity.TransferSent:
+		and = append(and, "payer = "+arg(filter.UserID.Value()))
+	case entity.TransferReceived:
+		and = append(and, "payee = "+arg(filter.UserID.Value()))
+	default:
+		and = append(and, "(payer = "+arg(filter.UserID.Value())+" OR payee = "+arg(filter.UserID.Value())+")")
+	}
+
+	if !filter.From.IsZero() {
+		and = append(and, "created_at >= "+arg(filter.From.UTC()))
+	}
+	if !filter.To.IsZero() {
+		and = append(and, "created_at < "+arg(filter.To.UTC()))
+	}
+
+	if filter.MinValue.Value() > 0 {
+		and = append(and, "value >= "+arg(filter.MinValue.Value()))
+	}
+	if filter.MaxValue.Value() > 0 {
+		and = append(and, "value <= "+arg(filter.MaxValue.Value()))
+	}
+
+	if filter.Status != "" {
+		and = append(and, "status = "+arg(filter.Status.String()))
+	}
+
+	if filter.After != nil {
+		var at = filter.After.CreatedAt.UTC()
+		and = append(and, "(created_at < "+arg(at)+" OR (created_at = "+arg(at)+" AND id < "+arg(filter.After.ID.Value())+"))")
+	}
+
+	return strings.Join(and, " AND "), args
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+const userColumns = `id, full_name, email, password, document_type, document_value,
+	wallet_currency, wallet_amount, wallet_version, type, kyc_tier, created_at`
+
+type findUserByIDSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewFindUserByIDUserSQLRepository creates new findUserByIDSQLRepository with its dependencies
+func NewFindUserByIDUserSQLRepository(handler *database.SQLHandler) entity.UserRepositoryFinder {
+	return findUserByIDSQLRepository{handler: handler}
+}
+
+// FindByID performs select from the users table
+func (f findUserByIDSQLRepository) FindByID(ctx context.Context, ID vo.Uuid) (entity.User, error) {
+	return f.findOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, ID.Value(), entity.ErrFindUserByID)
+}
+
+// FindByEmail performs select from the users table
+func (f findUserByIDSQLRepository) FindByEmail(ctx context.Context, email vo.Email) (entity.User, error) {
+	return f.findOne(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1`, email.Value(), entity.ErrFindUserByEmail)
+}
+
+func (f findUserByIDSQLRepository) findOne(ctx context.Context, query string, arg string, errFind error) (entity.User, error) {
+	var u findUserByIDBSON
+
+	err := sqlConn(ctx, f.handler).QueryRowContext(ctx, query, arg).Scan(
+		&u.ID,
+		&u.FullName,
+		&u.Email,
+		&u.Password,
+		&u.Document.Type,
+		&u.Document.Value,
+		&u.Wallet.Currency,
+		&u.Wallet.Amount,
+		&u.Wallet.Version,
+		&u.Type,
+		&u.KYCTier,
+		&u.CreatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return entity.User{}, entity.ErrNotFoundUser
+		default:
+			return entity.User{}, errors.Wrap(err, errFind.Error())
+		}
+	}
+
+	u.CreatedAt = u.CreatedAt.UTC()
+
+	return u.toEntity()
+}
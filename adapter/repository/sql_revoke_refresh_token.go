@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type revokeRefreshTokenSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewRevokeRefreshTokenSQLRepository creates new revokeRefreshTokenSQLRepository with its dependencies
+func NewRevokeRefreshTokenSQLRepository(handler *database.SQLHandler) entity.RefreshTokenRepositoryRevoker {
+	return revokeRefreshTokenSQLRepository{handler: handler}
+}
+
+// Revoke performs update of the refresh_tokens table, only a token that is not revoked yet is matched
+func (r revokeRefreshTokenSQLRepository) Revoke(ctx context.Context, ID vo.Uuid, revokedAt time.Time) error {
+	result, err := sqlConn(ctx, r.handler).ExecContext(
+		ctx,
+		`UPDATE refresh_tokens SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`,
+		revokedAt.UTC(),
+		ID.Value(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrRevokeRefreshToken.Error())
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, entity.ErrRevokeRefreshToken.Error())
+	} else if n == 0 {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
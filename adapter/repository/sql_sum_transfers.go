@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type sumTransfersSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewSumTransfersSQLRepository creates new sumTransfersSQLRepository with its dependencies
+func NewSumTransfersSQLRepository(handler *database.SQLHandler) entity.TransferRepositoryAggregator {
+	return sumTransfersSQLRepository{handler: handler}
+}
+
+// SumSent performs select from the transfers table, counting the transfers completed or reversed by the payer since
+// the date and summing their value net of refunds. The reversals, moving value back to the payer, are not counted
+func (s sumTransfersSQLRepository) SumSent(
+	ctx context.Context,
+	payer vo.Uuid,
+	currency vo.Currency,
+	since time.Time,
+) (entity.TransferTotal, error) {
+	var total entity.TransferTotal
+
+	err := sqlConn(ctx, s.handler).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*), COALESCE(SUM(value - refunded), 0) FROM transfers
+		WHERE payer = $1 AND currency = $2 AND created_at >= $3 AND status IN ($4, $5) AND reversal_of IS NULL`,
+		payer.Value(),
+		currency.String(),
+		since.UTC(),
+		vo.COMPLETED.String(),
+		vo.REVERSED.String(),
+	).Scan(&total.Count, &total.Amount)
+	if err != nil {
+		return entity.TransferTotal{}, errors.Wrap(err, entity.ErrSumTransfers.Error())
+	}
+
+	return total, nil
+}
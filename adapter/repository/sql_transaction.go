@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+)
+
+type (
+	// sqlExecutor is implemented by *sql.DB and *sql.Tx
+	sqlExecutor interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	}
+
+	// rowScanner is implemented by *sql.Row and *sql.Rows
+	rowScanner interface {
+		Scan(dest ...interface{}) error
+	}
+
+	sqlTxKey struct{}
+)
+
+// withSQLTransaction runs fn inside a transaction carried by the context given to fn, every SQL repository called
+// with it joins the transaction. A transaction started inside another one joins it
+func withSQLTransaction(ctx context.Context, handler *database.SQLHandler, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(sqlTxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := handler.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, sqlTxKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// sqlConn returns the transaction carried by ctx, or the database outside of a transaction
+func sqlConn(ctx context.Context, handler *database.SQLHandler) sqlExecutor {
+	if tx, ok := ctx.Value(sqlTxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return handler.DB()
+}
+
+// isUniqueViolation reports whether err is the violation of a unique constraint by PostgreSQL or SQLite
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate key value") ||
+		strings.Contains(msg, "23505") ||
+		strings.Contains(msg, "UNIQUE constraint failed")
+}
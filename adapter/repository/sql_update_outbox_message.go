@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type updateOutboxMessageSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewUpdateOutboxMessageSQLRepository creates new updateOutboxMessageSQLRepository with its dependencies
+func NewUpdateOutboxMessageSQLRepository(handler *database.SQLHandler) entity.OutboxRepositoryUpdater {
+	return updateOutboxMessageSQLRepository{handler: handler}
+}
+
+// MarkPublished performs update of the outbox table setting the publication date
+func (u updateOutboxMessageSQLRepository) MarkPublished(ctx context.Context, ID vo.Uuid, publishedAt time.Time) error {
+	result, err := sqlConn(ctx, u.handler).ExecContext(
+		ctx,
+		`UPDATE outbox SET published_at = $1 WHERE id = $2`,
+		publishedAt.UTC(),
+		ID.Value(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	} else if n == 0 {
+		return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	return nil
+}
+
+// MarkProcessed performs update of the outbox table setting the processing date of a message not processed yet
+func (u updateOutboxMessageSQLRepository) MarkProcessed(ctx context.Context, ID vo.Uuid, processedAt time.Time) error {
+	result, err := sqlConn(ctx, u.handler).ExecContext(
+		ctx,
+		`UPDATE outbox SET processed_at = $1 WHERE id = $2 AND processed_at IS NULL`,
+		processedAt.UTC(),
+		ID.Value(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+	if n > 0 {
+		return nil
+	}
+
+	// nothing updated, the message is either missing or already processed
+	var exists int
+	err = sqlConn(ctx, u.handler).QueryRowContext(ctx, `SELECT 1 FROM outbox WHERE id = $1`, ID.Value()).Scan(&exists)
+	switch err {
+	case nil:
+		return entity.ErrOutboxMessageProcessed
+	case sql.ErrNoRows:
+		return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+	default:
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type updateTransferSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewUpdateTransferSQLRepository creates new updateTransferSQLRepository with its dependencies
+func NewUpdateTransferSQLRepository(handler *database.SQLHandler) entity.TransferRepositoryUpdater {
+	return updateTransferSQLRepository{handler: handler}
+}
+
+// Update performs update of the transfers table persisting the status, its transition history and the refunded value
+func (u updateTransferSQLRepository) Update(ctx context.Context, t entity.Transfer) error {
+	history, err := historyToJSON(t.History())
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateTransfer.Error())
+	}
+
+	result, err := sqlConn(ctx, u.handler).ExecContext(
+		ctx,
+		`UPDATE transfers SET status = $1, history = $2, refunded = $3 WHERE id = $4`,
+		t.Status().String(),
+		history,
+		t.Refunded().Amount().Value(),
+		t.ID().Value(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateTransfer.Error())
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, entity.ErrUpdateTransfer.Error())
+	} else if n == 0 {
+		return errors.Wrap(entity.ErrNotFoundTransfer, entity.ErrUpdateTransfer.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+)
+
+type updateUserSQLRepository struct {
+	handler *database.SQLHandler
+}
+
+// NewUpdateUserSQLRepository creates new updateUserSQLRepository with its dependencies
+func NewUpdateUserSQLRepository(handler *database.SQLHandler) entity.UserRepositoryUpdater {
+	return updateUserSQLRepository{handler: handler}
+}
+
+// UpdateWallet performs update of the users table conditioned on the version of the wallet
+func (u updateUserSQLRepository) UpdateWallet(ctx context.Context, ID vo.Uuid, wallet *vo.Wallet) error {
+	result, err := sqlConn(ctx, u.handler).ExecContext(
+		ctx,
+		`UPDATE users SET wallet_amount = $1, wallet_version = wallet_version + 1 WHERE id = $2 AND wallet_version = $3`,
+		wallet.Money().Amount().Value(),
+		ID.Value(),
+		wallet.Version(),
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+	} else if n > 0 {
+		return nil
+	}
+
+	// tells a user that does not exist from a wallet updated since it was read
+	var exists int
+	err = sqlConn(ctx, u.handler).QueryRowContext(ctx, `SELECT 1 FROM users WHERE id = $1`, ID.Value()).Scan(&exists)
+	switch err {
+	case nil:
+		return entity.ErrConcurrentModification
+	case sql.ErrNoRows:
+		return errors.Wrap(entity.ErrNotFoundUser, entity.ErrUpdateUserWallet.Error())
+	default:
+		return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+	}
+}
+
+// UpdatePassword performs update of the users table
+func (u updateUserSQLRepository) UpdatePassword(ctx context.Context, ID vo.Uuid, password vo.HashedPassword) error {
+	return u.update(
+		ctx,
+		`UPDATE users SET password = $1 WHERE id = $2`,
+		password.Value(),
+		ID,
+		entity.ErrUpdateUserPassword,
+	)
+}
+
+func (u updateUserSQLRepository) update(ctx context.Context, query string, value interface{}, ID vo.Uuid, errUpdate error) error {
+	result, err := sqlConn(ctx, u.handler).ExecContext(ctx, query, value, ID.Value())
+	if err != nil {
+		return errors.Wrap(err, errUpdate.Error())
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, errUpdate.Error())
+	} else if n == 0 {
+		return errors.Wrap(entity.ErrNotFoundUser, errUpdate.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+)
+
+type (
+	// Bson data
+	transferTotalBSON struct {
+		Count  int   `bson:"count"`
+		Amount int64 `bson:"amount"`
+	}
+
+	sumTransfersRepository struct {
+		handler    *database.MongoHandler
+		collection string
+	}
+)
+
+// NewSumTransfersRepository creates new sumTransfersRepository with its dependencies
+func NewSumTransfersRepository(handler *database.MongoHandler) entity.TransferRepositoryAggregator {
+	return sumTransfersRepository{
+		handler:    handler,
+		collection: "transfer",
+	}
+}
+
+// SumSent performs aggregate into the database, counting the transfers completed or reversed by the payer since
+// the date and summing their value net of refunds. The reversals, moving value back to the payer, are not counted
+func (s sumTransfersRepository) SumSent(
+	ctx context.Context,
+	payer vo.Uuid,
+	currency vo.Currency,
+	since time.Time,
+) (entity.TransferTotal, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"payer":       payer.Value(),
+			"currency":    currency.String(),
+			"created_at":  bson.M{"$gte": since},
+			"status":      bson.M{"$in": bson.A{vo.COMPLETED.String(), vo.REVERSED.String()}},
+			"reversal_of": bson.M{"$exists": false},
+		}},
+		bson.M{"$group": bson.M{
+			"_id":    nil,
+			"count":  bson.M{"$sum": 1},
+			"amount": bson.M{"$sum": bson.M{"$subtract": bson.A{"$value", bson.M{"$ifNull": bson.A{"$refunded", 0}}}}},
+		}},
+	}
+
+	cur, err := s.handler.Db().Collection(s.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return entity.TransferTotal{}, errors.Wrap(err, entity.ErrSumTransfers.Error())
+	}
+	defer cur.Close(ctx)
+
+	var result transferTotalBSON
+	if cur.Next(ctx) {
+		if err := cur.Decode(&result); err != nil {
+			return entity.TransferTotal{}, errors.Wrap(err, entity.ErrSumTransfers.Error())
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return entity.TransferTotal{}, errors.Wrap(err, entity.ErrSumTransfers.Error())
+	}
+
+	return entity.TransferTotal{Count: result.Count, Amount: result.Amount}, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// withTransaction runs fn inside a session transaction, every repository called with the session context joins it
+func withTransaction(ctx context.Context, handler *database.MongoHandler, fn func(context.Context) error) error {
+	callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		err := fn(sessCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	session, err := handler.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, callback)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
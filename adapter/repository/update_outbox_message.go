@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type updateOutboxMessageRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewUpdateOutboxMessageRepository creates new updateOutboxMessageRepository with its dependencies
+func NewUpdateOutboxMessageRepository(handler *database.MongoHandler) entity.OutboxRepositoryUpdater {
+	return updateOutboxMessageRepository{
+		handler:    handler,
+		collection: "outbox",
+	}
+}
+
+// MarkPublished performs updateOne into the database setting the publication date
+func (u updateOutboxMessageRepository) MarkPublished(ctx context.Context, ID vo.Uuid, publishedAt time.Time) error {
+	var (
+		query  = bson.M{"id": ID.Value()}
+		update = bson.M{"$set": bson.M{"published_at": publishedAt}}
+	)
+
+	res, err := u.handler.Db().Collection(u.collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	if res.MatchedCount == 0 {
+		return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	return nil
+}
+
+// MarkProcessed performs updateOne into the database setting the processing date of a message not processed yet
+func (u updateOutboxMessageRepository) MarkProcessed(ctx context.Context, ID vo.Uuid, processedAt time.Time) error {
+	var (
+		query  = bson.M{"id": ID.Value(), "processed_at": nil}
+		update = bson.M{"$set": bson.M{"processed_at": processedAt}}
+	)
+
+	res, err := u.handler.Db().Collection(u.collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	if res.MatchedCount == 0 {
+		return u.notProcessable(ctx, ID)
+	}
+
+	return nil
+}
+
+// notProcessable tells a message already processed from a missing one
+func (u updateOutboxMessageRepository) notProcessable(ctx context.Context, ID vo.Uuid) error {
+	n, err := u.handler.Db().Collection(u.collection).CountDocuments(ctx, bson.M{"id": ID.Value()})
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	if n == 0 {
+		return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+	}
+
+	return entity.ErrOutboxMessageProcessed
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type updateTransferRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewUpdateTransferRepository creates new updateTransferRepository with its dependencies
+func NewUpdateTransferRepository(handler *database.MongoHandler) entity.TransferRepositoryUpdater {
+	return updateTransferRepository{
+		handler:    handler,
+		collection: "transfer",
+	}
+}
+
+// Update performs updateOne into the database persisting the status, its transition history and the refunded value
+func (u updateTransferRepository) Update(ctx context.Context, t entity.Transfer) error {
+	var (
+		query  = bson.M{"id": t.ID().Value()}
+		update = bson.M{"$set": bson.M{
+			"status":   t.Status().String(),
+			"history":  historyToBSON(t.History()),
+			"refunded": t.Refunded().Amount().Value(),
+		}}
+	)
+
+	res, err := u.handler.Db().Collection(u.collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateTransfer.Error())
+	}
+
+	if res.MatchedCount == 0 {
+		return errors.Wrap(entity.ErrNotFoundTransfer, entity.ErrUpdateTransfer.Error())
+	}
+
+	return nil
+}
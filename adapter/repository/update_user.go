@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type updateUserRepository struct {
+	handler    *database.MongoHandler
+	collection string
+}
+
+// NewUpdateUserRepository creates new updateUserRepository with its dependencies
+func NewUpdateUserRepository(handler *database.MongoHandler) entity.UserRepositoryUpdater {
+	return updateUserRepository{
+		handler:    handler,
+		collection: "users",
+	}
+}
+
+// UpdateWallet performs updateOne into the database conditioned on the version of the wallet, the wallets stored
+// before the versions have none and match version 0
+func (u updateUserRepository) UpdateWallet(ctx context.Context, ID vo.Uuid, wallet *vo.Wallet) error {
+	var (
+		version interface{} = wallet.Version()
+		update              = bson.M{
+			"$set": bson.M{"wallet.amount": wallet.Money().Amount().Value()},
+			"$inc": bson.M{"wallet.version": 1},
+		}
+	)
+	if wallet.Version() == 0 {
+		version = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	result, err := u.handler.Db().Collection(u.collection).UpdateOne(
+		ctx,
+		bson.M{"id": ID.Value(), "wallet.version": version},
+		update,
+	)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return u.missing(ctx, ID)
+	}
+
+	return nil
+}
+
+// missing tells a user that does not exist from a wallet updated since it was read
+func (u updateUserRepository) missing(ctx context.Context, ID vo.Uuid) error {
+	n, err := u.handler.Db().Collection(u.collection).CountDocuments(ctx, bson.M{"id": ID.Value()})
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+	}
+
+	if n == 0 {
+		return errors.Wrap(entity.ErrNotFoundUser, entity.ErrUpdateUserWallet.Error())
+	}
+
+	return entity.ErrConcurrentModification
+}
+
+// UpdatePassword performs updateOne into the database
+func (u updateUserRepository) UpdatePassword(ctx context.Context, ID vo.Uuid, password vo.HashedPassword) error {
+	var (
+		query  = bson.M{"id": ID.Value()}
+		update = bson.M{"$set": bson.M{"password": password.Value()}}
+	)
+
+	result, err := u.handler.Db().Collection(u.collection).UpdateOne(ctx, query, update)
+	if err != nil {
+		return errors.Wrap(err, entity.ErrUpdateUserPassword.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(entity.ErrNotFoundUser, entity.ErrUpdateUserPassword.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type (
+	// Engine authorizes the transfers in process, with the rules of a rules file
+	Engine struct {
+		rules     []rule
+		users     entity.UserRepositoryFinder
+		transfers entity.TransferRepositoryAggregator
+		now       func() time.Time
+		log       logger.Logger
+		logKey    string
+	}
+
+	// Evaluation explains a decision, with the result of every rule evaluated
+	Evaluation struct {
+		Decision usecase.AuthorizationDecision
+		Results  []RuleResult
+	}
+
+	// RuleResult is the result of a rule, Outcome is approved when the rule passed
+	RuleResult struct {
+		Rule    string
+		Type    string
+		Outcome usecase.AuthorizationOutcome
+		Detail  string
+	}
+)
+
+// NewEngine creates new Engine with the rules of the file, the users and the transfers are read by the rules needing them
+func NewEngine(
+	file RuleFile,
+	users entity.UserRepositoryFinder,
+	transfers entity.TransferRepositoryAggregator,
+	l logger.Logger,
+) (*Engine, error) {
+	var rules []rule
+	for i, entry := range file.Rules {
+		r, err := entry.toRule()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rule %d %s", i, entry.Name)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return &Engine{
+		rules:     rules,
+		users:     users,
+		transfers: transfers,
+		now:       time.Now,
+		log:       l,
+		logKey:    "rules_authorizer",
+	}, nil
+}
+
+// NewFileEngine creates new Engine with the rules read from a JSON file
+func NewFileEngine(
+	path string,
+	users entity.UserRepositoryFinder,
+	transfers entity.TransferRepositoryAggregator,
+	l logger.Logger,
+) (*Engine, error) {
+	file, err := ReadRuleFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEngine(file, users, transfers, l)
+}
+
+// Evaluate runs the rules in order until one does not pass, its action and reason code are the decision.
+// The transfer is approved when every rule passes
+func (e *Engine) Evaluate(ctx context.Context, t entity.Transfer) (Evaluation, error) {
+	var (
+		evaluation = Evaluation{Decision: usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved}}
+		f          = &facts{users: e.users, transfers: e.transfers, now: e.now()}
+	)
+
+	for _, r := range e.rules {
+		m := r.meta()
+
+		passed, detail, err := r.check(ctx, t, f)
+		if err != nil {
+			return evaluation, errors.Wrapf(err, "error evaluating rule %s", m.name)
+		}
+
+		result := RuleResult{Rule: m.name, Type: m.kind, Outcome: usecase.AuthorizationApproved, Detail: detail}
+		if !passed {
+			result.Outcome = m.action
+		}
+		evaluation.Results = append(evaluation.Results, result)
+
+		if !passed {
+			evaluation.Decision = usecase.AuthorizationDecision{Outcome: m.action, ReasonCode: m.reasonCode}
+			break
+		}
+	}
+
+	return evaluation, nil
+}
+
+// Authorized evaluates the rules, the results are logged to explain the decision
+func (e *Engine) Authorized(ctx context.Context, t entity.Transfer) (usecase.AuthorizationDecision, error) {
+	evaluation, err := e.Evaluate(ctx, t)
+	if err != nil {
+		e.log.WithFields(logger.Fields{
+			"key":         e.logKey,
+			"transfer_id": t.ID().Value(),
+			"error":       err.Error(),
+		}).Errorf("failed to evaluate rules")
+
+		return usecase.AuthorizationDecision{}, err
+	}
+
+	var results = make([]string, 0, len(evaluation.Results))
+	for _, r := range evaluation.Results {
+		results = append(results, r.Rule+": "+string(r.Outcome)+" ("+r.Detail+")")
+	}
+
+	e.log.WithFields(logger.Fields{
+		"key":         e.logKey,
+		"transfer_id": t.ID().Value(),
+		"decision":    string(evaluation.Decision.Outcome),
+		"reason_code": evaluation.Decision.ReasonCode,
+		"rules":       results,
+	}).Infof("rules evaluated")
+
+	return evaluation.Decision, nil
+}
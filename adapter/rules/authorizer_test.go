@@ -0,0 +1,350 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+const (
+	payerID = "0db298eb-c8e7-4829-84b7-c1036b4f0791"
+	payeeID = "5b8b1f2a-3c4d-4e5f-8a9b-0c1d2e3f4a5b"
+)
+
+type stubUserRepoFinder struct {
+	users map[string]entity.User
+	err   error
+}
+
+func (s stubUserRepoFinder) FindByID(_ context.Context, ID vo.Uuid) (entity.User, error) {
+	return s.users[ID.Value()], s.err
+}
+
+func (s stubUserRepoFinder) FindByEmail(_ context.Context, _ vo.Email) (entity.User, error) {
+	return entity.User{}, s.err
+}
+
+// sumSentCall is a call to SumSent
+type sumSentCall struct {
+	payer    vo.Uuid
+	currency vo.Currency
+	since    time.Time
+}
+
+// spyTransferRepoAggregator returns the total sent and records the calls received
+type spyTransferRepoAggregator struct {
+	total entity.TransferTotal
+	calls []sumSentCall
+	err   error
+}
+
+func (s *spyTransferRepoAggregator) SumSent(
+	_ context.Context,
+	payer vo.Uuid,
+	currency vo.Currency,
+	since time.Time,
+) (entity.TransferTotal, error) {
+	s.calls = append(s.calls, sumSentCall{payer: payer, currency: currency, since: since})
+	return s.total, s.err
+}
+
+func mustUuid(value string) vo.Uuid {
+	ID, err := vo.NewUuid(value)
+	if err != nil {
+		panic(err)
+	}
+	return ID
+}
+
+func newTransfer(payee string, value vo.Money) entity.Transfer {
+	return entity.NewTransfer(
+		vo.NewUuidRandom(),
+		mustUuid(payerID),
+		mustUuid(payee),
+		value,
+		time.Now(),
+	)
+}
+
+func newUser(ID string, typeUser func(vo.Uuid, vo.FullName, vo.Email, vo.HashedPassword, vo.Document, *vo.Wallet, time.Time) entity.User) entity.User {
+	return typeUser(
+		mustUuid(ID),
+		vo.NewFullName("Test testing"),
+		vo.NewEmailTest("test@testing.com"),
+		vo.NewHashedPasswordTest("$2a$10$passw"),
+		vo.NewDocumentTest(vo.CPF, "07091054954"),
+		vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
+		time.Now(),
+	)
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	var (
+		ngn = vo.NewMoneyNGN(vo.NewAmountTest(100))
+		usd = vo.NewMoneyUSD(vo.NewAmountTest(100))
+
+		users = stubUserRepoFinder{users: map[string]entity.User{
+			payerID: newUser(payerID, entity.NewMerchantUser),
+			payeeID: newUser(payeeID, entity.NewCommonUser),
+		}}
+
+		// 150 transfers of 100 NGN sent today
+		sent = entity.TransferTotal{Count: 150, Amount: 15000}
+
+		now = time.Date(2021, 3, 10, 15, 4, 5, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name      string
+		rules     []RuleFileEntry
+		transfer  entity.Transfer
+		sent      entity.TransferTotal
+		want      usecase.AuthorizationDecision
+		wantRules []usecase.AuthorizationOutcome
+		wantErr   bool
+	}{
+		{
+			name:     "No rule approves",
+			transfer: newTransfer(payeeID, ngn),
+			want:     usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+		},
+		{
+			name: "Every rule passes",
+			rules: []RuleFileEntry{
+				{Name: "max", Type: MaxAmountRule, MaxAmount: 100},
+				{Name: "currencies", Type: AllowedCurrenciesRule, Currencies: []string{"NGN"}},
+				{Name: "blocked", Type: BlockedPayeesRule, Payees: []string{payerID}},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantRules: []usecase.AuthorizationOutcome{"approved", "approved", "approved"},
+		},
+		{
+			name: "Max amount denies",
+			rules: []RuleFileEntry{
+				{Name: "max", Type: MaxAmountRule, MaxAmount: 99, ReasonCode: "R01"},
+				{Name: "currencies", Type: AllowedCurrenciesRule, Currencies: []string{"NGN"}},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R01"},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+		{
+			name: "Max amount of another currency is skipped",
+			rules: []RuleFileEntry{
+				{Name: "max", Type: MaxAmountRule, Currency: "USD", MaxAmount: 1},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantRules: []usecase.AuthorizationOutcome{"approved"},
+		},
+		{
+			name: "Rule with review action holds the transfer",
+			rules: []RuleFileEntry{
+				{Name: "max", Type: MaxAmountRule, MaxAmount: 99, Action: "review", ReasonCode: "R10"},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationReview, ReasonCode: "R10"},
+			wantRules: []usecase.AuthorizationOutcome{"review"},
+		},
+		{
+			name: "Blocked payee denies",
+			rules: []RuleFileEntry{
+				{Name: "blocked", Type: BlockedPayeesRule, Payees: []string{payeeID}, ReasonCode: "R03"},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R03"},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+		{
+			name: "Currency not allowed denies",
+			rules: []RuleFileEntry{
+				{Name: "currencies", Type: AllowedCurrenciesRule, Currencies: []string{"NGN"}, ReasonCode: "R04"},
+			},
+			transfer:  newTransfer(payeeID, usd),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R04"},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+		{
+			name: "Payer type not allowed denies",
+			rules: []RuleFileEntry{
+				{Name: "types", Type: AllowedUserTypesRule, PayerTypes: []string{"COMMON"}, ReasonCode: "R02"},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R02"},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+		{
+			name: "Payee type allowed passes",
+			rules: []RuleFileEntry{
+				{Name: "types", Type: AllowedUserTypesRule, PayeeTypes: []string{"common"}},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantRules: []usecase.AuthorizationOutcome{"approved"},
+		},
+		{
+			name: "Daily count above the limit denies",
+			rules: []RuleFileEntry{
+				{Name: "velocity", Type: DailyVelocityRule, MaxCount: 150, ReasonCode: "R20"},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			sent:      sent,
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R20"},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+		{
+			name: "Daily amount adds the transfer",
+			rules: []RuleFileEntry{
+				{Name: "velocity", Type: DailyVelocityRule, Currency: "NGN", MaxAmount: 15100},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			sent:      sent,
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved},
+			wantRules: []usecase.AuthorizationOutcome{"approved"},
+		},
+		{
+			name: "Daily amount above the limit denies",
+			rules: []RuleFileEntry{
+				{Name: "velocity", Type: DailyVelocityRule, Currency: "NGN", MaxAmount: 15099},
+			},
+			transfer:  newTransfer(payeeID, ngn),
+			sent:      sent,
+			want:      usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied},
+			wantRules: []usecase.AuthorizationOutcome{"denied"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfers := &spyTransferRepoAggregator{total: tt.sent}
+
+			e, err := NewEngine(RuleFile{Rules: tt.rules}, users, transfers, logger.Dummy{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			e.now = func() time.Time { return now }
+
+			got, err := e.Evaluate(context.Background(), tt.transfer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got.Decision != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got.Decision, tt.want)
+			}
+
+			var outcomes []usecase.AuthorizationOutcome
+			for _, r := range got.Results {
+				outcomes = append(outcomes, r.Outcome)
+				if r.Detail == "" {
+					t.Errorf("[TestCase '%s'] Rule '%s' is not explained", tt.name, r.Rule)
+				}
+			}
+			if !reflect.DeepEqual(outcomes, tt.wantRules) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, outcomes, tt.wantRules)
+			}
+
+			want := sumSentCall{
+				payer:    mustUuid(payerID),
+				currency: tt.transfer.Value().Currency(),
+				since:    time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC),
+			}
+			for _, c := range transfers.calls {
+				if c != want {
+					t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_Authorized(t *testing.T) {
+	var errRepository = errors.New("repository failure")
+
+	e, err := NewEngine(
+		RuleFile{Rules: []RuleFileEntry{{Name: "velocity", Type: DailyVelocityRule, MaxCount: 1}}},
+		stubUserRepoFinder{},
+		&spyTransferRepoAggregator{err: errRepository},
+		logger.Dummy{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.Authorized(context.Background(), newTransfer(payeeID, vo.NewMoneyNGN(vo.NewAmountTest(100)))); !errors.Is(err, errRepository) {
+		t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", "Repository failure", err, errRepository)
+	}
+}
+
+func TestNewFileEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "Valid rules",
+			content: `{"rules":[{"name":"max","type":"max_amount","currency":"NGN","max_amount":100,"action":"review"}]}`,
+		},
+		{
+			name:    "Unknown rule type",
+			content: `{"rules":[{"name":"unknown","type":"unknown"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid action",
+			content: `{"rules":[{"name":"max","type":"max_amount","max_amount":100,"action":"maybe"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Velocity without limit",
+			content: `{"rules":[{"name":"velocity","type":"daily_velocity"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid blocked payee",
+			content: `{"rules":[{"name":"blocked","type":"blocked_payees","payees":["abc"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid currency",
+			content: `{"rules":[{"name":"currencies","type":"allowed_currencies","currencies":["XXX"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid user type",
+			content: `{"rules":[{"name":"types","type":"allowed_user_types","payer_types":["ADMIN"]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			content: `{"rules":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := NewFileEngine(path, stubUserRepoFinder{}, &spyTransferRepoAggregator{}, logger.Dummy{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type chain struct {
+	authorizers []usecase.Authorizer
+}
+
+// NewChain creates new authorizer asking the authorizers in order, e.g. the rules before the http authorizer.
+// The first decision other than approved, or the first error, ends the chain
+func NewChain(authorizers ...usecase.Authorizer) usecase.Authorizer {
+	return chain{authorizers: authorizers}
+}
+
+// Authorized approves the transfer when every authorizer approves it
+func (c chain) Authorized(ctx context.Context, t entity.Transfer) (usecase.AuthorizationDecision, error) {
+	var decision = usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved}
+	for _, a := range c.authorizers {
+		d, err := a.Authorized(ctx, t)
+		if err != nil {
+			return usecase.AuthorizationDecision{}, err
+		}
+
+		if d.Outcome != usecase.AuthorizationApproved {
+			return d, nil
+		}
+		decision = d
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type spyAuthorizer struct {
+	result usecase.AuthorizationDecision
+	err    error
+	called *int
+}
+
+func (s spyAuthorizer) Authorized(_ context.Context, _ entity.Transfer) (usecase.AuthorizationDecision, error) {
+	*s.called++
+	return s.result, s.err
+}
+
+func TestChain_Authorized(t *testing.T) {
+	var (
+		approved = usecase.AuthorizationDecision{Outcome: usecase.AuthorizationApproved}
+		denied   = usecase.AuthorizationDecision{Outcome: usecase.AuthorizationDenied, ReasonCode: "R01"}
+		errFail  = errors.New("authorizer failure")
+	)
+
+	tests := []struct {
+		name       string
+		decisions  []usecase.AuthorizationDecision
+		errs       []error
+		want       usecase.AuthorizationDecision
+		wantCalled []int
+		wantErr    error
+	}{
+		{
+			name:       "Every authorizer approves",
+			decisions:  []usecase.AuthorizationDecision{approved, approved},
+			errs:       []error{nil, nil},
+			want:       approved,
+			wantCalled: []int{1, 1},
+		},
+		{
+			name:       "Denial ends the chain",
+			decisions:  []usecase.AuthorizationDecision{denied, approved},
+			errs:       []error{nil, nil},
+			want:       denied,
+			wantCalled: []int{1, 0},
+		},
+		{
+			name:       "Error ends the chain",
+			decisions:  []usecase.AuthorizationDecision{approved, approved, approved},
+			errs:       []error{nil, errFail, nil},
+			wantCalled: []int{1, 1, 0},
+			wantErr:    errFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				called      = make([]int, len(tt.decisions))
+				authorizers []usecase.Authorizer
+			)
+			for i := range tt.decisions {
+				authorizers = append(authorizers, spyAuthorizer{result: tt.decisions[i], err: tt.errs[i], called: &called[i]})
+			}
+
+			got, err := NewChain(authorizers...).Authorized(context.Background(), entity.Transfer{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+
+			for i := range called {
+				if called[i] != tt.wantCalled[i] {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, called, tt.wantCalled)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+const (
+	// MaxAmountRule denies a transfer above MaxAmount, in minor units of Currency when it is set
+	MaxAmountRule = "max_amount"
+	// DailyVelocityRule denies a transfer taking the payer above MaxCount transfers or MaxAmount sent
+	// in Currency since the start of the day (UTC)
+	DailyVelocityRule = "daily_velocity"
+	// BlockedPayeesRule denies a transfer to one of Payees
+	BlockedPayeesRule = "blocked_payees"
+	// AllowedCurrenciesRule denies a transfer in a currency other than Currencies
+	AllowedCurrenciesRule = "allowed_currencies"
+	// AllowedUserTypesRule denies a transfer from a payer whose type is not in PayerTypes,
+	// or to a payee whose type is not in PayeeTypes, an empty list allows every type
+	AllowedUserTypesRule = "allowed_user_types"
+)
+
+type (
+	// RuleFile defines the content of a rules file, the rules are evaluated in order
+	RuleFile struct {
+		Rules []RuleFileEntry `json:"rules"`
+	}
+
+	// RuleFileEntry defines a rule of a rules file, the fields used depend on its type.
+	// Action is the outcome when the rule does not pass, denied by default or review
+	RuleFileEntry struct {
+		Name       string   `json:"name"`
+		Type       string   `json:"type"`
+		Action     string   `json:"action"`
+		ReasonCode string   `json:"reason_code"`
+		Currency   string   `json:"currency"`
+		MaxAmount  int64    `json:"max_amount"`
+		MaxCount   int      `json:"max_count"`
+		Payees     []string `json:"payees"`
+		Currencies []string `json:"currencies"`
+		PayerTypes []string `json:"payer_types"`
+		PayeeTypes []string `json:"payee_types"`
+	}
+)
+
+// ReadRuleFile reads the rules of a JSON file
+func ReadRuleFile(path string) (RuleFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return RuleFile{}, errors.Wrap(err, "error reading rules file")
+	}
+
+	var file RuleFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return RuleFile{}, errors.Wrap(err, "error decoding rules file")
+	}
+
+	return file, nil
+}
+
+func (e RuleFileEntry) toRule() (rule, error) {
+	var (
+		meta = ruleMeta{
+			name:       e.Name,
+			kind:       e.Type,
+			action:     usecase.AuthorizationDenied,
+			reasonCode: e.ReasonCode,
+		}
+		err error
+	)
+
+	switch usecase.AuthorizationOutcome(e.Action) {
+	case "", usecase.AuthorizationDenied:
+	case usecase.AuthorizationReview:
+		meta.action = usecase.AuthorizationReview
+	default:
+		return nil, fmt.Errorf("invalid action %q", e.Action)
+	}
+
+	var currency *vo.Currency
+	if e.Currency != "" {
+		c, err := vo.NewCurrency(e.Currency)
+		if err != nil {
+			return nil, err
+		}
+		currency = &c
+	}
+
+	switch e.Type {
+	case MaxAmountRule:
+		if e.MaxAmount <= 0 {
+			return nil, errors.New("max_amount must be positive")
+		}
+		return maxAmount{ruleMeta: meta, currency: currency, max: e.MaxAmount}, nil
+	case DailyVelocityRule:
+		if e.MaxAmount <= 0 && e.MaxCount <= 0 {
+			return nil, errors.New("max_amount or max_count must be positive")
+		}
+		return dailyVelocity{ruleMeta: meta, currency: currency, maxAmount: e.MaxAmount, maxCount: e.MaxCount}, nil
+	case BlockedPayeesRule:
+		var r = blockedPayees{ruleMeta: meta, payees: make(map[string]bool)}
+		for _, p := range e.Payees {
+			ID, err := vo.NewUuid(p)
+			if err != nil {
+				return nil, err
+			}
+			r.payees[ID.Value()] = true
+		}
+		return r, nil
+	case AllowedCurrenciesRule:
+		var r = allowedCurrencies{ruleMeta: meta, currencies: make(map[vo.TypeCurrency]bool)}
+		for _, c := range e.Currencies {
+			currency, err := vo.NewCurrency(c)
+			if err != nil {
+				return nil, err
+			}
+			r.currencies[currency.Value()] = true
+		}
+		return r, nil
+	case AllowedUserTypesRule:
+		var r = allowedUserTypes{ruleMeta: meta}
+		if r.payerTypes, err = typesUser(e.PayerTypes); err != nil {
+			return nil, err
+		}
+		if r.payeeTypes, err = typesUser(e.PayeeTypes); err != nil {
+			return nil, err
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unknown rule type %q", e.Type)
+	}
+}
+
+func typesUser(values []string) (map[vo.TypeUser]bool, error) {
+	var types = make(map[vo.TypeUser]bool)
+	for _, v := range values {
+		t, err := vo.NewTypeUser(v)
+		if err != nil {
+			return nil, err
+		}
+		types[t] = true
+	}
+
+	return types, nil
+}
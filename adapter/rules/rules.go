@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+type (
+	// rule checks a transfer, detail explains the result
+	rule interface {
+		meta() ruleMeta
+		check(ctx context.Context, t entity.Transfer, f *facts) (passed bool, detail string, err error)
+	}
+
+	ruleMeta struct {
+		name       string
+		kind       string
+		action     usecase.AuthorizationOutcome
+		reasonCode string
+	}
+
+	maxAmount struct {
+		ruleMeta
+		currency *vo.Currency
+		max      int64
+	}
+
+	dailyVelocity struct {
+		ruleMeta
+		currency  *vo.Currency
+		maxAmount int64
+		maxCount  int
+	}
+
+	blockedPayees struct {
+		ruleMeta
+		payees map[string]bool
+	}
+
+	allowedCurrencies struct {
+		ruleMeta
+		currencies map[vo.TypeCurrency]bool
+	}
+
+	allowedUserTypes struct {
+		ruleMeta
+		payerTypes map[vo.TypeUser]bool
+		payeeTypes map[vo.TypeUser]bool
+	}
+
+	// facts loads what the rules need about a transfer once, on the first rule asking for it
+	facts struct {
+		users     entity.UserRepositoryFinder
+		transfers entity.TransferRepositoryAggregator
+		now       time.Time
+		payer     *entity.User
+		payee     *entity.User
+	}
+)
+
+func (m ruleMeta) meta() ruleMeta {
+	return m
+}
+
+func (r maxAmount) check(_ context.Context, t entity.Transfer, _ *facts) (bool, string, error) {
+	if r.currency != nil && *r.currency != t.Value().Currency() {
+		return true, fmt.Sprintf("applies to %s only", r.currency.String()), nil
+	}
+
+	value := t.Value().Amount().Value()
+	if value > r.max {
+		return false, fmt.Sprintf("amount %d is above %d", value, r.max), nil
+	}
+
+	return true, fmt.Sprintf("amount %d is within %d", value, r.max), nil
+}
+
+func (r dailyVelocity) check(ctx context.Context, t entity.Transfer, f *facts) (bool, string, error) {
+	var currency = t.Value().Currency()
+	if r.currency != nil {
+		if *r.currency != currency {
+			return true, fmt.Sprintf("applies to %s only", r.currency.String()), nil
+		}
+	}
+
+	count, amount, err := f.sentToday(ctx, t.Payer(), currency)
+	if err != nil {
+		return false, "", err
+	}
+
+	// the transfer being authorized is not completed yet, it is added to what was sent today
+	count++
+	amount += t.Value().Amount().Value()
+
+	if r.maxCount > 0 && count > r.maxCount {
+		return false, fmt.Sprintf("%d transfers today is above %d", count, r.maxCount), nil
+	}
+
+	if r.maxAmount > 0 && amount > r.maxAmount {
+		return false, fmt.Sprintf("%d %s sent today is above %d", amount, currency.String(), r.maxAmount), nil
+	}
+
+	return true, fmt.Sprintf("%d transfers and %d %s sent today", count, amount, currency.String()), nil
+}
+
+func (r blockedPayees) check(_ context.Context, t entity.Transfer, _ *facts) (bool, string, error) {
+	if r.payees[t.Payee().Value()] {
+		return false, fmt.Sprintf("payee %s is blocked", t.Payee().Value()), nil
+	}
+
+	return true, "payee is not blocked", nil
+}
+
+func (r allowedCurrencies) check(_ context.Context, t entity.Transfer, _ *facts) (bool, string, error) {
+	currency := t.Value().Currency()
+	if !r.currencies[currency.Value()] {
+		return false, fmt.Sprintf("currency %s is not allowed", currency.String()), nil
+	}
+
+	return true, fmt.Sprintf("currency %s is allowed", currency.String()), nil
+}
+
+func (r allowedUserTypes) check(ctx context.Context, t entity.Transfer, f *facts) (bool, string, error) {
+	if len(r.payerTypes) > 0 {
+		payer, err := f.user(ctx, &f.payer, t.Payer())
+		if err != nil {
+			return false, "", err
+		}
+
+		if !r.payerTypes[payer.TypeUser()] {
+			return false, fmt.Sprintf("payer type %s is not allowed", payer.TypeUser()), nil
+		}
+	}
+
+	if len(r.payeeTypes) > 0 {
+		payee, err := f.user(ctx, &f.payee, t.Payee())
+		if err != nil {
+			return false, "", err
+		}
+
+		if !r.payeeTypes[payee.TypeUser()] {
+			return false, fmt.Sprintf("payee type %s is not allowed", payee.TypeUser()), nil
+		}
+	}
+
+	return true, "user types are allowed", nil
+}
+
+func (f *facts) user(ctx context.Context, cached **entity.User, ID vo.Uuid) (entity.User, error) {
+	if *cached != nil {
+		return **cached, nil
+	}
+
+	u, err := f.users.FindByID(ctx, ID)
+	if err != nil {
+		return entity.User{}, err
+	}
+	*cached = &u
+
+	return u, nil
+}
+
+// sentToday counts the transfers in currency sent by the payer since the start of the day, and sums them net of refunds
+func (f *facts) sentToday(ctx context.Context, payer vo.Uuid, currency vo.Currency) (int, int64, error) {
+	total, err := f.transfers.SumSent(ctx, payer, currency, f.now.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return total.Count, total.Amount, nil
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+const (
+	UserCreatedEvent       = "user.created"
+	TransferCompletedEvent = "transfer.completed"
+	TransferFailedEvent    = "transfer.failed"
+	WalletDebitedEvent     = "wallet.debited"
+	WalletCreditedEvent    = "wallet.credited"
+)
+
+type (
+	// Event defines something that happened to an entity
+	Event interface {
+		EventName() string
+		OccurredAt() time.Time
+	}
+
+	// UserCreated is recorded when a user is registered
+	UserCreated struct {
+		UserID   vo.Uuid
+		TypeUser vo.TypeUser
+		At       time.Time
+	}
+
+	// TransferCompleted is recorded when a transfer moves to COMPLETED, Transfer is its state at that moment
+	TransferCompleted struct {
+		Transfer Transfer
+		At       time.Time
+	}
+
+	// TransferFailed is recorded when a transfer moves to FAILED
+	TransferFailed struct {
+		TransferID vo.Uuid
+		Reason     string
+		At         time.Time
+	}
+
+	// WalletDebited is recorded when money is withdrawn from the wallet of a user
+	WalletDebited struct {
+		UserID  vo.Uuid
+		Value   vo.Money
+		Balance vo.Money
+		At      time.Time
+	}
+
+	// WalletCredited is recorded when money is deposited in the wallet of a user
+	WalletCredited struct {
+		UserID  vo.Uuid
+		Value   vo.Money
+		Balance vo.Money
+		At      time.Time
+	}
+
+	// events keeps the events recorded by an entity until they are pulled
+	events struct {
+		recorded []Event
+	}
+)
+
+func (e *events) record(event Event) {
+	e.recorded = append(e.recorded, event)
+}
+
+// PullEvents returns the events recorded since the last pull, in the order they happened
+func (e *events) PullEvents() []Event {
+	recorded := e.recorded
+	e.recorded = nil
+
+	return recorded
+}
+
+// EventName returns the name of the event
+func (e UserCreated) EventName() string {
+	return UserCreatedEvent
+}
+
+// OccurredAt returns when the event happened
+func (e UserCreated) OccurredAt() time.Time {
+	return e.At
+}
+
+// EventName returns the name of the event
+func (e TransferCompleted) EventName() string {
+	return TransferCompletedEvent
+}
+
+// OccurredAt returns when the event happened
+func (e TransferCompleted) OccurredAt() time.Time {
+	return e.At
+}
+
+// EventName returns the name of the event
+func (e TransferFailed) EventName() string {
+	return TransferFailedEvent
+}
+
+// OccurredAt returns when the event happened
+func (e TransferFailed) OccurredAt() time.Time {
+	return e.At
+}
+
+// EventName returns the name of the event
+func (e WalletDebited) EventName() string {
+	return WalletDebitedEvent
+}
+
+// OccurredAt returns when the event happened
+func (e WalletDebited) OccurredAt() time.Time {
+	return e.At
+}
+
+// EventName returns the name of the event
+func (e WalletCredited) EventName() string {
+	return WalletCreditedEvent
+}
+
+// OccurredAt returns when the event happened
+func (e WalletCredited) OccurredAt() time.Time {
+	return e.At
+}
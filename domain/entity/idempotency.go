@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+var (
+	ErrCreateIdempotencyKey = errors.New("error creating idempotency key")
+
+	ErrFindIdempotencyKey = errors.New("error fetching idempotency key")
+
+	ErrNotFoundIdempotencyKey = errors.New("not found idempotency key")
+
+	ErrIdempotencyKeyInUse = fault.New(fault.Conflict, "idempotency_key_in_use", "idempotency key is already in use by a concurrent request")
+
+	ErrIdempotencyKeyMismatch = fault.New(fault.Unprocessable, "idempotency_key_mismatch", "idempotency key was already used with a different request")
+)
+
+type (
+	// IdempotencyRepositoryCreator defines the operation of persisting an idempotency key
+	IdempotencyRepositoryCreator interface {
+		Create(context.Context, IdempotencyKey) error
+	}
+
+	// IdempotencyRepositoryFinder defines the search operation for an idempotency key of a payer
+	IdempotencyRepositoryFinder interface {
+		FindByKey(ctx context.Context, payer vo.Uuid, key string) (IdempotencyKey, error)
+	}
+
+	// IdempotencyKey defines the stored result of a request made with an idempotency key, a key is unique for the
+	// payer of the transfer only
+	IdempotencyKey struct {
+		key         string
+		fingerprint string
+		transfer    Transfer
+		createdAt   time.Time
+	}
+)
+
+// NewIdempotencyKey creates new idempotency key
+func NewIdempotencyKey(key string, fingerprint string, transfer Transfer, createdAt time.Time) IdempotencyKey {
+	return IdempotencyKey{
+		key:         key,
+		fingerprint: fingerprint,
+		transfer:    transfer,
+		createdAt:   createdAt,
+	}
+}
+
+// Matches checks that the key was stored for a request with the same fingerprint
+func (i IdempotencyKey) Matches(fingerprint string) error {
+	if i.fingerprint != fingerprint {
+		return ErrIdempotencyKeyMismatch
+	}
+
+	return nil
+}
+
+// Key returns the key property
+func (i IdempotencyKey) Key() string {
+	return i.key
+}
+
+// Payer returns the payer of the transfer, the owner of the key
+func (i IdempotencyKey) Payer() vo.Uuid {
+	return i.transfer.Payer()
+}
+
+// Fingerprint returns the fingerprint property
+func (i IdempotencyKey) Fingerprint() string {
+	return i.fingerprint
+}
+
+// Transfer returns the transfer property
+func (i IdempotencyKey) Transfer() Transfer {
+	return i.transfer
+}
+
+// CreatedAt returns the createdAt property
+func (i IdempotencyKey) CreatedAt() time.Time {
+	return i.createdAt
+}
@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+const (
+	// Posting directions
+	Debit  PostingDirection = "DEBIT"
+	Credit PostingDirection = "CREDIT"
+
+	openingBalanceAccount = "00000000-0000-0000-0000-000000000000"
+	fxAccount             = "00000000-0000-0000-0000-000000000001"
+)
+
+var (
+	ErrCreateJournalEntry = errors.New("error creating journal entry")
+
+	ErrFindPostings = errors.New("error fetching postings")
+
+	ErrUnbalancedJournalEntry = errors.New("journal entry debits and credits do not balance")
+
+	ErrInvalidPosting = errors.New("invalid posting")
+)
+
+type (
+	// LedgerRepositoryCreator defines the operation of creating a journal entry
+	LedgerRepositoryCreator interface {
+		Create(context.Context, JournalEntry) (JournalEntry, error)
+	}
+
+	// LedgerRepositoryFinder defines the search operation for the postings of an account
+	LedgerRepositoryFinder interface {
+		FindPostingsByAccount(context.Context, vo.Uuid) ([]Posting, error)
+	}
+
+	// PostingDirection defines whether a posting debits or credits an account
+	PostingDirection string
+
+	// Posting defines a single debit or credit line of a journal entry
+	Posting struct {
+		account   vo.Uuid
+		direction PostingDirection
+		money     vo.Money
+	}
+
+	// JournalEntry defines the immutable ledger record of a wallet movement
+	JournalEntry struct {
+		id          vo.Uuid
+		transferID  vo.Uuid
+		description string
+		postings    []Posting
+		createdAt   time.Time
+	}
+)
+
+// String returns string representation of the PostingDirection
+func (d PostingDirection) String() string {
+	return string(d)
+}
+
+// NewPosting creates new posting
+func NewPosting(account vo.Uuid, direction PostingDirection, money vo.Money) (Posting, error) {
+	switch direction {
+	case Debit, Credit:
+	default:
+		return Posting{}, ErrInvalidPosting
+	}
+
+	if money.Amount().Value() <= 0 {
+		return Posting{}, ErrInvalidPosting
+	}
+
+	return Posting{
+		account:   account,
+		direction: direction,
+		money:     money,
+	}, nil
+}
+
+// Account returns the account property
+func (p Posting) Account() vo.Uuid {
+	return p.account
+}
+
+// Direction returns the direction property
+func (p Posting) Direction() PostingDirection {
+	return p.direction
+}
+
+// Money returns the money property
+func (p Posting) Money() vo.Money {
+	return p.money
+}
+
+// NewJournalEntry creates new journal entry, rejecting entries whose debits and credits do not balance per currency
+func NewJournalEntry(
+	ID vo.Uuid,
+	transferID vo.Uuid,
+	description string,
+	postings []Posting,
+	createdAt time.Time,
+) (JournalEntry, error) {
+	if len(postings) < 2 {
+		return JournalEntry{}, ErrUnbalancedJournalEntry
+	}
+
+	var totals = make(map[vo.TypeCurrency]vo.Money)
+	for _, p := range postings {
+		total, ok := totals[p.Money().Currency().Value()]
+		if !ok {
+			total = vo.NewMoney(p.Money().Currency(), vo.Amount{})
+		}
+
+		var err error
+		switch p.Direction() {
+		case Debit:
+			total, err = total.Add(p.Money())
+		case Credit:
+			total, err = total.Sub(p.Money())
+		default:
+			return JournalEntry{}, ErrInvalidPosting
+		}
+		if err != nil {
+			return JournalEntry{}, err
+		}
+
+		totals[p.Money().Currency().Value()] = total
+	}
+
+	for _, total := range totals {
+		if total.Amount().Value() != 0 {
+			return JournalEntry{}, ErrUnbalancedJournalEntry
+		}
+	}
+
+	return JournalEntry{
+		id:          ID,
+		transferID:  transferID,
+		description: description,
+		postings:    append([]Posting(nil), postings...),
+		createdAt:   createdAt,
+	}, nil
+}
+
+// NewTransferJournalEntry creates the journal entry that debits the payer and credits the payee of a transfer.
+// Cross-currency transfers go through the FX account, keeping each currency balanced
+func NewTransferJournalEntry(ID vo.Uuid, t Transfer) (JournalEntry, error) {
+	debit, err := NewPosting(t.Payer(), Debit, t.Value())
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	credit, err := NewPosting(t.Payee(), Credit, t.Credit())
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	var postings = []Posting{debit, credit}
+	if t.Value().Currency() != t.Credit().Currency() {
+		fxCredit, err := NewPosting(FXAccount(), Credit, t.Value())
+		if err != nil {
+			return JournalEntry{}, err
+		}
+
+		fxDebit, err := NewPosting(FXAccount(), Debit, t.Credit())
+		if err != nil {
+			return JournalEntry{}, err
+		}
+
+		postings = append(postings, fxCredit, fxDebit)
+	}
+
+	return NewJournalEntry(ID, t.ID(), "transfer", postings, t.CreatedAt())
+}
+
+// NewOpeningBalanceJournalEntry creates the journal entry that funds a new wallet from the opening balance account
+func NewOpeningBalanceJournalEntry(ID vo.Uuid, u User) (JournalEntry, error) {
+	debit, err := NewPosting(OpeningBalanceAccount(), Debit, u.Wallet().Money())
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	credit, err := NewPosting(u.ID(), Credit, u.Wallet().Money())
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	return NewJournalEntry(ID, vo.Uuid{}, "opening balance", []Posting{debit, credit}, u.CreatedAt())
+}
+
+// OpeningBalanceAccount returns the system account that funds opening wallet balances
+func OpeningBalanceAccount() vo.Uuid {
+	ID, _ := vo.NewUuid(openingBalanceAccount)
+	return ID
+}
+
+// FXAccount returns the system account that buys and sells currencies in cross-currency transfers
+func FXAccount() vo.Uuid {
+	ID, _ := vo.NewUuid(fxAccount)
+	return ID
+}
+
+// BalanceFromPostings recomputes the balance of an account in the given currency, credits increase it and debits decrease it
+func BalanceFromPostings(account vo.Uuid, currency vo.Currency, postings []Posting) (vo.Money, error) {
+	var balance = vo.NewMoney(currency, vo.Amount{})
+	for _, p := range postings {
+		if p.Account() != account || p.Money().Currency() != currency {
+			continue
+		}
+
+		var err error
+		switch p.Direction() {
+		case Credit:
+			balance, err = balance.Add(p.Money())
+		case Debit:
+			balance, err = balance.Sub(p.Money())
+		}
+		if err != nil {
+			return vo.Money{}, err
+		}
+	}
+
+	amount, err := vo.NewAmount(balance.Amount().Value())
+	if err != nil {
+		return vo.Money{}, err
+	}
+
+	return vo.NewMoney(currency, amount), nil
+}
+
+// ID returns the id property
+func (j JournalEntry) ID() vo.Uuid {
+	return j.id
+}
+
+// TransferID returns the transferID property
+func (j JournalEntry) TransferID() vo.Uuid {
+	return j.transferID
+}
+
+// Description returns the description property
+func (j JournalEntry) Description() string {
+	return j.description
+}
+
+// Postings returns a copy of the postings property
+func (j JournalEntry) Postings() []Posting {
+	return append([]Posting(nil), j.postings...)
+}
+
+// CreatedAt returns the createdAt property
+func (j JournalEntry) CreatedAt() time.Time {
+	return j.createdAt
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// NoLimit disables a limit of TransferLimit
+const NoLimit = -1
+
+const (
+	// Names of the limits of TransferLimit
+	PerTransactionLimit = "per_transaction"
+	DailyLimit          = "daily"
+	MonthlyLimit        = "monthly"
+	DailyCountLimit     = "daily_count"
+	MonthlyCountLimit   = "monthly_count"
+)
+
+var (
+	ErrLimitExceeded = fault.New(fault.Unprocessable, "limit_exceeded", "transfer limit exceeded")
+
+	ErrSumTransfers = errors.New("error summing transfers")
+)
+
+type (
+	// TransferRepositoryAggregator defines the sum of the transfers sent by a user in a currency, net of refunds
+	TransferRepositoryAggregator interface {
+		SumSent(ctx context.Context, payer vo.Uuid, currency vo.Currency, since time.Time) (TransferTotal, error)
+	}
+
+	// TransferTotal is the number of transfers and the value they moved, in minor units
+	TransferTotal struct {
+		Count  int
+		Amount int64
+	}
+
+	// TransferLimit defines the limits of a KYC tier in a currency, amounts are in minor units and NoLimit disables one
+	TransferLimit struct {
+		Tier           vo.KYCTier
+		Currency       vo.Currency
+		PerTransaction int64
+		Daily          int64
+		Monthly        int64
+		DailyCount     int
+		MonthlyCount   int
+	}
+
+	// LimitExceededError tells which limit the transfer exceeds and how much is still allowed,
+	// Max and Remaining are amounts in minor units or numbers of transfers for the count limits
+	LimitExceededError struct {
+		Limit     string
+		Currency  vo.Currency
+		Max       int64
+		Remaining int64
+	}
+)
+
+// NewUnlimitedTransferLimit creates new TransferLimit without any limit
+func NewUnlimitedTransferLimit(tier vo.KYCTier, currency vo.Currency) TransferLimit {
+	return TransferLimit{
+		Tier:           tier,
+		Currency:       currency,
+		PerTransaction: NoLimit,
+		Daily:          NoLimit,
+		Monthly:        NoLimit,
+		DailyCount:     NoLimit,
+		MonthlyCount:   NoLimit,
+	}
+}
+
+// Check returns a LimitExceededError for the first limit exceeded by value added to the totals sent
+// today and this month, in the order per transaction, daily, monthly, daily count and monthly count
+func (l TransferLimit) Check(value vo.Money, today TransferTotal, month TransferTotal) error {
+	amount := value.Amount().Value()
+
+	for _, c := range []struct {
+		limit string
+		max   int64
+		used  int64
+		add   int64
+	}{
+		{limit: PerTransactionLimit, max: l.PerTransaction, used: 0, add: amount},
+		{limit: DailyLimit, max: l.Daily, used: today.Amount, add: amount},
+		{limit: MonthlyLimit, max: l.Monthly, used: month.Amount, add: amount},
+		{limit: DailyCountLimit, max: int64(l.DailyCount), used: int64(today.Count), add: 1},
+		{limit: MonthlyCountLimit, max: int64(l.MonthlyCount), used: int64(month.Count), add: 1},
+	} {
+		if c.max == NoLimit || c.used+c.add <= c.max {
+			continue
+		}
+
+		remaining := c.max - c.used
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		return &LimitExceededError{
+			Limit:     c.limit,
+			Currency:  value.Currency(),
+			Max:       c.max,
+			Remaining: remaining,
+		}
+	}
+
+	return nil
+}
+
+// Error returns the limit exceeded and the remaining allowance
+func (e *LimitExceededError) Error() string {
+	if e.Limit == DailyCountLimit || e.Limit == MonthlyCountLimit {
+		return fmt.Sprintf("%s: %s limit of %d transfers, %d remaining", ErrLimitExceeded, e.Limit, e.Max, e.Remaining)
+	}
+
+	return fmt.Sprintf(
+		"%s: %s limit of %d %s, %d %s remaining",
+		ErrLimitExceeded,
+		e.Limit,
+		e.Max,
+		e.Currency.String(),
+		e.Remaining,
+		e.Currency.String(),
+	)
+}
+
+// Unwrap returns ErrLimitExceeded, errors.Is(err, ErrLimitExceeded) matches and the error is classified with it
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+var (
+	ErrCreateOutboxMessage = errors.New("error creating outbox message")
+
+	ErrFindOutboxMessages = errors.New("error fetching outbox messages")
+
+	ErrUpdateOutboxMessage = errors.New("error updating outbox message")
+
+	ErrNotFoundOutboxMessage = errors.New("not found outbox message")
+
+	ErrOutboxMessageProcessed = errors.New("outbox message already processed")
+)
+
+const (
+	// TransferCompletedMessage is recorded when a transfer moves the value between the wallets
+	TransferCompletedMessage = "transfer.completed"
+	// TransferReversedMessage is recorded when a reversal refunds the value of a transfer
+	TransferReversedMessage = "transfer.reversed"
+)
+
+type (
+	// OutboxRepositoryCreator defines the operation of recording a message, it must join the transaction of the context
+	OutboxRepositoryCreator interface {
+		Create(context.Context, OutboxMessage) error
+	}
+
+	// OutboxRepositoryFinder defines the search operations for the messages not published yet, ordered from the oldest,
+	// and for a message by its ID
+	OutboxRepositoryFinder interface {
+		FindPending(ctx context.Context, limit int) ([]OutboxMessage, error)
+		FindByID(ctx context.Context, ID vo.Uuid) (OutboxMessage, error)
+	}
+
+	// OutboxRepositoryUpdater defines the operations of marking a message as published and as processed by its consumer,
+	// a message is processed once, marking it again fails with ErrOutboxMessageProcessed
+	OutboxRepositoryUpdater interface {
+		MarkPublished(ctx context.Context, ID vo.Uuid, publishedAt time.Time) error
+		MarkProcessed(ctx context.Context, ID vo.Uuid, processedAt time.Time) error
+	}
+
+	// OutboxMessage defines a message recorded in the same transaction as the change it describes,
+	// it is published afterwards at least once and its ID lets consumers discard the copies
+	OutboxMessage struct {
+		id          vo.Uuid
+		aggregateID vo.Uuid
+		kind        string
+		payload     []byte
+		createdAt   time.Time
+		publishedAt *time.Time
+		processedAt *time.Time
+	}
+)
+
+// NewOutboxMessage creates new outbox message pending publication
+func NewOutboxMessage(ID vo.Uuid, aggregateID vo.Uuid, kind string, payload []byte, createdAt time.Time) OutboxMessage {
+	return OutboxMessage{
+		id:          ID,
+		aggregateID: aggregateID,
+		kind:        kind,
+		payload:     payload,
+		createdAt:   createdAt,
+	}
+}
+
+// NewPublishedOutboxMessage restores an outbox message with its publication and processing dates
+func NewPublishedOutboxMessage(
+	ID vo.Uuid,
+	aggregateID vo.Uuid,
+	kind string,
+	payload []byte,
+	createdAt time.Time,
+	publishedAt *time.Time,
+	processedAt *time.Time,
+) OutboxMessage {
+	m := NewOutboxMessage(ID, aggregateID, kind, payload, createdAt)
+	m.publishedAt = publishedAt
+	m.processedAt = processedAt
+
+	return m
+}
+
+// Published reports whether the message was already published
+func (o OutboxMessage) Published() bool {
+	return o.publishedAt != nil
+}
+
+// Processed reports whether the consumer already processed the message, the copies published afterwards are discarded
+func (o OutboxMessage) Processed() bool {
+	return o.processedAt != nil
+}
+
+// ID returns the id property
+func (o OutboxMessage) ID() vo.Uuid {
+	return o.id
+}
+
+// AggregateID returns the aggregateID property
+func (o OutboxMessage) AggregateID() vo.Uuid {
+	return o.aggregateID
+}
+
+// Kind returns the kind property
+func (o OutboxMessage) Kind() string {
+	return o.kind
+}
+
+// Payload returns the payload property
+func (o OutboxMessage) Payload() []byte {
+	return o.payload
+}
+
+// CreatedAt returns the createdAt property
+func (o OutboxMessage) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// PublishedAt returns the publishedAt property
+func (o OutboxMessage) PublishedAt() *time.Time {
+	return o.publishedAt
+}
+
+// ProcessedAt returns the processedAt property
+func (o OutboxMessage) ProcessedAt() *time.Time {
+	return o.processedAt
+}
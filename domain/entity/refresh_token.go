@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+var (
+	ErrInvalidCredentials = fault.New(fault.Unauthenticated, "invalid_credentials", "invalid email or password")
+
+	ErrInvalidRefreshToken = fault.New(fault.Unauthenticated, "invalid_refresh_token", "invalid refresh token")
+
+	ErrCreateRefreshToken = errors.New("error creating refresh token")
+
+	ErrFindRefreshToken = errors.New("error fetching refresh token")
+
+	ErrRevokeRefreshToken = errors.New("error revoking refresh token")
+)
+
+type (
+	// RefreshTokenRepositoryCreator defines the operation of persisting a refresh token
+	RefreshTokenRepositoryCreator interface {
+		Create(context.Context, RefreshToken) error
+	}
+
+	// RefreshTokenRepositoryFinder defines the search operation for a refresh token
+	RefreshTokenRepositoryFinder interface {
+		FindByHash(context.Context, string) (RefreshToken, error)
+	}
+
+	// RefreshTokenRepositoryRevoker defines the operation of revoking a refresh token,
+	// it must fail with ErrInvalidRefreshToken when the token was already revoked so a token is rotated only once
+	RefreshTokenRepositoryRevoker interface {
+		Revoke(context.Context, vo.Uuid, time.Time) error
+	}
+
+	// RefreshToken defines a long-lived token exchanged for new access tokens, only the hash of the token is stored
+	RefreshToken struct {
+		id        vo.Uuid
+		userID    vo.Uuid
+		hash      string
+		expiresAt time.Time
+		revokedAt *time.Time
+		createdAt time.Time
+	}
+)
+
+// NewRefreshToken creates new refresh token from the token handed to the user
+func NewRefreshToken(ID vo.Uuid, userID vo.Uuid, token string, expiresAt time.Time, createdAt time.Time) RefreshToken {
+	return RefreshToken{
+		id:        ID,
+		userID:    userID,
+		hash:      HashRefreshToken(token),
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+	}
+}
+
+// NewRefreshTokenFromHash restores a stored refresh token
+func NewRefreshTokenFromHash(
+	ID vo.Uuid,
+	userID vo.Uuid,
+	hash string,
+	expiresAt time.Time,
+	revokedAt *time.Time,
+	createdAt time.Time,
+) RefreshToken {
+	return RefreshToken{
+		id:        ID,
+		userID:    userID,
+		hash:      hash,
+		expiresAt: expiresAt,
+		revokedAt: revokedAt,
+		createdAt: createdAt,
+	}
+}
+
+// HashRefreshToken hashes the token handed to the user, refresh tokens are random so a fast hash is enough
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Valid checks that the token was not revoked and has not expired
+func (r RefreshToken) Valid(now time.Time) error {
+	if r.revokedAt != nil || !now.Before(r.expiresAt) {
+		return ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+// ID returns the id property
+func (r RefreshToken) ID() vo.Uuid {
+	return r.id
+}
+
+// UserID returns the userID property
+func (r RefreshToken) UserID() vo.Uuid {
+	return r.userID
+}
+
+// Hash returns the hash property
+func (r RefreshToken) Hash() string {
+	return r.hash
+}
+
+// ExpiresAt returns the expiresAt property
+func (r RefreshToken) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+// RevokedAt returns the revokedAt property
+func (r RefreshToken) RevokedAt() *time.Time {
+	return r.revokedAt
+}
+
+// CreatedAt returns the createdAt property
+func (r RefreshToken) CreatedAt() time.Time {
+	return r.createdAt
+}
@@ -5,13 +5,41 @@ import (
 	"errors"
 	"time"
 
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 )
 
 var (
 	ErrCreateTransfer = errors.New("error creating transfer")
 
-	ErrUnauthorizedTransfer = errors.New("unauthorized transfer")
+	ErrUpdateTransfer = errors.New("error updating transfer")
+
+	ErrFindTransferByID = errors.New("error fetching transfer by ID")
+
+	ErrNotFoundTransfer = fault.New(fault.NotFound, "transfer_not_found", "not found transfer")
+
+	ErrUnauthorizedTransfer = fault.New(fault.Unprocessable, "transfer_denied", "unauthorized transfer")
+
+	ErrSelfTransfer = fault.New(fault.Unprocessable, "self_transfer", "payer and payee must be different users")
+
+	ErrInvalidTransferTransition = fault.New(fault.Conflict, "invalid_transfer_transition", "invalid transfer status transition")
+
+	ErrRefundExceedsTransfer = fault.New(fault.Unprocessable, "refund_exceeds_transfer", "refund exceeds the refundable value of the transfer")
+
+	ErrInvalidRefund = fault.New(fault.Unprocessable, "invalid_refund", "invalid refund")
+
+	ErrReversalNotAllowed = fault.New(fault.Forbidden, "reversal_not_allowed", "only the payee of a transfer can reverse it")
+
+	ErrFindTransfersByUser = errors.New("error fetching transfers by user")
+
+	ErrInvalidTransferCursor = fault.New(fault.Invalid, "invalid_cursor", "invalid transfer cursor")
+)
+
+const (
+	// TransferSent selects the transfers where the user is the payer
+	TransferSent TransferDirection = "sent"
+	// TransferReceived selects the transfers where the user is the payee
+	TransferReceived TransferDirection = "received"
 )
 
 type (
@@ -21,17 +49,66 @@ type (
 		WithTransaction(context.Context, func(context.Context) error) error
 	}
 
+	// TransferRepositoryUpdater defines the update operation of a transfer entity status
+	TransferRepositoryUpdater interface {
+		Update(context.Context, Transfer) error
+	}
+
+	// TransferRepositoryFinder defines the search operations for a transfer entity
+	TransferRepositoryFinder interface {
+		FindByID(context.Context, vo.Uuid) (Transfer, error)
+		FindByUser(context.Context, TransferFilter) ([]Transfer, error)
+	}
+
+	// TransferDirection defines which side of a transfer the user is on, empty means both
+	TransferDirection string
+
+	// TransferFilter defines the criteria of a transfer history search, ordered from the newest transfer.
+	// Zero values disable the respective criterion
+	TransferFilter struct {
+		UserID    vo.Uuid
+		Direction TransferDirection
+		From      time.Time
+		To        time.Time
+		MinValue  vo.Amount
+		MaxValue  vo.Amount
+		Status    vo.TransferStatus
+		After     *TransferCursor
+		Limit     int
+	}
+
+	// TransferCursor defines the position of the last transfer of a page
+	TransferCursor struct {
+		CreatedAt time.Time
+		ID        vo.Uuid
+	}
+
 	// Transfer define the transfer entity
 	Transfer struct {
-		id        vo.Uuid
-		payer     vo.Uuid
-		payee     vo.Uuid
-		value     vo.Money
-		createdAt time.Time
+		id         vo.Uuid
+		payer      vo.Uuid
+		payee      vo.Uuid
+		value      vo.Money
+		credit     vo.Money
+		rate       vo.ExchangeRate
+		refunded   vo.Money
+		reversalOf vo.Uuid
+		status     vo.TransferStatus
+		history    []TransferTransition
+		createdAt  time.Time
+		events
+	}
+
+	// TransferTransition defines a status change in the transfer lifecycle
+	TransferTransition struct {
+		from   vo.TransferStatus
+		to     vo.TransferStatus
+		reason string
+		at     time.Time
 	}
 )
 
-// NewTransfer creates new transfer
+// NewTransfer creates new transfer in the PENDING status, crediting the payee with the same value debited from the payer
 func NewTransfer(
 	ID vo.Uuid,
 	payerID vo.Uuid,
@@ -44,10 +121,160 @@ func NewTransfer(
 		payer:     payerID,
 		payee:     payeeID,
 		value:     value,
+		credit:    value,
+		rate:      vo.NewIdentityExchangeRate(value.Currency()),
+		refunded:  vo.NewMoney(value.Currency(), vo.Amount{}),
+		status:    vo.PENDING,
+		history:   []TransferTransition{NewTransferTransition("", vo.PENDING, "", createdAt)},
 		createdAt: createdAt,
 	}
 }
 
+// NewReversal creates new transfer that moves value back from the payee to the payer of the original transfer.
+// The refund is given in the currency debited from the original payer, a cross-currency reversal reuses the rate of the
+// original transfer so the payee gives back what it was credited
+func NewReversal(ID vo.Uuid, original Transfer, refund vo.Money, createdAt time.Time) (Transfer, error) {
+	debit, err := original.Rate().Convert(refund)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	reversal := NewTransfer(ID, original.Payee(), original.Payer(), debit, createdAt)
+	reversal.reversalOf = original.ID()
+	if debit.Currency() != refund.Currency() {
+		reversal.credit = refund
+		reversal.rate = original.Rate()
+	}
+
+	return reversal, nil
+}
+
+// NewTransferCursor creates new cursor pointing at the transfer
+func NewTransferCursor(t Transfer) TransferCursor {
+	return TransferCursor{
+		CreatedAt: t.CreatedAt(),
+		ID:        t.ID(),
+	}
+}
+
+// NewTransferTransition creates new transfer transition
+func NewTransferTransition(from vo.TransferStatus, to vo.TransferStatus, reason string, at time.Time) TransferTransition {
+	return TransferTransition{
+		from:   from,
+		to:     to,
+		reason: reason,
+		at:     at,
+	}
+}
+
+// WithStatus restores a persisted status and its transition history
+func (t Transfer) WithStatus(status vo.TransferStatus, history []TransferTransition) Transfer {
+	t.status = status
+	t.history = append([]TransferTransition(nil), history...)
+	return t
+}
+
+// WithConversion sets the value credited to the payee in another currency and the rate applied to convert it
+func (t Transfer) WithConversion(credit vo.Money, rate vo.ExchangeRate) Transfer {
+	t.credit = credit
+	t.rate = rate
+	return t
+}
+
+// WithRefunded restores the persisted refunded value
+func (t Transfer) WithRefunded(refunded vo.Money) Transfer {
+	t.refunded = refunded
+	return t
+}
+
+// WithReversalOf restores the link to the transfer being reversed
+func (t Transfer) WithReversalOf(ID vo.Uuid) Transfer {
+	t.reversalOf = ID
+	return t
+}
+
+// Refund records a full or partial refund, moving the transfer to REVERSED once the whole value was refunded
+func (t *Transfer) Refund(value vo.Money, at time.Time) error {
+	if t.status != vo.COMPLETED {
+		return ErrInvalidTransferTransition
+	}
+
+	if value.Currency() != t.value.Currency() || value.Amount().Value() <= 0 {
+		return ErrInvalidRefund
+	}
+
+	if value.Amount().Value() > t.Refundable().Amount().Value() {
+		return ErrRefundExceedsTransfer
+	}
+
+	refunded, err := t.refunded.Add(value)
+	if err != nil {
+		return err
+	}
+
+	t.refunded = refunded
+	if t.Refundable().Amount().Value() > 0 {
+		return nil
+	}
+
+	return t.Reverse("refunded", at)
+}
+
+// Refundable returns the value that can still be refunded
+func (t Transfer) Refundable() vo.Money {
+	refundable, err := t.value.Sub(t.refunded)
+	if err != nil {
+		return vo.NewMoney(t.value.Currency(), vo.Amount{})
+	}
+
+	return refundable
+}
+
+// Authorize moves the transfer to AUTHORIZED
+func (t *Transfer) Authorize(at time.Time) error {
+	return t.transition(vo.AUTHORIZED, "", at)
+}
+
+// Complete moves the transfer to COMPLETED and records TransferCompleted
+func (t *Transfer) Complete(at time.Time) error {
+	if err := t.transition(vo.COMPLETED, "", at); err != nil {
+		return err
+	}
+
+	snapshot := *t
+	snapshot.events = events{}
+	t.record(TransferCompleted{Transfer: snapshot, At: at})
+
+	return nil
+}
+
+// Fail moves the transfer to FAILED recording the reason and records TransferFailed
+func (t *Transfer) Fail(reason string, at time.Time) error {
+	if err := t.transition(vo.FAILED, reason, at); err != nil {
+		return err
+	}
+
+	t.record(TransferFailed{TransferID: t.id, Reason: reason, At: at})
+
+	return nil
+}
+
+// Reverse moves the transfer to REVERSED
+func (t *Transfer) Reverse(reason string, at time.Time) error {
+	return t.transition(vo.REVERSED, reason, at)
+}
+
+func (t *Transfer) transition(to vo.TransferStatus, reason string, at time.Time) error {
+	if !t.status.CanTransitionTo(to) {
+		return ErrInvalidTransferTransition
+	}
+
+	t.history = append(t.history, NewTransferTransition(t.status, to, reason, at))
+	t.status = to
+
+	return nil
+}
+
 // ID returns the id property
 func (t Transfer) ID() vo.Uuid {
 	return t.id
@@ -68,7 +295,57 @@ func (t Transfer) Value() vo.Money {
 	return t.value
 }
 
+// Credit returns the value credited to the payee
+func (t Transfer) Credit() vo.Money {
+	return t.credit
+}
+
+// Rate returns the exchange rate applied to the transfer
+func (t Transfer) Rate() vo.ExchangeRate {
+	return t.rate
+}
+
+// Refunded returns the refunded property
+func (t Transfer) Refunded() vo.Money {
+	return t.refunded
+}
+
+// ReversalOf returns the ID of the transfer this transfer reverses, empty for regular transfers
+func (t Transfer) ReversalOf() vo.Uuid {
+	return t.reversalOf
+}
+
+// Status returns the status property
+func (t Transfer) Status() vo.TransferStatus {
+	return t.status
+}
+
+// History returns a copy of the history property
+func (t Transfer) History() []TransferTransition {
+	return append([]TransferTransition(nil), t.history...)
+}
+
 // CreatedAt returns the createdAt property
 func (t Transfer) CreatedAt() time.Time {
 	return t.createdAt
-}
\ No newline at end of file
+}
+
+// From returns the from property
+func (t TransferTransition) From() vo.TransferStatus {
+	return t.from
+}
+
+// To returns the to property
+func (t TransferTransition) To() vo.TransferStatus {
+	return t.to
+}
+
+// Reason returns the reason property
+func (t TransferTransition) Reason() string {
+	return t.reason
+}
+
+// At returns the at property
+func (t TransferTransition) At() time.Time {
+	return t.at
+}
@@ -5,35 +5,53 @@ import (
 	"errors"
 	"time"
 
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 )
 
 var (
-	ErrUserInsufficientBalance = errors.New("user does not have sufficient balance")
+	ErrUserInsufficientBalance = fault.New(fault.Unprocessable, "insufficient_balance", "user does not have sufficient balance")
 
-	ErrNotFoundUser = errors.New("not found user")
+	ErrNotFoundUser = fault.New(fault.NotFound, "user_not_found", "not found user")
 
 	ErrUpdateUserWallet = errors.New("error updating the value of the wallet")
 
+	// ErrConcurrentModification is returned when the wallet was updated by someone else since it was read
+	ErrConcurrentModification = fault.New(fault.Conflict, "concurrent_modification", "the wallet was modified concurrently")
+
 	ErrCreateUser = errors.New("error creating user")
 
+	// ErrUserAlreadyExists is returned when the ID, the email or the document of the user is taken by another one
+	ErrUserAlreadyExists = fault.New(fault.Conflict, "user_already_exists", "a user with this email or document already exists")
+
 	ErrFindUserByID = errors.New("error fetching user by ID")
+
+	ErrFindUserByEmail = errors.New("error fetching user by email")
+
+	ErrUpdateUserPassword = errors.New("error updating the password of the user")
+
+	// ErrCurrencyMismatch is returned when the money moved does not match the currency of the wallet
+	ErrCurrencyMismatch = vo.ErrCurrencyMismatch
 )
 
 type (
 	// UserRepositoryCreator defines the operation of creating a transfer entity
 	UserRepositoryCreator interface {
 		Create(context.Context, User) (User, error)
+		WithTransaction(context.Context, func(context.Context) error) error
 	}
 
 	// UserRepositoryFinder defines the search operation for a user entity
 	UserRepositoryFinder interface {
 		FindByID(context.Context, vo.Uuid) (User, error)
+		FindByEmail(context.Context, vo.Email) (User, error)
 	}
 
-	// UserRepositoryUpdater defines the update operation of a user entity wallet
+	// UserRepositoryUpdater defines the update operations of a user entity wallet and password. The wallet is only
+	// updated if it is still at the version it was read at, ErrConcurrentModification is returned otherwise
 	UserRepositoryUpdater interface {
-		UpdateWallet(context.Context, vo.Uuid, vo.Money) error
+		UpdateWallet(context.Context, vo.Uuid, *vo.Wallet) error
+		UpdatePassword(context.Context, vo.Uuid, vo.HashedPassword) error
 	}
 
 	// User defines the user entity
@@ -41,12 +59,14 @@ type (
 		id        vo.Uuid
 		fullName  vo.FullName
 		email     vo.Email
-		password  vo.Password
+		password  vo.HashedPassword
 		document  vo.Document
 		wallet    *vo.Wallet
 		typeUser  vo.TypeUser
+		kycTier   vo.KYCTier
 		roles     vo.Roles
 		createdAt time.Time
+		events
 	}
 )
 
@@ -55,7 +75,7 @@ func NewUser(
 	ID vo.Uuid,
 	fullName vo.FullName,
 	email vo.Email,
-	password vo.Password,
+	password vo.HashedPassword,
 	document vo.Document,
 	wallet *vo.Wallet,
 	typeUser vo.TypeUser,
@@ -92,7 +112,7 @@ func NewCommonUser(
 	ID vo.Uuid,
 	fullName vo.FullName,
 	email vo.Email,
-	password vo.Password,
+	password vo.HashedPassword,
 	document vo.Document,
 	wallet *vo.Wallet,
 	createdAt time.Time,
@@ -108,6 +128,7 @@ func NewCommonUser(
 			CanTransfer: true,
 		},
 		typeUser:  vo.COMMON,
+		kycTier:   vo.DefaultKYCTier,
 		createdAt: createdAt,
 	}
 }
@@ -117,7 +138,7 @@ func NewMerchantUser(
 	ID vo.Uuid,
 	fullName vo.FullName,
 	email vo.Email,
-	password vo.Password,
+	password vo.HashedPassword,
 	document vo.Document,
 	wallet *vo.Wallet,
 	createdAt time.Time,
@@ -133,24 +154,44 @@ func NewMerchantUser(
 			CanTransfer: false,
 		},
 		typeUser:  vo.MERCHANT,
+		kycTier:   vo.DefaultKYCTier,
 		createdAt: createdAt,
 	}
 }
 
-// Withdraw remove value of money of wallet
-func (u User) Withdraw(money vo.Money) error {
+// Register records UserCreated, it is called once when the user signs up
+func (u *User) Register(at time.Time) {
+	u.record(UserCreated{UserID: u.id, TypeUser: u.typeUser, At: at})
+}
+
+// Withdraw remove value of money of wallet and records WalletDebited
+func (u *User) Withdraw(money vo.Money, at time.Time) error {
+	if u.Wallet().Money().Currency() != money.Currency() {
+		return ErrCurrencyMismatch
+	}
+
 	if u.Wallet().Money().Amount().Value() < money.Amount().Value() {
 		return ErrUserInsufficientBalance
 	}
 
-	u.Wallet().Sub(money.Amount())
+	if _, err := u.Wallet().Sub(money); err != nil {
+		return err
+	}
+
+	u.record(WalletDebited{UserID: u.id, Value: money, Balance: u.Wallet().Money(), At: at})
 
 	return nil
 }
 
-// Deposit add value of money of wallet
-func (u User) Deposit(money vo.Money) {
-	u.Wallet().Add(money.Amount())
+// Deposit add value of money of wallet and records WalletCredited
+func (u *User) Deposit(money vo.Money, at time.Time) error {
+	if _, err := u.Wallet().Add(money); err != nil {
+		return err
+	}
+
+	u.record(WalletCredited{UserID: u.id, Value: money, Balance: u.Wallet().Money(), At: at})
+
+	return nil
 }
 
 // CanTransfer returns whether it is possible to transfer
@@ -172,8 +213,20 @@ func (u User) FullName() vo.FullName {
 	return u.fullName
 }
 
-// Password returns the password property
-func (u User) Password() vo.Password {
+// WithPassword returns a copy of the user with another hashed password
+func (u User) WithPassword(password vo.HashedPassword) User {
+	u.password = password
+	return u
+}
+
+// WithKYCTier returns a copy of the user with another KYC tier
+func (u User) WithKYCTier(tier vo.KYCTier) User {
+	u.kycTier = tier
+	return u
+}
+
+// Password returns the hashed password property
+func (u User) Password() vo.HashedPassword {
 	return u.password
 }
 
@@ -192,6 +245,11 @@ func (u User) TypeUser() vo.TypeUser {
 	return u.typeUser
 }
 
+// KYCTier returns the kycTier property
+func (u User) KYCTier() vo.KYCTier {
+	return u.kycTier
+}
+
 // Wallet returns the wallet property
 func (u User) Wallet() *vo.Wallet {
 	return u.wallet
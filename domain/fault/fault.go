@@ -0,0 +1,117 @@
+package fault
+
+import "errors"
+
+const (
+	// Kinds of error, they tell how the caller can react to it. Internal is the kind of the errors not classified
+	Internal Kind = iota
+	Invalid
+	Unauthenticated
+	Forbidden
+	NotFound
+	Conflict
+	Unprocessable
+	TooLarge
+	Unavailable
+)
+
+type (
+	// Kind define the class of an error
+	Kind int
+
+	// Error is a domain error with its kind and a stable code, e.g. insufficient_balance, clients can rely on
+	// the code while the message is meant for humans
+	Error struct {
+		kind    Kind
+		code    string
+		message string
+		cause   error
+	}
+)
+
+// New creates new Error, it is meant for sentinel errors compared with errors.Is
+func New(kind Kind, code string, message string) *Error {
+	return &Error{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+}
+
+// Wrap classifies err with the kind and the code, its message is kept and errors.Is still matches it
+func Wrap(err error, kind Kind, code string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{
+		kind:    kind,
+		code:    code,
+		message: err.Error(),
+		cause:   err,
+	}
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Unwrap returns the error classified by Wrap
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Kind returns the kind property
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// Code returns the code property
+func (e *Error) Code() string {
+	return e.code
+}
+
+// KindOf returns the kind of the first Error in the chain of err, Internal when there is none
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.kind
+	}
+
+	return Internal
+}
+
+// CodeOf returns the code of the first Error in the chain of err, internal_error when there is none
+func CodeOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.code
+	}
+
+	return "internal_error"
+}
+
+// String returns string representation of the Kind
+func (k Kind) String() string {
+	switch k {
+	case Invalid:
+		return "invalid"
+	case Unauthenticated:
+		return "unauthenticated"
+	case Forbidden:
+		return "forbidden"
+	case NotFound:
+		return "not_found"
+	case Conflict:
+		return "conflict"
+	case Unprocessable:
+		return "unprocessable"
+	case TooLarge:
+		return "too_large"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
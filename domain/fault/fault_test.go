@@ -0,0 +1,79 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestKindOf(t *testing.T) {
+	var errNotFound = New(NotFound, "not_found_user", "not found user")
+
+	tests := []struct {
+		name     string
+		err      error
+		wantKind Kind
+		wantCode string
+	}{
+		{
+			name:     "Sentinel error",
+			err:      errNotFound,
+			wantKind: NotFound,
+			wantCode: "not_found_user",
+		},
+		{
+			name:     "Sentinel error wrapped with fmt",
+			err:      fmt.Errorf("payee: %w", errNotFound),
+			wantKind: NotFound,
+			wantCode: "not_found_user",
+		},
+		{
+			name:     "Sentinel error wrapped with pkg errors",
+			err:      pkgerrors.Wrap(errNotFound, "error fetching payee"),
+			wantKind: NotFound,
+			wantCode: "not_found_user",
+		},
+		{
+			name:     "Error classified by Wrap",
+			err:      Wrap(errors.New("unexpected EOF"), Invalid, "invalid_body"),
+			wantKind: Invalid,
+			wantCode: "invalid_body",
+		},
+		{
+			name:     "Error not classified",
+			err:      errors.New("connection refused"),
+			wantKind: Internal,
+			wantCode: "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.wantKind {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantKind)
+			}
+
+			if got := CodeOf(tt.err); got != tt.wantCode {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var cause = errors.New("unexpected EOF")
+
+	err := Wrap(cause, Invalid, "invalid_body")
+	if err.Error() != cause.Error() {
+		t.Errorf("[TestCase 'Message is kept'] Got: '%v' | Want: '%v'", err.Error(), cause.Error())
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("[TestCase 'Cause is matched'] Got: '%v' | Want: '%v'", false, true)
+	}
+
+	if err := Wrap(nil, Invalid, "invalid_body"); err != nil {
+		t.Errorf("[TestCase 'Nil error'] Got: '%v' | Want: '%v'", err, nil)
+	}
+}
@@ -1,12 +1,13 @@
 package vo
 
 import (
-	"errors"
 	"strconv"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 var (
-	errInvalidAmount = errors.New("invalid amount")
+	errInvalidAmount = fault.New(fault.Invalid, "invalid_amount", "invalid amount")
 )
 
 // Amount structure
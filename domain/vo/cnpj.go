@@ -1,13 +1,13 @@
 package vo
 
 import (
-	"errors"
 	"regexp"
 
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 var (
-	ErrInvalidCNPJ = errors.New("invalid cnpj")
+	ErrInvalidCNPJ = fault.New(fault.Invalid, "invalid_cnpj", "invalid cnpj")
 	rxCNPJ         = regexp.MustCompile(`^\d{2}\.?\d{3}\.?\d{3}\/?(:?\d{3}[1-9]|\d{2}[1-9]\d|\d[1-9]\d{2}|[1-9]\d{3})-?\d{2}$`)
 )
 
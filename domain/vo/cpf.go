@@ -1,12 +1,13 @@
 package vo
 
 import (
-	"errors"
 	"regexp"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 var (
-	ErrInvalidCPF = errors.New("invalid cpf")
+	ErrInvalidCPF = fault.New(fault.Invalid, "invalid_cpf", "invalid cpf")
 
 	rxCPF = regexp.MustCompile(`^\d{3}\.?\d{3}\.?\d{3}-?\d{2}$`)
 )
@@ -1,6 +1,6 @@
 package vo
 
-import "errors"
+import "github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 
 const (
 	//Currency types
@@ -19,7 +19,14 @@ func (tc TypeCurrency) String() string {
 }
 
 var (
-	ErrInvalidCurrency = errors.New("invalid currency")
+	ErrInvalidCurrency = fault.New(fault.Invalid, "invalid_currency", "invalid currency")
+
+	// minorUnits is the ISO 4217 number of decimal places of each currency
+	minorUnits = map[TypeCurrency]int{
+		NGN: 2,
+		USD: 2,
+		GBP: 2,
+	}
 )
 
 type (
@@ -40,11 +47,8 @@ func NewCurrency(value string) (Currency, error) {
 }
 
 func (c Currency) validate() bool {
-	switch c.value {
-	case NGN, USD, GBP:
-		return true
-	}
-	return false
+	_, ok := minorUnits[c.value]
+	return ok
 }
 
 // Value return value Currency
@@ -52,6 +56,11 @@ func (c Currency) Value() TypeCurrency {
 	return c.value
 }
 
+// MinorUnits returns the number of decimal places of the currency, 2 for NGN means 100 kobo make one naira
+func (c Currency) MinorUnits() int {
+	return minorUnits[c.value]
+}
+
 // String returns string representation of the Currency
 func (c Currency) String() string {
 	return string(c.value)
@@ -61,4 +70,4 @@ func (c Currency) String() string {
 func (c Currency) Equals(value Value) bool {
 	o, ok := value.(Currency)
 	return ok && c.value == o.value
-}
\ No newline at end of file
+}
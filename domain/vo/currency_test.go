@@ -136,3 +136,34 @@ func TestCurrency_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrency_MinorUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		want     int
+	}{
+		{
+			name:     "Test minor units of NGN",
+			currency: Currency{value: NGN},
+			want:     2,
+		},
+		{
+			name:     "Test minor units of USD",
+			currency: Currency{value: USD},
+			want:     2,
+		},
+		{
+			name:     "Test minor units of GBP",
+			currency: Currency{value: GBP},
+			want:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.currency.MinorUnits(); got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
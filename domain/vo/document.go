@@ -1,8 +1,6 @@
 package vo
 
-import (
-	"github.com/pkg/errors"
-)
+import "github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 
 const (
 	// Document types
@@ -11,7 +9,7 @@ const (
 )
 
 var (
-	ErrInvalidTypeDocument = errors.New("invalid type document")
+	ErrInvalidTypeDocument = fault.New(fault.Invalid, "invalid_document_type", "invalid type document")
 )
 
 type (
@@ -25,7 +23,7 @@ func (t TypeDocument) String() string {
 }
 
 var (
-	ErrInvalidDocument = errors.New("invalid document")
+	ErrInvalidDocument = fault.New(fault.Invalid, "invalid_document", "invalid document")
 )
 
 // Document structure
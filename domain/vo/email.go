@@ -1,12 +1,13 @@
 package vo
 
 import (
-	"errors"
 	"regexp"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 var (
-	ErrInvalidEmail = errors.New("invalid email")
+	ErrInvalidEmail = fault.New(fault.Invalid, "invalid_email", "invalid email")
 
 	rxEmail = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+\\/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 )
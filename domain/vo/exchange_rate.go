@@ -0,0 +1,133 @@
+package vo
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+var (
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+
+	ErrExchangeRateUnavailable = fault.New(fault.Unprocessable, "exchange_rate_unavailable", "exchange rate unavailable")
+
+	ErrConversionTooSmall = fault.New(fault.Unprocessable, "conversion_too_small", "converted value rounds to zero")
+
+	rxExchangeRate = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,12})?$`)
+)
+
+// ExchangeRate structure, the rate is how many units of the to currency one unit of the from currency buys
+type ExchangeRate struct {
+	from Currency
+	to   Currency
+	rate string
+}
+
+// NewExchangeRate creates new ExchangeRate from a positive decimal rate with at most 12 decimal places
+func NewExchangeRate(from Currency, to Currency, rate string) (ExchangeRate, error) {
+	var e = ExchangeRate{from: from, to: to, rate: rate}
+
+	if !e.validate() {
+		return ExchangeRate{}, ErrInvalidExchangeRate
+	}
+	return e, nil
+}
+
+// NewIdentityExchangeRate creates the rate between a currency and itself
+func NewIdentityExchangeRate(currency Currency) ExchangeRate {
+	return ExchangeRate{from: currency, to: currency, rate: "1"}
+}
+
+func (e ExchangeRate) validate() bool {
+	if !rxExchangeRate.MatchString(e.rate) {
+		return false
+	}
+
+	if e.from == e.to {
+		return e.rate == "1"
+	}
+
+	r, ok := new(big.Rat).SetString(e.rate)
+	return ok && r.Sign() > 0
+}
+
+// Convert converts money in the from currency to the to currency.
+// The result is rounded to the nearest minor unit of the to currency, ties are rounded to the even unit (banker's rounding)
+func (e ExchangeRate) Convert(money Money) (Money, error) {
+	if money.Currency() != e.from {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	if e.from == e.to {
+		return money, nil
+	}
+
+	r, ok := new(big.Rat).SetString(e.rate)
+	if !ok {
+		return Money{}, ErrInvalidExchangeRate
+	}
+
+	// the rate is quoted in major units, scale it to the minor units of each currency
+	var scale = new(big.Rat).SetFrac(pow10(e.to.MinorUnits()), pow10(e.from.MinorUnits()))
+
+	var (
+		product   = new(big.Rat).Mul(new(big.Rat).SetInt64(money.Amount().Value()), new(big.Rat).Mul(r, scale))
+		quo, rem  = new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+		remainder = new(big.Int).Mul(rem, big.NewInt(2))
+	)
+
+	switch remainder.Cmp(product.Denom()) {
+	case 1:
+		quo.Add(quo, big.NewInt(1))
+	case 0:
+		if quo.Bit(0) == 1 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	if !quo.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	if quo.Sign() == 0 && money.Amount().Value() > 0 {
+		return Money{}, ErrConversionTooSmall
+	}
+
+	return NewMoney(e.to, Amount{value: quo.Int64()}), nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// From returns the from currency
+func (e ExchangeRate) From() Currency {
+	return e.from
+}
+
+// To returns the to currency
+func (e ExchangeRate) To() Currency {
+	return e.to
+}
+
+// String returns the decimal representation of the rate
+func (e ExchangeRate) String() string {
+	return e.rate
+}
+
+// Equals check that two ExchangeRate are the same
+func (e ExchangeRate) Equals(value Value) bool {
+	o, ok := value.(ExchangeRate)
+	return ok && e == o
+}
+
+// NewExchangeRateTest creates new ExchangeRate for testing
+func NewExchangeRateTest(from TypeCurrency, to TypeCurrency, rate string) ExchangeRate {
+	return ExchangeRate{
+		from: Currency{value: from},
+		to:   Currency{value: to},
+		rate: rate,
+	}
+}
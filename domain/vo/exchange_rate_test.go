@@ -0,0 +1,151 @@
+package vo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewExchangeRate(t *testing.T) {
+	type args struct {
+		from Currency
+		to   Currency
+		rate string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    ExchangeRate
+		wantErr error
+	}{
+		{
+			name: "Test new valid exchange rate",
+			args: args{
+				from: Currency{value: USD},
+				to:   Currency{value: NGN},
+				rate: "1500.25",
+			},
+			want: ExchangeRate{
+				from: Currency{value: USD},
+				to:   Currency{value: NGN},
+				rate: "1500.25",
+			},
+		},
+		{
+			name: "Test new invalid zero exchange rate",
+			args: args{
+				from: Currency{value: USD},
+				to:   Currency{value: NGN},
+				rate: "0",
+			},
+			want:    ExchangeRate{},
+			wantErr: ErrInvalidExchangeRate,
+		},
+		{
+			name: "Test new invalid negative exchange rate",
+			args: args{
+				from: Currency{value: USD},
+				to:   Currency{value: NGN},
+				rate: "-1.5",
+			},
+			want:    ExchangeRate{},
+			wantErr: ErrInvalidExchangeRate,
+		},
+		{
+			name: "Test new invalid fraction exchange rate",
+			args: args{
+				from: Currency{value: USD},
+				to:   Currency{value: NGN},
+				rate: "3/2",
+			},
+			want:    ExchangeRate{},
+			wantErr: ErrInvalidExchangeRate,
+		},
+		{
+			name: "Test new invalid exchange rate between the same currency",
+			args: args{
+				from: Currency{value: USD},
+				to:   Currency{value: USD},
+				rate: "2",
+			},
+			want:    ExchangeRate{},
+			wantErr: ErrInvalidExchangeRate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExchangeRate(tt.args.from, tt.args.to, tt.args.rate)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    ExchangeRate
+		money   Money
+		want    Money
+		wantErr error
+	}{
+		{
+			name:  "Test convert exact value",
+			rate:  NewExchangeRateTest(USD, NGN, "1500"),
+			money: NewMoneyUSD(Amount{value: 250}),
+			want:  Money{currency: Currency{value: NGN}, amount: Amount{value: 375000}},
+		},
+		{
+			name:  "Test convert rounding down",
+			rate:  NewExchangeRateTest(NGN, USD, "0.000666666667"),
+			money: NewMoneyNGN(Amount{value: 100000}),
+			want:  Money{currency: Currency{value: USD}, amount: Amount{value: 67}},
+		},
+		{
+			name:  "Test convert tie rounds to the even unit below",
+			rate:  NewExchangeRateTest(GBP, USD, "1.25"),
+			money: NewMoney(Currency{value: GBP}, Amount{value: 2}),
+			want:  Money{currency: Currency{value: USD}, amount: Amount{value: 2}},
+		},
+		{
+			name:  "Test convert tie rounds to the even unit above",
+			rate:  NewExchangeRateTest(GBP, USD, "1.5"),
+			money: NewMoney(Currency{value: GBP}, Amount{value: 1}),
+			want:  Money{currency: Currency{value: USD}, amount: Amount{value: 2}},
+		},
+		{
+			name:  "Test convert with identity rate",
+			rate:  NewIdentityExchangeRate(Currency{value: NGN}),
+			money: NewMoneyNGN(Amount{value: 100}),
+			want:  NewMoneyNGN(Amount{value: 100}),
+		},
+		{
+			name:    "Test convert value that rounds to zero",
+			rate:    NewExchangeRateTest(NGN, USD, "0.000666666667"),
+			money:   NewMoneyNGN(Amount{value: 1}),
+			want:    Money{},
+			wantErr: ErrConversionTooSmall,
+		},
+		{
+			name:    "Test convert money of another currency",
+			rate:    NewExchangeRateTest(USD, NGN, "1500"),
+			money:   NewMoneyNGN(Amount{value: 100}),
+			want:    Money{},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rate.Convert(tt.money)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
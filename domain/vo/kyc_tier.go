@@ -0,0 +1,42 @@
+package vo
+
+import (
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+const (
+	// KYC tiers, from the unverified user to the fully verified one
+	TIER0 KYCTier = "TIER_0"
+	TIER1 KYCTier = "TIER_1"
+	TIER2 KYCTier = "TIER_2"
+	TIER3 KYCTier = "TIER_3"
+
+	// DefaultKYCTier is the tier of a user signing up with a document
+	DefaultKYCTier = TIER1
+)
+
+var (
+	ErrInvalidKYCTier = fault.New(fault.Invalid, "invalid_kyc_tier", "invalid kyc tier")
+)
+
+type (
+	// KYCTier define how much of the identity of the user was verified, it sets the limits of the transfers
+	KYCTier string
+)
+
+// NewKYCTier creates new KYCTier
+func NewKYCTier(value string) (KYCTier, error) {
+	switch t := KYCTier(strings.ToUpper(value)); t {
+	case TIER0, TIER1, TIER2, TIER3:
+		return t, nil
+	}
+
+	return "", ErrInvalidKYCTier
+}
+
+// String returns string representation of the KYCTier
+func (t KYCTier) String() string {
+	return string(t)
+}
@@ -0,0 +1,29 @@
+package vo
+
+import "testing"
+
+func TestNewKYCTier(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    KYCTier
+		wantErr bool
+	}{
+		{name: "Valid tier", value: "TIER_2", want: TIER2, wantErr: false},
+		{name: "Lower case tier", value: "tier_0", want: TIER0, wantErr: false},
+		{name: "Invalid tier", value: "GOLD", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewKYCTier(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,39 @@
 package vo
 
-// Money structure
+import (
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+var (
+	ErrCurrencyMismatch = fault.New(fault.Unprocessable, "currency_mismatch", "currency mismatch")
+
+	ErrMoneyOverflow = fault.New(fault.Unprocessable, "money_overflow", "money overflow")
+
+	ErrInvalidMoney = fault.New(fault.Invalid, "invalid_money", "invalid money")
+
+	ErrInvalidAllocation = errors.New("invalid allocation")
+
+	rxMoney = regexp.MustCompile(`^([0-9]{1,3}(?:,[0-9]{3})+|[0-9]+)(?:\.([0-9]+))? ([A-Z]{3})$`)
+)
+
+// Money structure, the amount is expressed in the minor unit of the currency
 type Money struct {
-	currency 	Currency
-	amount		Amount
+	currency Currency
+	amount   Amount
 }
 
 // NewMoney creates new Money
 func NewMoney(currency Currency, amount Amount) Money {
 	return Money{
 		currency: currency,
-		amount: amount,
+		amount:   amount,
 	}
 }
 
@@ -18,7 +41,7 @@ func NewMoney(currency Currency, amount Amount) Money {
 func NewMoneyNGN(amount Amount) Money {
 	return Money{
 		currency: Currency{value: NGN},
-		amount: amount,
+		amount:   amount,
 	}
 }
 
@@ -26,8 +49,39 @@ func NewMoneyNGN(amount Amount) Money {
 func NewMoneyUSD(amount Amount) Money {
 	return Money{
 		currency: Currency{value: USD},
-		amount: amount,
+		amount:   amount,
+	}
+}
+
+// ParseMoney parses a decimal string followed by the currency code, like "1,234.56 USD".
+// Thousands separators are optional and the fraction can not have more digits than the minor units of the currency
+func ParseMoney(value string) (Money, error) {
+	var matches = rxMoney.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return Money{}, ErrInvalidMoney
+	}
+
+	currency, err := NewCurrency(matches[3])
+	if err != nil {
+		return Money{}, err
+	}
+
+	var (
+		units    = currency.MinorUnits()
+		integer  = strings.ReplaceAll(matches[1], ",", "")
+		fraction = matches[2]
+	)
+
+	if len(fraction) > units {
+		return Money{}, ErrInvalidMoney
 	}
+
+	minor, err := strconv.ParseInt(integer+fraction+strings.Repeat("0", units-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return NewMoney(currency, Amount{value: minor}), nil
 }
 
 // Amount return value Amount
@@ -40,24 +94,140 @@ func (m Money) Currency() Currency {
 	return m.currency
 }
 
-// Add adds value in Amount
-func (m Money) Add(amount Amount) Money {
+// Add adds money of the same currency
+func (m Money) Add(money Money) (Money, error) {
+	if m.currency != money.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	var a, b = m.amount.Value(), money.amount.Value()
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return Money{}, ErrMoneyOverflow
+	}
+
 	return Money{
 		currency: m.currency,
-		amount:		Amount{value: m.amount.Value() + amount.Value()},
+		amount:   Amount{value: a + b},
+	}, nil
+}
+
+// Sub subtracts money of the same currency
+func (m Money) Sub(money Money) (Money, error) {
+	if m.currency != money.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	var a, b = m.amount.Value(), money.amount.Value()
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return Money{}, ErrMoneyOverflow
 	}
+
+	return Money{
+		currency: m.currency,
+		amount:   Amount{value: a - b},
+	}, nil
 }
 
-// Sub subtracts value from Amount
-func (m Money) Sub(amount Amount) Money {
+// Mul multiplies the money by an integer factor
+func (m Money) Mul(factor int64) (Money, error) {
+	var product = new(big.Int).Mul(big.NewInt(m.amount.Value()), big.NewInt(factor))
+	if !product.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
 	return Money{
 		currency: m.currency,
-		amount:	Amount{value: m.amount.Value() - amount.Value()},
+		amount:   Amount{value: product.Int64()},
+	}, nil
+}
+
+// Allocate splits the money in parts proportional to the ratios without losing minor units,
+// the remainder of the division is handed out one unit at a time to the first parts with a non-zero ratio
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 || m.amount.Value() < 0 {
+		return nil, ErrInvalidAllocation
+	}
+
+	var total = new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, ErrInvalidAllocation
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+
+	if total.Sign() == 0 {
+		return nil, ErrInvalidAllocation
 	}
+
+	var (
+		parts     = make([]Money, len(ratios))
+		remainder = m.amount.Value()
+		amount    = big.NewInt(m.amount.Value())
+	)
+
+	for i, ratio := range ratios {
+		var share = new(big.Int).Mul(amount, big.NewInt(ratio))
+		share.Quo(share, total)
+
+		parts[i] = Money{currency: m.currency, amount: Amount{value: share.Int64()}}
+		remainder -= share.Int64()
+	}
+
+	for i := 0; remainder > 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+
+		parts[i].amount.value++
+		remainder--
+	}
+
+	return parts, nil
+}
+
+// String returns the decimal representation of the money followed by the currency code, like "1,234.56 USD"
+func (m Money) String() string {
+	var (
+		value = m.amount.Value()
+		sign  string
+	)
+
+	if value < 0 {
+		sign = "-"
+	}
+
+	var (
+		digits = strings.TrimPrefix(strconv.FormatInt(value, 10), "-")
+		units  = m.currency.MinorUnits()
+	)
+
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+
+	var (
+		integer  = digits[:len(digits)-units]
+		fraction = digits[len(digits)-units:]
+		grouped  strings.Builder
+	)
+
+	for i, d := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(d)
+	}
+
+	if units > 0 {
+		grouped.WriteString("." + fraction)
+	}
+
+	return sign + grouped.String() + " " + m.currency.String()
 }
 
 // Equals check that two Money are the same
 func (m Money) Equals(value Value) bool {
 	o, ok := value.(Money)
 	return ok && m.amount == o.amount && m.currency == o.currency
-}
\ No newline at end of file
+}
@@ -1,9 +1,9 @@
 package vo
 
 import (
+	"math"
 	"reflect"
 	"testing"
-
 )
 
 func TestNewMoney(t *testing.T) {
@@ -93,13 +93,14 @@ func TestMoney_Add(t *testing.T) {
 		amount   Amount
 	}
 	type args struct {
-		amount Amount
+		money Money
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   Money
+		name    string
+		fields  fields
+		args    args
+		want    Money
+		wantErr error
 	}{
 		{
 			name: "Test add money",
@@ -112,8 +113,13 @@ func TestMoney_Add(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 100,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
 				},
 			},
 			want: Money{
@@ -136,8 +142,13 @@ func TestMoney_Add(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 10,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 10,
+					},
 				},
 			},
 			want: Money{
@@ -149,11 +160,61 @@ func TestMoney_Add(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test add money with a different currency",
+			fields: fields{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: 100,
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: USD,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			want:    Money{},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name: "Test add money overflow",
+			fields: fields{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: math.MaxInt64,
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 1,
+					},
+				},
+			},
+			want:    Money{},
+			wantErr: ErrMoneyOverflow,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := NewMoney(tt.fields.currency, tt.fields.amount)
-			if got := m.Add(tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			got, err := m.Add(tt.args.money)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
 			}
 		})
@@ -166,13 +227,14 @@ func TestMoney_Sub(t *testing.T) {
 		amount   Amount
 	}
 	type args struct {
-		amount Amount
+		money Money
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   Money
+		name    string
+		fields  fields
+		args    args
+		want    Money
+		wantErr error
 	}{
 		{
 			name: "Test sub money",
@@ -185,8 +247,13 @@ func TestMoney_Sub(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 100,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
 				},
 			},
 			want: Money{
@@ -209,8 +276,13 @@ func TestMoney_Sub(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 1000,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 1000,
+					},
 				},
 			},
 			want: Money{
@@ -222,11 +294,61 @@ func TestMoney_Sub(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test sub money with a different currency",
+			fields: fields{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: 100,
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: USD,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			want:    Money{},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name: "Test sub money overflow",
+			fields: fields{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: math.MinInt64,
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 1,
+					},
+				},
+			},
+			want:    Money{},
+			wantErr: ErrMoneyOverflow,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := NewMoney(tt.fields.currency, tt.fields.amount)
-			if got := m.Sub(tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			got, err := m.Sub(tt.args.money)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
 			}
 		})
@@ -345,3 +467,212 @@ func TestMoney_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestMoney_Mul(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   Money
+		factor  int64
+		want    Money
+		wantErr error
+	}{
+		{
+			name:   "Test mul money",
+			money:  NewMoneyNGN(Amount{value: 150}),
+			factor: 3,
+			want:   NewMoneyNGN(Amount{value: 450}),
+		},
+		{
+			name:    "Test mul money overflow",
+			money:   NewMoneyNGN(Amount{value: math.MaxInt64 / 2}),
+			factor:  3,
+			want:    Money{},
+			wantErr: ErrMoneyOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.Mul(tt.factor)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   Money
+		ratios  []int64
+		want    []Money
+		wantErr error
+	}{
+		{
+			name:   "Test allocate money in equal parts",
+			money:  NewMoneyNGN(Amount{value: 100}),
+			ratios: []int64{1, 1, 1},
+			want: []Money{
+				NewMoneyNGN(Amount{value: 34}),
+				NewMoneyNGN(Amount{value: 33}),
+				NewMoneyNGN(Amount{value: 33}),
+			},
+		},
+		{
+			name:   "Test allocate money by ratios",
+			money:  NewMoneyUSD(Amount{value: 5}),
+			ratios: []int64{70, 30},
+			want: []Money{
+				NewMoneyUSD(Amount{value: 4}),
+				NewMoneyUSD(Amount{value: 1}),
+			},
+		},
+		{
+			name:   "Test allocate money skipping zero ratios",
+			money:  NewMoneyNGN(Amount{value: 1}),
+			ratios: []int64{0, 1, 1},
+			want: []Money{
+				NewMoneyNGN(Amount{value: 0}),
+				NewMoneyNGN(Amount{value: 1}),
+				NewMoneyNGN(Amount{value: 0}),
+			},
+		},
+		{
+			name:   "Test allocate large money without overflow",
+			money:  NewMoneyNGN(Amount{value: math.MaxInt64}),
+			ratios: []int64{math.MaxInt64, math.MaxInt64},
+			want: []Money{
+				NewMoneyNGN(Amount{value: math.MaxInt64/2 + 1}),
+				NewMoneyNGN(Amount{value: math.MaxInt64 / 2}),
+			},
+		},
+		{
+			name:    "Test allocate money without ratios",
+			money:   NewMoneyNGN(Amount{value: 100}),
+			wantErr: ErrInvalidAllocation,
+		},
+		{
+			name:    "Test allocate money with zero ratios",
+			money:   NewMoneyNGN(Amount{value: 100}),
+			ratios:  []int64{0, 0},
+			wantErr: ErrInvalidAllocation,
+		},
+		{
+			name:    "Test allocate money with a negative ratio",
+			money:   NewMoneyNGN(Amount{value: 100}),
+			ratios:  []int64{2, -1},
+			wantErr: ErrInvalidAllocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.Allocate(tt.ratios...)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr error
+	}{
+		{
+			name:  "Test parse money with thousands separators",
+			value: "1,234.56 USD",
+			want:  NewMoneyUSD(Amount{value: 123456}),
+		},
+		{
+			name:  "Test parse money without separators",
+			value: "1234.5 NGN",
+			want:  NewMoneyNGN(Amount{value: 123450}),
+		},
+		{
+			name:  "Test parse money without fraction",
+			value: "20 GBP",
+			want:  NewMoney(Currency{value: GBP}, Amount{value: 2000}),
+		},
+		{
+			name:    "Test parse money with more decimal places than the currency",
+			value:   "1.234 USD",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Test parse money with misplaced separators",
+			value:   "12,34.56 USD",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Test parse negative money",
+			value:   "-1.00 USD",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Test parse money with an unknown currency",
+			value:   "1.00 EUR",
+			wantErr: ErrInvalidCurrency,
+		},
+		{
+			name:    "Test parse money overflow",
+			value:   "92,233,720,368,547,758.08 USD",
+			wantErr: ErrMoneyOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{
+			name:  "Test format money with thousands separators",
+			money: NewMoneyUSD(Amount{value: 123456}),
+			want:  "1,234.56 USD",
+		},
+		{
+			name:  "Test format money lower than one unit",
+			money: NewMoneyNGN(Amount{value: 5}),
+			want:  "0.05 NGN",
+		},
+		{
+			name:  "Test format negative money",
+			money: NewMoneyNGN(Amount{value: -1234567}),
+			want:  "-12,345.67 NGN",
+		},
+		{
+			name:  "Test format the largest money",
+			money: NewMoneyUSD(Amount{value: math.MaxInt64}),
+			want:  "92,233,720,368,547,758.07 USD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
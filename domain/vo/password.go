@@ -1,13 +1,70 @@
 package vo
 
-// Password structure
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+)
+
+const (
+	minPasswordLength = 8
+	// maxPasswordLength is the number of bytes bcrypt takes into account
+	maxPasswordLength = 72
+)
+
+var (
+	ErrPasswordTooShort = fault.New(fault.Invalid, "password_too_short", "password must have at least 8 characters")
+
+	ErrPasswordTooLong = fault.New(fault.Invalid, "password_too_long", "password must have at most 72 bytes")
+
+	ErrPasswordTooWeak = fault.New(fault.Invalid, "password_too_weak", "password must have upper case and lower case letters and digits")
+
+	ErrInvalidHashedPassword = errors.New("invalid hashed password")
+)
+
+// Password structure, the plaintext typed by the user, it must never be persisted or returned
 type Password struct {
 	value string
 }
 
-// NewPassword creates a new password
-func NewPassword(value string) Password {
-	return Password{value: value}
+// NewPassword creates a new password that satisfies the strength policy
+func NewPassword(value string) (Password, error) {
+	var p = Password{value: value}
+
+	if err := p.validate(); err != nil {
+		return Password{}, err
+	}
+	return p, nil
+}
+
+func (p Password) validate() error {
+	if utf8.RuneCountInString(p.value) < minPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	if len(p.value) > maxPasswordLength {
+		return ErrPasswordTooLong
+	}
+
+	var upper, lower, digit bool
+	for _, r := range p.value {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+
+	if !upper || !lower || !digit {
+		return ErrPasswordTooWeak
+	}
+	return nil
 }
 
 // Value returns the value  password
@@ -19,4 +76,53 @@ func (p Password) Value() string {
 func (p Password) Equals(value Value) bool {
 	o, ok := value.(Password)
 	return ok && p.value == o.value
-}
\ No newline at end of file
+}
+
+// NewPasswordTest creates a new password for testing, skipping the strength policy
+func NewPasswordTest(value string) Password {
+	return Password{value: value}
+}
+
+// HashedPassword structure, a hash string tagged with its algorithm, like "$argon2id$..." or "$2a$..."
+type HashedPassword struct {
+	value string
+}
+
+// NewHashedPassword creates a new HashedPassword from an algorithm-tagged hash string
+func NewHashedPassword(value string) (HashedPassword, error) {
+	var h = HashedPassword{value: value}
+
+	if !h.validate() {
+		return HashedPassword{}, ErrInvalidHashedPassword
+	}
+	return h, nil
+}
+
+func (h HashedPassword) validate() bool {
+	return h.Algorithm() != ""
+}
+
+// Algorithm returns the tag of the algorithm that produced the hash
+func (h HashedPassword) Algorithm() string {
+	var parts = strings.SplitN(h.value, "$", 3)
+	if len(parts) < 3 || parts[0] != "" {
+		return ""
+	}
+	return parts[1]
+}
+
+// Value returns the hash string
+func (h HashedPassword) Value() string {
+	return h.value
+}
+
+// Equals check that two HashedPassword are equal
+func (h HashedPassword) Equals(value Value) bool {
+	o, ok := value.(HashedPassword)
+	return ok && h.value == o.value
+}
+
+// NewHashedPasswordTest creates a new HashedPassword for testing
+func NewHashedPasswordTest(value string) HashedPassword {
+	return HashedPassword{value: value}
+}
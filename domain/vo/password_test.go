@@ -0,0 +1,93 @@
+package vo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Password
+		wantErr error
+	}{
+		{
+			name:  "Test new valid password",
+			value: "Passw0rd",
+			want:  Password{value: "Passw0rd"},
+		},
+		{
+			name:    "Test new password too short",
+			value:   "Pass0",
+			want:    Password{},
+			wantErr: ErrPasswordTooShort,
+		},
+		{
+			name:    "Test new password too long",
+			value:   "Passw0rd" + strings.Repeat("a", 65),
+			want:    Password{},
+			wantErr: ErrPasswordTooLong,
+		},
+		{
+			name:    "Test new password without digits",
+			value:   "Password",
+			want:    Password{},
+			wantErr: ErrPasswordTooWeak,
+		},
+		{
+			name:    "Test new password without upper case letters",
+			value:   "passw0rd",
+			want:    Password{},
+			wantErr: ErrPasswordTooWeak,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPassword(tt.value)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHashedPassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantAlgorithm string
+		wantErr       error
+	}{
+		{
+			name:          "Test new bcrypt hashed password",
+			value:         "$2a$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW",
+			wantAlgorithm: "2a",
+		},
+		{
+			name:          "Test new argon2id hashed password",
+			value:         "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5",
+			wantAlgorithm: "argon2id",
+		},
+		{
+			name:    "Test new hashed password from plaintext",
+			value:   "passw",
+			wantErr: ErrInvalidHashedPassword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewHashedPassword(tt.value)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if got.Algorithm() != tt.wantAlgorithm {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got.Algorithm(), tt.wantAlgorithm)
+			}
+		})
+	}
+}
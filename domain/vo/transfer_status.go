@@ -0,0 +1,53 @@
+package vo
+
+import "github.com/ofiliobi/urban-octo-fortnight/domain/fault"
+
+const (
+	// Transfer statuses
+	PENDING    TransferStatus = "PENDING"
+	AUTHORIZED TransferStatus = "AUTHORIZED"
+	COMPLETED  TransferStatus = "COMPLETED"
+	FAILED     TransferStatus = "FAILED"
+	REVERSED   TransferStatus = "REVERSED"
+)
+
+var (
+	ErrInvalidTransferStatus = fault.New(fault.Invalid, "invalid_transfer_status", "invalid transfer status")
+
+	transferTransitions = map[TransferStatus][]TransferStatus{
+		PENDING:    {AUTHORIZED, FAILED},
+		AUTHORIZED: {COMPLETED, FAILED},
+		COMPLETED:  {REVERSED},
+	}
+)
+
+type (
+	// TransferStatus define the states of the transfer lifecycle
+	TransferStatus string
+)
+
+// NewTransferStatus creates new TransferStatus
+func NewTransferStatus(value string) (TransferStatus, error) {
+	switch s := TransferStatus(value); s {
+	case PENDING, AUTHORIZED, COMPLETED, FAILED, REVERSED:
+		return s, nil
+	}
+
+	return "", ErrInvalidTransferStatus
+}
+
+// CanTransitionTo checks whether the lifecycle allows moving to the next status
+func (s TransferStatus) CanTransitionTo(next TransferStatus) bool {
+	for _, allowed := range transferTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns string representation of the TransferStatus
+func (s TransferStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,54 @@
+package vo
+
+import "testing"
+
+func TestTransferStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from TransferStatus
+		to   TransferStatus
+		want bool
+	}{
+		{name: "Pending to authorized", from: PENDING, to: AUTHORIZED, want: true},
+		{name: "Pending to failed", from: PENDING, to: FAILED, want: true},
+		{name: "Authorized to completed", from: AUTHORIZED, to: COMPLETED, want: true},
+		{name: "Authorized to failed", from: AUTHORIZED, to: FAILED, want: true},
+		{name: "Completed to reversed", from: COMPLETED, to: REVERSED, want: true},
+		{name: "Pending to completed", from: PENDING, to: COMPLETED, want: false},
+		{name: "Completed to failed", from: COMPLETED, to: FAILED, want: false},
+		{name: "Failed to completed", from: FAILED, to: COMPLETED, want: false},
+		{name: "Reversed to completed", from: REVERSED, to: COMPLETED, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTransferStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    TransferStatus
+		wantErr bool
+	}{
+		{name: "Valid status", value: "COMPLETED", want: COMPLETED, wantErr: false},
+		{name: "Invalid status", value: "DONE", want: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTransferStatus(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package vo
 import (
 	"strings"
 
-	"github.com/pkg/errors"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 const (
@@ -12,9 +12,9 @@ const (
 )
 
 var (
-	ErrInvalidTypeUser = errors.New("invalid type user")
+	ErrInvalidTypeUser = fault.New(fault.Invalid, "invalid_user_type", "invalid type user")
 
-	ErrNotAllowedTypeUser = errors.New("not allowed user type")
+	ErrNotAllowedTypeUser = fault.New(fault.Unprocessable, "user_type_not_allowed", "not allowed user type")
 )
 
 type (
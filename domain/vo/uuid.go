@@ -1,12 +1,15 @@
 package vo
 
 import (
-	"errors"
 	"regexp"
+
+	"github.com/google/uuid"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/fault"
 )
 
 var (
-	ErrInvalidUuid = errors.New("invalid uuid")
+	ErrInvalidUuid = fault.New(fault.Invalid, "invalid_uuid", "invalid uuid")
 
 	rxUuid = regexp.MustCompile(`[0-9a-fA-F]{8}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{4}\-[0-9a-fA-F]{12}`)
 )
@@ -31,6 +34,11 @@ func (e Uuid) validate() bool {
 	return rxUuid.MatchString(e.value)
 }
 
+// NewUuidRandom creates new random Uuid
+func NewUuidRandom() Uuid {
+	return Uuid{value: uuid.New().String()}
+}
+
 // Value return value Uuid
 func (e Uuid) Value() string {
 	return e.value
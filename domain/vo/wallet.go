@@ -1,8 +1,9 @@
 package vo
 
-// Wallet structure
+// Wallet structure, the version counts the updates of the stored wallet so an update made from a stale read is rejected
 type Wallet struct {
-	money Money
+	money   Money
+	version int64
 }
 
 // NewWallet creates new wallet
@@ -12,21 +13,44 @@ func NewWallet(money Money) *Wallet {
 	}
 }
 
+// NewVersionedWallet restores a stored wallet with its version
+func NewVersionedWallet(money Money, version int64) *Wallet {
+	return &Wallet{
+		money:   money,
+		version: version,
+	}
+}
+
 // Money return value money
 func (w Wallet) Money() Money {
 	return w.money
 }
 
-// Add value in money value amount
-func (w *Wallet) Add(amount Amount) Money {
-	w.money = w.money.Add(amount)
-	return w.money
+// Version returns the version of the stored wallet the money was read from
+func (w Wallet) Version() int64 {
+	return w.version
 }
 
-// Sub subtracts value in money value amount
-func (w *Wallet) Sub(amount Amount) Money {
-	w.money = w.money.Sub(amount)
-	return w.money
+// Add adds money of the wallet currency, leaving the wallet untouched on error
+func (w *Wallet) Add(money Money) (Money, error) {
+	result, err := w.money.Add(money)
+	if err != nil {
+		return w.money, err
+	}
+
+	w.money = result
+	return w.money, nil
+}
+
+// Sub subtracts money of the wallet currency, leaving the wallet untouched on error
+func (w *Wallet) Sub(money Money) (Money, error) {
+	result, err := w.money.Sub(money)
+	if err != nil {
+		return w.money, err
+	}
+
+	w.money = result
+	return w.money, nil
 }
 
 // Equals check that two Wallet are the same
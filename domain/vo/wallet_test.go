@@ -10,13 +10,14 @@ func TestWallet_Add(t *testing.T) {
 		money Money
 	}
 	type args struct {
-		amount Amount
+		money Money
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   Money
+		name    string
+		fields  fields
+		args    args
+		want    Money
+		wantErr error
 	}{
 		{
 			name: "Test add value in money",
@@ -31,8 +32,13 @@ func TestWallet_Add(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 100,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
 				},
 			},
 			want: Money{
@@ -57,8 +63,13 @@ func TestWallet_Add(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 250,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 250,
+					},
 				},
 			},
 			want: Money{
@@ -70,11 +81,47 @@ func TestWallet_Add(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test add money with a different currency",
+			fields: fields{
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: USD,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			want: Money{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: 100,
+				},
+			},
+			wantErr: ErrCurrencyMismatch,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := NewWallet(tt.fields.money)
-			if got := w.Add(tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			got, err := w.Add(tt.args.money)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
 			}
 		})
@@ -86,13 +133,14 @@ func TestWallet_Sub(t *testing.T) {
 		money Money
 	}
 	type args struct {
-		amount Amount
+		money Money
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   Money
+		name    string
+		fields  fields
+		args    args
+		want    Money
+		wantErr error
 	}{
 		{
 			name: "Test sub value in money",
@@ -107,8 +155,13 @@ func TestWallet_Sub(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 100,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
 				},
 			},
 			want: Money{
@@ -133,8 +186,13 @@ func TestWallet_Sub(t *testing.T) {
 				},
 			},
 			args: args{
-				amount: Amount{
-					value: 100,
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
 				},
 			},
 			want: Money{
@@ -146,11 +204,47 @@ func TestWallet_Sub(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test sub money with a different currency",
+			fields: fields{
+				money: Money{
+					currency: Currency{
+						value: NGN,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			args: args{
+				money: Money{
+					currency: Currency{
+						value: USD,
+					},
+					amount: Amount{
+						value: 100,
+					},
+				},
+			},
+			want: Money{
+				currency: Currency{
+					value: NGN,
+				},
+				amount: Amount{
+					value: 100,
+				},
+			},
+			wantErr: ErrCurrencyMismatch,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := NewWallet(tt.fields.money)
-			if got := w.Sub(tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			got, err := w.Sub(tt.args.money)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
 			}
 		})
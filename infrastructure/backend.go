@@ -0,0 +1,170 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	adapterlogger "github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+)
+
+const (
+	// Backends of DATABASE_BACKEND and QUEUE_BACKEND, the memory ones keep everything in the process so the server
+	// runs without external services, the outbox relay and the notify worker then run inside the HTTP server.
+	// The SQL backends open DATABASE_DSN with the driver of the same name, built in with the postgres or sqlite tag
+	mongoBackend    = "mongodb"
+	postgresBackend = "postgres"
+	sqliteBackend   = "sqlite"
+	rabbitMQBackend = "rabbitmq"
+	memoryBackend   = "memory"
+)
+
+// repositories gathers the repositories of a database backend
+type repositories struct {
+	userCreator         entity.UserRepositoryCreator
+	userFinder          entity.UserRepositoryFinder
+	userUpdater         entity.UserRepositoryUpdater
+	transferCreator     entity.TransferRepositoryCreator
+	transferUpdater     entity.TransferRepositoryUpdater
+	transferFinder      entity.TransferRepositoryFinder
+	transferAggregator  entity.TransferRepositoryAggregator
+	ledgerCreator       entity.LedgerRepositoryCreator
+	ledgerFinder        entity.LedgerRepositoryFinder
+	idempotencyCreator  entity.IdempotencyRepositoryCreator
+	idempotencyFinder   entity.IdempotencyRepositoryFinder
+	outboxCreator       entity.OutboxRepositoryCreator
+	outboxFinder        entity.OutboxRepositoryFinder
+	outboxUpdater       entity.OutboxRepositoryUpdater
+	refreshTokenCreator entity.RefreshTokenRepositoryCreator
+	refreshTokenFinder  entity.RefreshTokenRepositoryFinder
+	refreshTokenRevoker entity.RefreshTokenRepositoryRevoker
+}
+
+func newMongoRepositories(handler *database.MongoHandler) repositories {
+	return repositories{
+		userCreator:         repository.NewCreateUserRepository(handler),
+		userFinder:          repository.NewFindUserByIDUserRepository(handler),
+		userUpdater:         repository.NewUpdateUserRepository(handler),
+		transferCreator:     repository.NewCreateTransferRepository(handler),
+		transferUpdater:     repository.NewUpdateTransferRepository(handler),
+		transferFinder:      repository.NewFindTransferRepository(handler),
+		transferAggregator:  repository.NewSumTransfersRepository(handler),
+		ledgerCreator:       repository.NewCreateJournalEntryRepository(handler),
+		ledgerFinder:        repository.NewFindPostingsByAccountRepository(handler),
+		idempotencyCreator:  repository.NewCreateIdempotencyKeyRepository(handler),
+		idempotencyFinder:   repository.NewFindIdempotencyKeyRepository(handler),
+		outboxCreator:       repository.NewCreateOutboxMessageRepository(handler),
+		outboxFinder:        repository.NewFindOutboxMessagesRepository(handler),
+		outboxUpdater:       repository.NewUpdateOutboxMessageRepository(handler),
+		refreshTokenCreator: repository.NewCreateRefreshTokenRepository(handler),
+		refreshTokenFinder:  repository.NewFindRefreshTokenRepository(handler),
+		refreshTokenRevoker: repository.NewRevokeRefreshTokenRepository(handler),
+	}
+}
+
+func newSQLRepositories(handler *database.SQLHandler) repositories {
+	return repositories{
+		userCreator:         repository.NewCreateUserSQLRepository(handler),
+		userFinder:          repository.NewFindUserByIDUserSQLRepository(handler),
+		userUpdater:         repository.NewUpdateUserSQLRepository(handler),
+		transferCreator:     repository.NewCreateTransferSQLRepository(handler),
+		transferUpdater:     repository.NewUpdateTransferSQLRepository(handler),
+		transferFinder:      repository.NewFindTransferSQLRepository(handler),
+		transferAggregator:  repository.NewSumTransfersSQLRepository(handler),
+		ledgerCreator:       repository.NewCreateJournalEntrySQLRepository(handler),
+		ledgerFinder:        repository.NewFindPostingsByAccountSQLRepository(handler),
+		idempotencyCreator:  repository.NewCreateIdempotencyKeySQLRepository(handler),
+		idempotencyFinder:   repository.NewFindIdempotencyKeySQLRepository(handler),
+		outboxCreator:       repository.NewCreateOutboxMessageSQLRepository(handler),
+		outboxFinder:        repository.NewFindOutboxMessagesSQLRepository(handler),
+		outboxUpdater:       repository.NewUpdateOutboxMessageSQLRepository(handler),
+		refreshTokenCreator: repository.NewCreateRefreshTokenSQLRepository(handler),
+		refreshTokenFinder:  repository.NewFindRefreshTokenSQLRepository(handler),
+		refreshTokenRevoker: repository.NewRevokeRefreshTokenSQLRepository(handler),
+	}
+}
+
+func newMemoryRepositories(store *database.InMemory) repositories {
+	var (
+		users     = database.NewUserInMen(store)
+		transfers = database.NewTransferInMen(store)
+		ledger    = database.NewLedgerInMen(store)
+		keys      = database.NewIdempotencyInMen(store)
+		outbox    = database.NewOutboxInMen(store)
+		tokens    = database.NewRefreshTokenInMen(store)
+	)
+
+	return repositories{
+		userCreator:         users,
+		userFinder:          users,
+		userUpdater:         users,
+		transferCreator:     transfers,
+		transferUpdater:     transfers,
+		transferFinder:      transfers,
+		transferAggregator:  transfers,
+		ledgerCreator:       ledger,
+		ledgerFinder:        ledger,
+		idempotencyCreator:  keys,
+		idempotencyFinder:   keys,
+		outboxCreator:       outbox,
+		outboxFinder:        outbox,
+		outboxUpdater:       outbox,
+		refreshTokenCreator: tokens,
+		refreshTokenFinder:  tokens,
+		refreshTokenRevoker: tokens,
+	}
+}
+
+// openSQLDatabase opens DATABASE_DSN with the driver of the backend and applies the migrations not applied yet
+func openSQLDatabase(backend string, logger adapterlogger.Logger) *database.SQLHandler {
+	handler, err := database.NewSQLHandler(backend, os.Getenv("DATABASE_DSN"))
+	if err != nil {
+		log.Fatalf("error opening the %s database, is the binary built with the %s tag? %v", backend, backend, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	migrations, err := handler.Migrate(ctx)
+	if err != nil {
+		log.Fatalf("error migrating the %s database: %v", backend, err)
+	}
+
+	for _, m := range migrations {
+		logger.WithFields(adapterlogger.Fields{"version": m.Version, "name": m.Name}).Infof("Migration applied")
+	}
+
+	return handler
+}
+
+// databaseBackend reads DATABASE_BACKEND, mongodb by default
+func databaseBackend() string {
+	return backend("DATABASE_BACKEND", mongoBackend, postgresBackend, sqliteBackend, memoryBackend)
+}
+
+// queueBackend reads QUEUE_BACKEND, rabbitmq by default
+func queueBackend() string {
+	return backend("QUEUE_BACKEND", rabbitMQBackend, memoryBackend)
+}
+
+// backend reads the variable, the first of the accepted values is the default one
+func backend(name string, accepted ...string) string {
+	v := os.Getenv(name)
+	if v == "" {
+		return accepted[0]
+	}
+
+	for _, a := range accepted {
+		if v == a {
+			return v
+		}
+	}
+
+	log.Fatalf("unknown %s %q, want one of %s", name, v, strings.Join(accepted, ", "))
+	return ""
+}
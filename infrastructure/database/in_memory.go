@@ -2,45 +2,151 @@ package database
 
 import (
 	"context"
+	"sync"
 
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
-	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/pkg/errors"
 )
 
-type UserInMen struct {
-	users []*entity.User
-}
+var errDuplicateKey = errors.New("duplicate key")
 
-func (u *UserInMen) Create(_ context.Context, user entity.User) (entity.User, error) {
-	u.users = append(u.users, &user)
+type (
+	// InMemory defines a store keeping the data in the memory of the process, it stands in for MongoDB when the
+	// server runs without external services and the data is lost on restart. It is safe for concurrent use: the reads
+	// see the committed data, the writes are serialized and a transaction works on a copy committed when it succeeds
+	InMemory struct {
+		mu     sync.RWMutex
+		writer sync.Mutex
+		data   memoryData
+	}
 
-	return user, nil
+	// memoryData holds the records by ID, the stored entities are copies never modified in place so copying
+	// the maps is enough to take a snapshot
+	memoryData struct {
+		users         map[string]entity.User
+		transfers     map[string]entity.Transfer
+		entries       []entity.JournalEntry
+		keys          map[idempotencyKeyID]entity.IdempotencyKey
+		outbox        map[string]entity.OutboxMessage
+		refreshTokens map[string]entity.RefreshToken
+	}
+
+	// memoryTx is the copy of the data a transaction works on
+	memoryTx struct {
+		store *InMemory
+		mu    sync.Mutex
+		data  memoryData
+	}
+
+	memoryTxKey struct{}
+)
+
+// NewInMemory creates new empty InMemory
+func NewInMemory() *InMemory {
+	return &InMemory{data: newMemoryData()}
 }
 
-func (u *UserInMen) FindByID(_ context.Context, ID vo.Uuid) (entity.User, error)  {
-	for _, user := range u.users {
-		if user.ID() == ID {
-			return *user, nil
-		}
+func newMemoryData() memoryData {
+	return memoryData{
+		users:         make(map[string]entity.User),
+		transfers:     make(map[string]entity.Transfer),
+		keys:          make(map[idempotencyKeyID]entity.IdempotencyKey),
+		outbox:        make(map[string]entity.OutboxMessage),
+		refreshTokens: make(map[string]entity.RefreshToken),
 	}
-	return entity.User{}, nil
 }
 
-func (u *UserInMen) UpdateWallet(_ context.Context, ID vo.Uuid, money vo.Money) error {
-	for _, user := range u.users {
-		if user.ID() == ID {
-			user.Wallet().NewMoney(vo.NewMoneyNGN(money.Amount()))
-		}
+// WithTransaction runs fn inside a transaction, every repository of the store called with the context of fn joins it.
+// fn sees its own writes, the other callers only see them once fn succeeds and nothing of them when it fails.
+// The transactions run one at a time, a transaction started inside another one joins it
+func (m *InMemory) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := m.tx(ctx); ok {
+		return fn(ctx)
+	}
+
+	m.writer.Lock()
+	defer m.writer.Unlock()
+
+	m.mu.RLock()
+	tx := &memoryTx{store: m, data: m.data.clone()}
+	m.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, tx)); err != nil {
+		return err
 	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	m.mu.Lock()
+	m.data = tx.data
+	m.mu.Unlock()
+
 	return nil
 }
 
-type TransferInMen struct {
-	Transfer []*entity.Transfer
+// tx returns the transaction of the store joined by ctx
+func (m *InMemory) tx(ctx context.Context) (*memoryTx, bool) {
+	tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx)
+	if !ok || tx.store != m {
+		return nil, false
+	}
+
+	return tx, true
+}
+
+// read runs fn on the data of the transaction joined by ctx or on the committed data
+func (m *InMemory) read(ctx context.Context, fn func(*memoryData) error) error {
+	if tx, ok := m.tx(ctx); ok {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+
+		return fn(&tx.data)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return fn(&m.data)
+}
+
+// write runs fn on the data of the transaction joined by ctx, outside of a transaction it waits for the running one
+// and commits at once. fn must check everything before changing the data, it is not rolled back when fn fails
+func (m *InMemory) write(ctx context.Context, fn func(*memoryData) error) error {
+	if tx, ok := m.tx(ctx); ok {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+
+		return fn(&tx.data)
+	}
+
+	m.writer.Lock()
+	defer m.writer.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return fn(&m.data)
 }
 
-func (t *TransferInMen) Create(_ context.Context, transfer entity.Transfer) (entity.Transfer, error) {
-	t.Transfer = append(t.Transfer, &transfer)
+func (d memoryData) clone() memoryData {
+	c := newMemoryData()
+	for k, v := range d.users {
+		c.users[k] = v
+	}
+	for k, v := range d.transfers {
+		c.transfers[k] = v
+	}
+	c.entries = append(c.entries, d.entries...)
+	for k, v := range d.keys {
+		c.keys[k] = v
+	}
+	for k, v := range d.outbox {
+		c.outbox[k] = v
+	}
+	for k, v := range d.refreshTokens {
+		c.refreshTokens[k] = v
+	}
 
-	return transfer, nil
-}
\ No newline at end of file
+	return c
+}
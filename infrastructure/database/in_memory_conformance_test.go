@@ -0,0 +1,36 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository/repositorytest"
+)
+
+func TestInMemory_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		var (
+			store     = NewInMemory()
+			users     = NewUserInMen(store)
+			transfers = NewTransferInMen(store)
+			keys      = NewIdempotencyInMen(store)
+			ledger    = NewLedgerInMen(store)
+			outbox    = NewOutboxInMen(store)
+		)
+
+		return repositorytest.Repositories{
+			UserCreator:        users,
+			UserFinder:         users,
+			UserUpdater:        users,
+			TransferCreator:    transfers,
+			TransferFinder:     transfers,
+			TransferUpdater:    transfers,
+			TransferAggregator: transfers,
+			IdempotencyCreator: keys,
+			IdempotencyFinder:  keys,
+			LedgerCreator:      ledger,
+			OutboxCreator:      outbox,
+			OutboxFinder:       outbox,
+			OutboxUpdater:      outbox,
+		}
+	})
+}
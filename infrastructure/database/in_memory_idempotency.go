@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type (
+	// IdempotencyInMen defines the idempotency key repositories of an InMemory store
+	IdempotencyInMen struct {
+		store *InMemory
+	}
+
+	// idempotencyKeyID identifies a key, a key is unique for its payer only
+	idempotencyKeyID struct {
+		payer string
+		key   string
+	}
+)
+
+// NewIdempotencyInMen creates new IdempotencyInMen with its dependencies
+func NewIdempotencyInMen(store *InMemory) IdempotencyInMen {
+	return IdempotencyInMen{store: store}
+}
+
+// Create stores the key, a key already stored for the payer is rejected with ErrIdempotencyKeyInUse
+func (i IdempotencyInMen) Create(ctx context.Context, key entity.IdempotencyKey) error {
+	var ID = idempotencyKeyID{payer: key.Payer().Value(), key: key.Key()}
+
+	return i.store.write(ctx, func(d *memoryData) error {
+		if _, ok := d.keys[ID]; ok {
+			return entity.ErrIdempotencyKeyInUse
+		}
+
+		d.keys[ID] = entity.NewIdempotencyKey(
+			key.Key(),
+			key.Fingerprint(),
+			cloneTransfer(key.Transfer()),
+			key.CreatedAt(),
+		)
+		return nil
+	})
+}
+
+// FindByKey returns the key stored for the payer
+func (i IdempotencyInMen) FindByKey(ctx context.Context, payer vo.Uuid, key string) (entity.IdempotencyKey, error) {
+	var result entity.IdempotencyKey
+
+	err := i.store.read(ctx, func(d *memoryData) error {
+		k, ok := d.keys[idempotencyKeyID{payer: payer.Value(), key: key}]
+		if !ok {
+			return entity.ErrNotFoundIdempotencyKey
+		}
+
+		result = entity.NewIdempotencyKey(k.Key(), k.Fingerprint(), cloneTransfer(k.Transfer()), k.CreatedAt())
+		return nil
+	})
+	if err != nil {
+		return entity.IdempotencyKey{}, err
+	}
+
+	return result, nil
+}
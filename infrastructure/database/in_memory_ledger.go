@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// LedgerInMen defines the ledger repositories of an InMemory store
+type LedgerInMen struct {
+	store *InMemory
+}
+
+// NewLedgerInMen creates new LedgerInMen with its dependencies
+func NewLedgerInMen(store *InMemory) LedgerInMen {
+	return LedgerInMen{store: store}
+}
+
+// Create appends the journal entry to the ledger
+func (l LedgerInMen) Create(ctx context.Context, entry entity.JournalEntry) (entity.JournalEntry, error) {
+	_ = l.store.write(ctx, func(d *memoryData) error {
+		d.entries = append(d.entries, entry)
+		return nil
+	})
+
+	return entry, nil
+}
+
+// FindPostingsByAccount returns the postings of the account in the order they were recorded
+func (l LedgerInMen) FindPostingsByAccount(ctx context.Context, account vo.Uuid) ([]entity.Posting, error) {
+	var postings []entity.Posting
+
+	_ = l.store.read(ctx, func(d *memoryData) error {
+		for _, entry := range d.entries {
+			for _, p := range entry.Postings() {
+				if p.Account() == account {
+					postings = append(postings, p)
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return postings, nil
+}
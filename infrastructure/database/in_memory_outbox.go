@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/pkg/errors"
+)
+
+// OutboxInMen defines the outbox repositories of an InMemory store
+type OutboxInMen struct {
+	store *InMemory
+}
+
+// NewOutboxInMen creates new OutboxInMen with its dependencies
+func NewOutboxInMen(store *InMemory) OutboxInMen {
+	return OutboxInMen{store: store}
+}
+
+// Create stores a copy of the message, the ID is unique. Called with the context of a transaction it joins it
+func (o OutboxInMen) Create(ctx context.Context, m entity.OutboxMessage) error {
+	return o.store.write(ctx, func(d *memoryData) error {
+		if _, ok := d.outbox[m.ID().Value()]; ok {
+			return errors.Wrap(errDuplicateKey, entity.ErrCreateOutboxMessage.Error())
+		}
+
+		d.outbox[m.ID().Value()] = cloneOutboxMessage(m, m.PublishedAt(), m.ProcessedAt())
+		return nil
+	})
+}
+
+// FindPending returns the messages not published yet, ordered from the oldest
+func (o OutboxInMen) FindPending(ctx context.Context, limit int) ([]entity.OutboxMessage, error) {
+	var messages = make([]entity.OutboxMessage, 0)
+
+	_ = o.store.read(ctx, func(d *memoryData) error {
+		for _, m := range d.outbox {
+			if !m.Published() {
+				messages = append(messages, cloneOutboxMessage(m, nil, m.ProcessedAt()))
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(messages, func(i, j int) bool {
+		if !messages[i].CreatedAt().Equal(messages[j].CreatedAt()) {
+			return messages[i].CreatedAt().Before(messages[j].CreatedAt())
+		}
+
+		return messages[i].ID().Value() < messages[j].ID().Value()
+	})
+
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	return messages, nil
+}
+
+// FindByID returns a copy of the message
+func (o OutboxInMen) FindByID(ctx context.Context, ID vo.Uuid) (entity.OutboxMessage, error) {
+	var message entity.OutboxMessage
+
+	err := o.store.read(ctx, func(d *memoryData) error {
+		m, ok := d.outbox[ID.Value()]
+		if !ok {
+			return entity.ErrNotFoundOutboxMessage
+		}
+
+		message = cloneOutboxMessage(m, m.PublishedAt(), m.ProcessedAt())
+		return nil
+	})
+
+	return message, err
+}
+
+// MarkPublished sets the publication date of the message
+func (o OutboxInMen) MarkPublished(ctx context.Context, ID vo.Uuid, publishedAt time.Time) error {
+	return o.store.write(ctx, func(d *memoryData) error {
+		m, ok := d.outbox[ID.Value()]
+		if !ok {
+			return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+		}
+
+		d.outbox[ID.Value()] = cloneOutboxMessage(m, &publishedAt, m.ProcessedAt())
+		return nil
+	})
+}
+
+// MarkProcessed sets the processing date of the message, a message already processed is left untouched
+func (o OutboxInMen) MarkProcessed(ctx context.Context, ID vo.Uuid, processedAt time.Time) error {
+	return o.store.write(ctx, func(d *memoryData) error {
+		m, ok := d.outbox[ID.Value()]
+		if !ok {
+			return errors.Wrap(entity.ErrNotFoundOutboxMessage, entity.ErrUpdateOutboxMessage.Error())
+		}
+
+		if m.Processed() {
+			return entity.ErrOutboxMessageProcessed
+		}
+
+		d.outbox[ID.Value()] = cloneOutboxMessage(m, m.PublishedAt(), &processedAt)
+		return nil
+	})
+}
+
+// cloneOutboxMessage copies the message and its payload with other publication and processing dates
+func cloneOutboxMessage(m entity.OutboxMessage, publishedAt *time.Time, processedAt *time.Time) entity.OutboxMessage {
+	return entity.NewPublishedOutboxMessage(
+		m.ID(),
+		m.AggregateID(),
+		m.Kind(),
+		append([]byte(nil), m.Payload()...),
+		m.CreatedAt(),
+		copyTime(publishedAt),
+		copyTime(processedAt),
+	)
+}
+
+// copyTime keeps the stored dates from being shared with the callers
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	at := *t
+	return &at
+}
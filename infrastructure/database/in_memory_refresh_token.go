@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/pkg/errors"
+)
+
+// RefreshTokenInMen defines the refresh token repositories of an InMemory store
+type RefreshTokenInMen struct {
+	store *InMemory
+}
+
+// NewRefreshTokenInMen creates new RefreshTokenInMen with its dependencies
+func NewRefreshTokenInMen(store *InMemory) RefreshTokenInMen {
+	return RefreshTokenInMen{store: store}
+}
+
+// Create stores the token, the ID and the hash are unique
+func (r RefreshTokenInMen) Create(ctx context.Context, token entity.RefreshToken) error {
+	return r.store.write(ctx, func(d *memoryData) error {
+		for ID, t := range d.refreshTokens {
+			if ID == token.ID().Value() || t.Hash() == token.Hash() {
+				return errors.Wrap(errDuplicateKey, entity.ErrCreateRefreshToken.Error())
+			}
+		}
+
+		d.refreshTokens[token.ID().Value()] = cloneRefreshToken(token, token.RevokedAt())
+		return nil
+	})
+}
+
+// FindByHash returns the token, an unknown token is reported as an invalid one
+func (r RefreshTokenInMen) FindByHash(ctx context.Context, hash string) (entity.RefreshToken, error) {
+	var result entity.RefreshToken
+
+	err := r.store.read(ctx, func(d *memoryData) error {
+		for _, t := range d.refreshTokens {
+			if t.Hash() == hash {
+				result = cloneRefreshToken(t, t.RevokedAt())
+				return nil
+			}
+		}
+
+		return entity.ErrInvalidRefreshToken
+	})
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return result, nil
+}
+
+// Revoke sets the revocation date of the token, a token already revoked is reported as an invalid one
+func (r RefreshTokenInMen) Revoke(ctx context.Context, ID vo.Uuid, revokedAt time.Time) error {
+	return r.store.write(ctx, func(d *memoryData) error {
+		t, ok := d.refreshTokens[ID.Value()]
+		if !ok || t.RevokedAt() != nil {
+			return entity.ErrInvalidRefreshToken
+		}
+
+		d.refreshTokens[ID.Value()] = cloneRefreshToken(t, &revokedAt)
+		return nil
+	})
+}
+
+// cloneRefreshToken copies the token with another revocation date
+func cloneRefreshToken(t entity.RefreshToken, revokedAt *time.Time) entity.RefreshToken {
+	if revokedAt != nil {
+		at := *revokedAt
+		revokedAt = &at
+	}
+
+	return entity.NewRefreshTokenFromHash(t.ID(), t.UserID(), t.Hash(), t.ExpiresAt(), revokedAt, t.CreatedAt())
+}
@@ -0,0 +1,286 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func newTestUser(ID vo.Uuid, amount int64) entity.User {
+	return entity.NewCommonUser(
+		ID,
+		vo.NewFullName("Test testing"),
+		vo.NewEmailTest("test@testing.com"),
+		vo.NewHashedPasswordTest("$2a$10$passw"),
+		vo.NewDocumentTest(vo.CPF, "070.910.584-24"),
+		vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(amount))),
+		time.Now(),
+	)
+}
+
+func TestUserInMen_FindByID(t *testing.T) {
+	var (
+		users = NewUserInMen(NewInMemory())
+		ID    = vo.NewUuidStaticTest()
+	)
+
+	if _, err := users.FindByID(context.Background(), ID); !errors.Is(err, entity.ErrNotFoundUser) {
+		t.Errorf("[TestCase 'Not found user'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundUser)
+	}
+
+	if _, err := users.Create(context.Background(), newTestUser(ID, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := users.Create(context.Background(), newTestUser(ID, 100)); !errors.Is(err, entity.ErrUserAlreadyExists) {
+		t.Errorf("[TestCase 'Duplicate user'] Err: '%v' | WantErr: '%v'", err, entity.ErrUserAlreadyExists)
+	}
+
+	if _, err := users.Create(context.Background(), newTestUser(vo.NewUuidRandom(), 100)); !errors.Is(err, entity.ErrUserAlreadyExists) {
+		t.Errorf("[TestCase 'Duplicate email and document'] Err: '%v' | WantErr: '%v'", err, entity.ErrUserAlreadyExists)
+	}
+
+	user, err := users.FindByID(context.Background(), ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the wallet of the user returned is a copy, changing it does not change the stored user
+	if _, err := user.Wallet().Add(vo.NewMoneyNGN(vo.NewAmountTest(50))); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, _ := users.FindByID(context.Background(), ID)
+	if got := stored.Wallet().Money().Amount().Value(); got != 100 {
+		t.Errorf("[TestCase 'Stored user is a copy'] Got: '%v' | Want: '%v'", got, 100)
+	}
+}
+
+func TestInMemory_WithTransaction(t *testing.T) {
+	var errRollback = errors.New("rollback")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAmount int64
+		wantFound  bool
+	}{
+		{
+			name:       "Commit",
+			wantAmount: 40,
+			wantFound:  true,
+		},
+		{
+			name:       "Rollback",
+			err:        errRollback,
+			wantAmount: 100,
+			wantFound:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				store     = NewInMemory()
+				users     = NewUserInMen(store)
+				transfers = NewTransferInMen(store)
+				payer     = vo.NewUuidStaticTest()
+				transfer  = entity.NewTransfer(
+					vo.NewUuidRandom(),
+					payer,
+					vo.NewUuidRandom(),
+					vo.NewMoneyNGN(vo.NewAmountTest(60)),
+					time.Now(),
+				)
+			)
+
+			if _, err := users.Create(context.Background(), newTestUser(payer, 100)); err != nil {
+				t.Fatal(err)
+			}
+
+			err := transfers.WithTransaction(context.Background(), func(ctx context.Context) error {
+				if err := users.UpdateWallet(ctx, payer, vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(40)))); err != nil {
+					return err
+				}
+
+				if _, err := transfers.Create(ctx, transfer); err != nil {
+					return err
+				}
+
+				// the transaction sees its own writes, the other callers do not until it commits
+				if user, _ := users.FindByID(ctx, payer); user.Wallet().Money().Amount().Value() != 40 {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, user.Wallet().Money().Amount().Value(), 40)
+				}
+				if _, err := transfers.FindByID(context.Background(), transfer.ID()); !errors.Is(err, entity.ErrNotFoundTransfer) {
+					t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, entity.ErrNotFoundTransfer)
+				}
+
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.err)
+			}
+
+			user, _ := users.FindByID(context.Background(), payer)
+			if got := user.Wallet().Money().Amount().Value(); got != tt.wantAmount {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantAmount)
+			}
+
+			_, err = transfers.FindByID(context.Background(), transfer.ID())
+			if got := err == nil; got != tt.wantFound {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestInMemory_WithTransactionConcurrent(t *testing.T) {
+	var (
+		store = NewInMemory()
+		users = NewUserInMen(store)
+		ID    = vo.NewUuidStaticTest()
+		wg    sync.WaitGroup
+	)
+
+	if _, err := users.Create(context.Background(), newTestUser(ID, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// every transaction reads the wallet and writes it back, none of the deposits is lost
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = users.WithTransaction(context.Background(), func(ctx context.Context) error {
+				user, err := users.FindByID(ctx, ID)
+				if err != nil {
+					return err
+				}
+
+				if _, err := user.Wallet().Add(vo.NewMoneyNGN(vo.NewAmountTest(1))); err != nil {
+					return err
+				}
+
+				return users.UpdateWallet(ctx, ID, user.Wallet())
+			})
+		}()
+	}
+	wg.Wait()
+
+	user, _ := users.FindByID(context.Background(), ID)
+	if got := user.Wallet().Money().Amount().Value(); got != 50 {
+		t.Errorf("[TestCase 'Concurrent deposits'] Got: '%v' | Want: '%v'", got, 50)
+	}
+}
+
+func TestTransferInMen_FindByUser(t *testing.T) {
+	var (
+		transfers = NewTransferInMen(NewInMemory())
+		user      = vo.NewUuidStaticTest()
+		now       = time.Now()
+		created   []entity.Transfer
+	)
+
+	for i := 0; i < 3; i++ {
+		transfer := entity.NewTransfer(
+			vo.NewUuidRandom(),
+			user,
+			vo.NewUuidRandom(),
+			vo.NewMoneyNGN(vo.NewAmountTest(int64(10*(i+1)))),
+			now.Add(time.Duration(i)*time.Minute),
+		)
+		if _, err := transfers.Create(context.Background(), transfer); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, transfer)
+	}
+
+	tests := []struct {
+		name   string
+		filter entity.TransferFilter
+		want   []vo.Uuid
+	}{
+		{
+			name:   "Newest first",
+			filter: entity.TransferFilter{UserID: user},
+			want:   []vo.Uuid{created[2].ID(), created[1].ID(), created[0].ID()},
+		},
+		{
+			name:   "Limit",
+			filter: entity.TransferFilter{UserID: user, Limit: 1},
+			want:   []vo.Uuid{created[2].ID()},
+		},
+		{
+			name: "After cursor",
+			filter: entity.TransferFilter{
+				UserID: user,
+				After:  &entity.TransferCursor{CreatedAt: created[2].CreatedAt(), ID: created[2].ID()},
+			},
+			want: []vo.Uuid{created[1].ID(), created[0].ID()},
+		},
+		{
+			name:   "Received only",
+			filter: entity.TransferFilter{UserID: user, Direction: entity.TransferReceived},
+			want:   []vo.Uuid{},
+		},
+		{
+			name:   "Minimum value",
+			filter: entity.TransferFilter{UserID: user, MinValue: vo.NewAmountTest(20)},
+			want:   []vo.Uuid{created[2].ID(), created[1].ID()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := transfers.FindByUser(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got = make([]vo.Uuid, 0)
+			for _, transfer := range result {
+				got = append(got, transfer.ID())
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxInMen(t *testing.T) {
+	var (
+		outbox  = NewOutboxInMen(NewInMemory())
+		now     = time.Now()
+		message = entity.NewOutboxMessage(vo.NewUuidRandom(), vo.NewUuidRandom(), "transfer", []byte(`{}`), now)
+	)
+
+	if err := outbox.Create(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+
+	if pending, _ := outbox.FindPending(context.Background(), 10); len(pending) != 1 {
+		t.Errorf("[TestCase 'Pending message'] Got: '%v' | Want: '%v'", len(pending), 1)
+	}
+
+	if err := outbox.MarkPublished(context.Background(), message.ID(), now); err != nil {
+		t.Fatal(err)
+	}
+
+	if pending, _ := outbox.FindPending(context.Background(), 10); len(pending) != 0 {
+		t.Errorf("[TestCase 'Published message'] Got: '%v' | Want: '%v'", len(pending), 0)
+	}
+
+	if err := outbox.MarkPublished(context.Background(), vo.NewUuidRandom(), now); !errors.Is(err, entity.ErrNotFoundOutboxMessage) {
+		t.Errorf("[TestCase 'Not found message'] Err: '%v' | WantErr: '%v'", err, entity.ErrNotFoundOutboxMessage)
+	}
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/pkg/errors"
+)
+
+// TransferInMen defines the transfer repositories of an InMemory store
+type TransferInMen struct {
+	store *InMemory
+}
+
+// NewTransferInMen creates new TransferInMen with its dependencies
+func NewTransferInMen(store *InMemory) TransferInMen {
+	return TransferInMen{store: store}
+}
+
+// Create stores a copy of the transfer, the ID is unique
+func (t TransferInMen) Create(ctx context.Context, transfer entity.Transfer) (entity.Transfer, error) {
+	err := t.store.write(ctx, func(d *memoryData) error {
+		if _, ok := d.transfers[transfer.ID().Value()]; ok {
+			return errors.Wrap(errDuplicateKey, entity.ErrCreateTransfer.Error())
+		}
+
+		d.transfers[transfer.ID().Value()] = cloneTransfer(transfer)
+		return nil
+	})
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+// WithTransaction runs fn inside a transaction of the store
+func (t TransferInMen) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return t.store.WithTransaction(ctx, fn)
+}
+
+// Update persists the status, its transition history and the refunded value of the transfer
+func (t TransferInMen) Update(ctx context.Context, transfer entity.Transfer) error {
+	return t.store.write(ctx, func(d *memoryData) error {
+		stored, ok := d.transfers[transfer.ID().Value()]
+		if !ok {
+			return errors.Wrap(entity.ErrNotFoundTransfer, entity.ErrUpdateTransfer.Error())
+		}
+
+		d.transfers[transfer.ID().Value()] = stored.
+			WithRefunded(transfer.Refunded()).
+			WithStatus(transfer.Status(), transfer.History())
+		return nil
+	})
+}
+
+// FindByID returns a copy of the transfer
+func (t TransferInMen) FindByID(ctx context.Context, ID vo.Uuid) (entity.Transfer, error) {
+	var result entity.Transfer
+
+	err := t.store.read(ctx, func(d *memoryData) error {
+		transfer, ok := d.transfers[ID.Value()]
+		if !ok {
+			return entity.ErrNotFoundTransfer
+		}
+
+		result = cloneTransfer(transfer)
+		return nil
+	})
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	return result, nil
+}
+
+// FindByUser returns the transfers matching the filter, ordered from the newest transfer and paginated by the cursor
+func (t TransferInMen) FindByUser(ctx context.Context, filter entity.TransferFilter) ([]entity.Transfer, error) {
+	var transfers = make([]entity.Transfer, 0)
+
+	_ = t.store.read(ctx, func(d *memoryData) error {
+		for _, transfer := range d.transfers {
+			if matchTransfer(transfer, filter) {
+				transfers = append(transfers, cloneTransfer(transfer))
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(transfers, func(i, j int) bool {
+		if !transfers[i].CreatedAt().Equal(transfers[j].CreatedAt()) {
+			return transfers[i].CreatedAt().After(transfers[j].CreatedAt())
+		}
+
+		return transfers[i].ID().Value() > transfers[j].ID().Value()
+	})
+
+	if filter.Limit > 0 && len(transfers) > filter.Limit {
+		transfers = transfers[:filter.Limit]
+	}
+
+	return transfers, nil
+}
+
+// SumSent counts the transfers completed or reversed by the payer since the date and sums their value net of refunds.
+// The reversals, moving value back to the payer, are not counted
+func (t TransferInMen) SumSent(
+	ctx context.Context,
+	payer vo.Uuid,
+	currency vo.Currency,
+	since time.Time,
+) (entity.TransferTotal, error) {
+	var total entity.TransferTotal
+
+	_ = t.store.read(ctx, func(d *memoryData) error {
+		for _, transfer := range d.transfers {
+			if transfer.Payer() != payer ||
+				transfer.Value().Currency() != currency ||
+				transfer.CreatedAt().Before(since) ||
+				(transfer.Status() != vo.COMPLETED && transfer.Status() != vo.REVERSED) ||
+				transfer.ReversalOf().Value() != "" {
+				continue
+			}
+
+			total.Count++
+			total.Amount += transfer.Value().Amount().Value() - transfer.Refunded().Amount().Value()
+		}
+
+		return nil
+	})
+
+	return total, nil
+}
+
+func matchTransfer(t entity.Transfer, filter entity.TransferFilter) bool {
+	switch filter.Direction {
+	case entity.TransferSent:
+		if t.Payer() != filter.UserID {
+			return false
+		}
+	case entity.TransferReceived:
+		if t.Payee() != filter.UserID {
+			return false
+		}
+	default:
+		if t.Payer() != filter.UserID && t.Payee() != filter.UserID {
+			return false
+		}
+	}
+
+	if !filter.From.IsZero() && t.CreatedAt().Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !t.CreatedAt().Before(filter.To) {
+		return false
+	}
+
+	var value = t.Value().Amount().Value()
+	if filter.MinValue.Value() > 0 && value < filter.MinValue.Value() {
+		return false
+	}
+	if filter.MaxValue.Value() > 0 && value > filter.MaxValue.Value() {
+		return false
+	}
+
+	if filter.Status != "" && t.Status() != filter.Status {
+		return false
+	}
+
+	if filter.After != nil {
+		if t.CreatedAt().After(filter.After.CreatedAt) {
+			return false
+		}
+		if t.CreatedAt().Equal(filter.After.CreatedAt) && t.ID().Value() >= filter.After.ID.Value() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cloneTransfer copies the transfer without its events
+func cloneTransfer(t entity.Transfer) entity.Transfer {
+	return entity.NewTransfer(t.ID(), t.Payer(), t.Payee(), t.Value(), t.CreatedAt()).
+		WithConversion(t.Credit(), t.Rate()).
+		WithRefunded(t.Refunded()).
+		WithReversalOf(t.ReversalOf()).
+		WithStatus(t.Status(), t.History())
+}
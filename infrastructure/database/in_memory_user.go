@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+	"github.com/pkg/errors"
+)
+
+// UserInMen defines the user repositories of an InMemory store
+type UserInMen struct {
+	store *InMemory
+}
+
+// NewUserInMen creates new UserInMen with its dependencies
+func NewUserInMen(store *InMemory) UserInMen {
+	return UserInMen{store: store}
+}
+
+// Create stores a copy of the user, the ID, the email and the document are unique
+func (u UserInMen) Create(ctx context.Context, user entity.User) (entity.User, error) {
+	stored, err := cloneUser(user)
+	if err != nil {
+		return entity.User{}, errors.Wrap(err, entity.ErrCreateUser.Error())
+	}
+
+	err = u.store.write(ctx, func(d *memoryData) error {
+		for _, other := range d.users {
+			if other.ID() == user.ID() || other.Email() == user.Email() || sameDocument(other.Document(), user.Document()) {
+				return errors.Wrap(entity.ErrUserAlreadyExists, entity.ErrCreateUser.Error())
+			}
+		}
+
+		d.users[user.ID().Value()] = stored
+		return nil
+	})
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// WithTransaction runs fn inside a transaction of the store
+func (u UserInMen) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return u.store.WithTransaction(ctx, fn)
+}
+
+// FindByID returns a copy of the user
+func (u UserInMen) FindByID(ctx context.Context, ID vo.Uuid) (entity.User, error) {
+	return u.findOne(ctx, func(user entity.User) bool {
+		return user.ID() == ID
+	})
+}
+
+// FindByEmail returns a copy of the user
+func (u UserInMen) FindByEmail(ctx context.Context, email vo.Email) (entity.User, error) {
+	return u.findOne(ctx, func(user entity.User) bool {
+		return user.Email() == email
+	})
+}
+
+func (u UserInMen) findOne(ctx context.Context, match func(entity.User) bool) (entity.User, error) {
+	var result entity.User
+
+	err := u.store.read(ctx, func(d *memoryData) error {
+		for _, user := range d.users {
+			if match(user) {
+				var err error
+				result, err = cloneUser(user)
+				return err
+			}
+		}
+
+		return entity.ErrNotFoundUser
+	})
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return result, nil
+}
+
+// UpdateWallet sets the amount of the wallet of the user if it is still at the version it was read at
+func (u UserInMen) UpdateWallet(ctx context.Context, ID vo.Uuid, wallet *vo.Wallet) error {
+	return u.store.write(ctx, func(d *memoryData) error {
+		user, ok := d.users[ID.Value()]
+		if !ok {
+			return errors.Wrap(entity.ErrNotFoundUser, entity.ErrUpdateUserWallet.Error())
+		}
+
+		if user.Wallet().Version() != wallet.Version() {
+			return entity.ErrConcurrentModification
+		}
+
+		var money = vo.NewMoney(user.Wallet().Money().Currency(), wallet.Money().Amount())
+		updated, err := newUser(user, vo.NewVersionedWallet(money, wallet.Version()+1))
+		if err != nil {
+			return errors.Wrap(err, entity.ErrUpdateUserWallet.Error())
+		}
+
+		d.users[ID.Value()] = updated
+		return nil
+	})
+}
+
+// UpdatePassword sets the password of the user
+func (u UserInMen) UpdatePassword(ctx context.Context, ID vo.Uuid, password vo.HashedPassword) error {
+	return u.store.write(ctx, func(d *memoryData) error {
+		user, ok := d.users[ID.Value()]
+		if !ok {
+			return errors.Wrap(entity.ErrNotFoundUser, entity.ErrUpdateUserPassword.Error())
+		}
+
+		d.users[ID.Value()] = user.WithPassword(password)
+		return nil
+	})
+}
+
+// sameDocument tells whether both documents are of the same type and value
+func sameDocument(a, b vo.Document) bool {
+	return a.Type() == b.Type() && a.Value() == b.Value()
+}
+
+// cloneUser copies the user with its own wallet and without its events
+func cloneUser(user entity.User) (entity.User, error) {
+	return newUser(user, vo.NewVersionedWallet(user.Wallet().Money(), user.Wallet().Version()))
+}
+
+func newUser(user entity.User, wallet *vo.Wallet) (entity.User, error) {
+	u, err := entity.NewUser(
+		user.ID(),
+		user.FullName(),
+		user.Email(),
+		user.Password(),
+		user.Document(),
+		wallet,
+		user.TypeUser(),
+		user.CreatedAt(),
+	)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return u.WithKYCTier(user.KYCTier()), nil
+}
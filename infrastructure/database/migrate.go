@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errInvalidMigrationName = errors.New("invalid migration name, want <version>_<name>.sql")
+	errDuplicateMigration   = errors.New("duplicate migration version")
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migration is a versioned change of the schema, read from a file named <version>_<name>.sql. Step, when set, runs
+// after the SQL in the same transaction, for the data changes the SQL dialects do not share
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+	Step    func(context.Context, *sql.Tx) error
+}
+
+// LoadMigrations reads the .sql files of fsys, in any directory, ordered by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	var migrations []Migration
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".sql" {
+			return err
+		}
+
+		name := strings.TrimSuffix(path.Base(p), ".sql")
+		i := strings.Index(name, "_")
+		if i <= 0 || i == len(name)-1 {
+			return errors.Wrap(errInvalidMigrationName, p)
+		}
+
+		version, err := strconv.Atoi(name[:i])
+		if err != nil || version <= 0 {
+			return errors.Wrap(errInvalidMigrationName, p)
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: name[i+1:], SQL: string(content)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, errors.Wrap(errDuplicateMigration, strconv.Itoa(migrations[i].Version))
+		}
+	}
+
+	return migrations, nil
+}
+
+// Migrate applies in order the migrations whose version is not recorded in schema_migrations yet, each one in
+// its own transaction with the record of its version, and returns them. Migrations applied concurrently by
+// another process make it fail on the version already recorded, the schema is left consistent
+func Migrate(ctx context.Context, db *sql.DB, migrations []Migration) ([]Migration, error) {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    BIGINT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`); err != nil {
+		return nil, errors.Wrap(err, "error creating schema_migrations")
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Migration
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		if err := apply(ctx, db, m); err != nil {
+			return result, errors.Wrap(err, fmt.Sprintf("error applying migration %d_%s", m.Version, m.Name))
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading schema_migrations")
+	}
+	defer rows.Close()
+
+	var applied = make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, errors.Wrap(err, "error reading schema_migrations")
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if m.Step != nil {
+		if err := m.Step(ctx, tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+		m.Version,
+		m.Name,
+		time.Now().UTC(),
+	); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		fsys         fstest.MapFS
+		wantVersions []int
+		wantErr      error
+	}{
+		{
+			name: "Ordered by version",
+			fsys: fstest.MapFS{
+				"migrations/0010_add_index.sql":    {Data: []byte("CREATE INDEX")},
+				"migrations/0002_create_table.sql": {Data: []byte("CREATE TABLE")},
+				"migrations/README.md":             {Data: []byte("not a migration")},
+			},
+			wantVersions: []int{2, 10},
+		},
+		{
+			name: "Invalid name",
+			fsys: fstest.MapFS{
+				"migrations/create_table.sql": {Data: []byte("CREATE TABLE")},
+			},
+			wantErr: errInvalidMigrationName,
+		},
+		{
+			name: "Duplicate version",
+			fsys: fstest.MapFS{
+				"migrations/0001_create_table.sql": {Data: []byte("CREATE TABLE")},
+				"migrations/1_add_index.sql":       {Data: []byte("CREATE INDEX")},
+			},
+			wantErr: errDuplicateMigration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadMigrations(tt.fsys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.wantVersions) {
+				t.Fatalf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantVersions)
+			}
+			for i := range got {
+				if got[i].Version != tt.wantVersions[i] {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got[i].Version, tt.wantVersions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := LoadMigrations(embeddedMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the versions are contiguous, a gap is a migration lost in a merge
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("[TestCase 'Embedded migrations'] Got: '%v' | Want: '%v'", m.Version, i+1)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// migrationSteps are the steps of the embedded migrations by version
+var migrationSteps = map[int]func(context.Context, *sql.Tx) error{
+	8: backfillIdempotencyKeyPayers,
+}
+
+// backfillIdempotencyKeyPayers sets the payer of the keys copied without one, read from the JSON of their transfer
+func backfillIdempotencyKeyPayers(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT idempotency_key, transfer FROM idempotency_keys WHERE payer_id = ''`)
+	if err != nil {
+		return err
+	}
+
+	var payers = make(map[string]string)
+	for rows.Next() {
+		var (
+			key      string
+			transfer string
+			t        struct {
+				Payer string `json:"payer"`
+			}
+		)
+		if err := rows.Scan(&key, &transfer); err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		if err := json.Unmarshal([]byte(transfer), &t); err != nil || t.Payer == "" {
+			_ = rows.Close()
+			return errors.Errorf("error reading the payer of the idempotency key %q", key)
+		}
+		payers[key] = t.Payer
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	// the rows are closed before the updates, a connection runs a single statement at once
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for key, payer := range payers {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE idempotency_keys SET payer_id = $1 WHERE payer_id = '' AND idempotency_key = $2`,
+			payer,
+			key,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+CREATE TABLE users (
+    id              VARCHAR(36) PRIMARY KEY,
+    full_name       TEXT        NOT NULL,
+    email           TEXT        NOT NULL,
+    password        TEXT        NOT NULL,
+    document_type   VARCHAR(8)  NOT NULL,
+    document_value  TEXT        NOT NULL,
+    wallet_currency VARCHAR(3)  NOT NULL,
+    wallet_amount   BIGINT      NOT NULL,
+    type            VARCHAR(16) NOT NULL,
+    kyc_tier        VARCHAR(8)  NOT NULL,
+    created_at      TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX users_email ON users (email);
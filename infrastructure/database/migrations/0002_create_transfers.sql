@@ -0,0 +1,20 @@
+-- history holds the status transitions as a JSON array
+CREATE TABLE transfers (
+    id                VARCHAR(36) PRIMARY KEY,
+    payer             VARCHAR(36) NOT NULL,
+    payee             VARCHAR(36) NOT NULL,
+    value             BIGINT      NOT NULL,
+    currency          VARCHAR(3)  NOT NULL,
+    credited_value    BIGINT      NOT NULL,
+    credited_currency VARCHAR(3)  NOT NULL,
+    rate              TEXT        NOT NULL,
+    refunded          BIGINT      NOT NULL,
+    reversal_of       VARCHAR(36),
+    status            VARCHAR(16) NOT NULL,
+    history           TEXT        NOT NULL,
+    created_at        TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX transfers_payer_created_at ON transfers (payer, created_at DESC, id DESC);
+CREATE INDEX transfers_payee_created_at ON transfers (payee, created_at DESC, id DESC);
+CREATE INDEX transfers_reversal_of ON transfers (reversal_of);
@@ -0,0 +1,18 @@
+CREATE TABLE journal_entries (
+    id          VARCHAR(36) PRIMARY KEY,
+    transfer_id VARCHAR(36) NOT NULL,
+    description TEXT        NOT NULL,
+    created_at  TIMESTAMP   NOT NULL
+);
+
+CREATE TABLE postings (
+    entry_id  VARCHAR(36) NOT NULL REFERENCES journal_entries (id),
+    position  INTEGER     NOT NULL,
+    account   VARCHAR(36) NOT NULL,
+    direction VARCHAR(8)  NOT NULL,
+    currency  VARCHAR(3)  NOT NULL,
+    amount    BIGINT      NOT NULL,
+    PRIMARY KEY (entry_id, position)
+);
+
+CREATE INDEX postings_account ON postings (account);
@@ -0,0 +1,7 @@
+-- transfer holds the transfer returned to the first request as JSON, it is returned again to the retries
+CREATE TABLE idempotency_keys (
+    idempotency_key TEXT PRIMARY KEY,
+    fingerprint     TEXT      NOT NULL,
+    transfer        TEXT      NOT NULL,
+    created_at      TIMESTAMP NOT NULL
+);
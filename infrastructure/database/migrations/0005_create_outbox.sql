@@ -0,0 +1,10 @@
+CREATE TABLE outbox (
+    id           VARCHAR(36) PRIMARY KEY,
+    aggregate_id VARCHAR(36) NOT NULL,
+    kind         TEXT        NOT NULL,
+    payload      TEXT        NOT NULL,
+    created_at   TIMESTAMP   NOT NULL,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending ON outbox (published_at, created_at, id);
@@ -0,0 +1,8 @@
+CREATE TABLE refresh_tokens (
+    id         VARCHAR(36) PRIMARY KEY,
+    user_id    VARCHAR(36) NOT NULL,
+    hash       TEXT        NOT NULL UNIQUE,
+    expires_at TIMESTAMP   NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL
+);
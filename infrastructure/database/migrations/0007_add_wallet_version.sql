@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN wallet_version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,17 @@
+-- an idempotency key is unique for its payer only. The keys stored before are copied without a payer, the
+-- migration step of the version then reads the payer from the JSON of their transfer
+CREATE TABLE idempotency_keys_by_payer (
+    payer_id        VARCHAR(36) NOT NULL,
+    idempotency_key TEXT        NOT NULL,
+    fingerprint     TEXT        NOT NULL,
+    transfer        TEXT        NOT NULL,
+    created_at      TIMESTAMP   NOT NULL,
+    PRIMARY KEY (payer_id, idempotency_key)
+);
+
+INSERT INTO idempotency_keys_by_payer (payer_id, idempotency_key, fingerprint, transfer, created_at)
+SELECT '', idempotency_key, fingerprint, transfer, created_at FROM idempotency_keys;
+
+DROP TABLE idempotency_keys;
+
+ALTER TABLE idempotency_keys_by_payer RENAME TO idempotency_keys;
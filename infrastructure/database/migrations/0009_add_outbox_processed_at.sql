@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN processed_at TIMESTAMP;
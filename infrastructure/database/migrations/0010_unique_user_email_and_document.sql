@@ -0,0 +1,7 @@
+-- an email and a document belong to a single user, as the unique indexes of MongoDB enforce. Users already stored
+-- with the same email or document make the migration fail, they are merged by hand before it is applied again
+CREATE UNIQUE INDEX users_email_unique ON users (email);
+
+CREATE UNIQUE INDEX users_document_unique ON users (document_type, document_value);
+
+DROP INDEX users_email;
@@ -48,3 +48,13 @@ func (m *MongoHandler) Client() *mongo.Client {
 func (m *MongoHandler) Db() *mongo.Database {
 	return m.db
 }
+
+// EnsureIndexes creates the indexes of the collection, indexes that already exist are left untouched
+func (m *MongoHandler) EnsureIndexes(ctx context.Context, collection string, indexes []mongo.IndexModel) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	_, err := m.db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+	return err
+}
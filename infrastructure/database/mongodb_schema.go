@@ -0,0 +1,372 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyTimeLayout is the layout produced by time.Time.String, used by documents stored before dates were BSON dates
+const legacyTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+type (
+	// mongoCollection describes a collection of the schema. The validator is applied with the moderate level, the
+	// documents stored before it are not checked until they are valid. The legacy indexes are dropped
+	mongoCollection struct {
+		name          string
+		validator     bson.M
+		indexes       []mongo.IndexModel
+		legacyIndexes []string
+	}
+
+	// legacyDate is a date field stored by older releases as a time.Time.String value
+	legacyDate struct {
+		collection string
+		field      string
+	}
+)
+
+var (
+	mongoSchema = []mongoCollection{
+		{
+			name: "users",
+			validator: jsonSchema([]string{"id", "email", "document", "wallet", "created_at"}, bson.M{
+				"id":    bson.M{"bsonType": "string"},
+				"email": bson.M{"bsonType": "string"},
+				"document": bson.M{
+					"bsonType": "object",
+					"required": bson.A{"type", "value"},
+					"properties": bson.M{
+						"type":  bson.M{"bsonType": "string"},
+						"value": bson.M{"bsonType": "string"},
+					},
+				},
+				"wallet": bson.M{
+					"bsonType": "object",
+					"required": bson.A{"currency", "amount"},
+					"properties": bson.M{
+						"currency": bson.M{"bsonType": "string"},
+						"amount":   bson.M{"bsonType": bson.A{"int", "long"}},
+						"version":  bson.M{"bsonType": bson.A{"int", "long"}},
+					},
+				},
+				"created_at": bson.M{"bsonType": "date"},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "email", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "document.type", Value: 1}, {Key: "document.value", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+			},
+			// unique on the type alone, it let a single user sign up with a CPF
+			legacyIndexes: []string{"document.type_1"},
+		},
+		{
+			name: "transfer",
+			validator: jsonSchema([]string{"id", "payer", "payee", "value", "currency", "status", "created_at"}, bson.M{
+				"id":       bson.M{"bsonType": "string"},
+				"payer":    bson.M{"bsonType": "string"},
+				"payee":    bson.M{"bsonType": "string"},
+				"value":    bson.M{"bsonType": bson.A{"int", "long"}},
+				"currency": bson.M{"bsonType": "string"},
+				"status":   bson.M{"bsonType": "string"},
+				"history": bson.M{
+					"bsonType": "array",
+					"items": bson.M{
+						"bsonType": "object",
+						"required": bson.A{"to", "at"},
+						"properties": bson.M{
+							"at": bson.M{"bsonType": "date"},
+						},
+					},
+				},
+				"created_at": bson.M{"bsonType": "date"},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "payer", Value: 1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}},
+				},
+				{
+					Keys: bson.D{{Key: "payee", Value: 1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}},
+				},
+				{
+					Keys:    bson.D{{Key: "reversal_of", Value: 1}},
+					Options: options.Index().SetSparse(true),
+				},
+			},
+		},
+		{
+			name: "ledger",
+			validator: jsonSchema([]string{"id", "transfer_id", "postings", "created_at"}, bson.M{
+				"id":          bson.M{"bsonType": "string"},
+				"transfer_id": bson.M{"bsonType": "string"},
+				"postings": bson.M{
+					"bsonType": "array",
+					"minItems": 2,
+					"items": bson.M{
+						"bsonType": "object",
+						"required": bson.A{"account", "direction", "currency", "amount"},
+					},
+				},
+				"created_at": bson.M{"bsonType": "date"},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "transfer_id", Value: 1}},
+				},
+				{
+					Keys: bson.D{{Key: "postings.account", Value: 1}},
+				},
+			},
+		},
+		{
+			name: "idempotency_keys",
+			validator: jsonSchema([]string{"payer_id", "key", "fingerprint", "transfer", "created_at"}, bson.M{
+				"payer_id":    bson.M{"bsonType": "string"},
+				"key":         bson.M{"bsonType": "string"},
+				"fingerprint": bson.M{"bsonType": "string"},
+				"transfer":    bson.M{"bsonType": "object"},
+				"created_at":  bson.M{"bsonType": "date"},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "payer_id", Value: 1}, {Key: "key", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+			},
+			legacyIndexes: []string{"key_1"},
+		},
+		{
+			name: "refresh_tokens",
+			validator: jsonSchema([]string{"id", "user_id", "hash", "expires_at", "created_at"}, bson.M{
+				"id":         bson.M{"bsonType": "string"},
+				"user_id":    bson.M{"bsonType": "string"},
+				"hash":       bson.M{"bsonType": "string"},
+				"expires_at": bson.M{"bsonType": "date"},
+				"revoked_at": bson.M{"bsonType": bson.A{"date", "null"}},
+				"created_at": bson.M{"bsonType": "date"},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "hash", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+			},
+		},
+		{
+			name: "outbox",
+			validator: jsonSchema([]string{"id", "aggregate_id", "kind", "payload", "created_at"}, bson.M{
+				"id":           bson.M{"bsonType": "string"},
+				"aggregate_id": bson.M{"bsonType": "string"},
+				"kind":         bson.M{"bsonType": "string"},
+				"payload":      bson.M{"bsonType": "binData"},
+				"created_at":   bson.M{"bsonType": "date"},
+				"published_at": bson.M{"bsonType": bson.A{"date", "null"}},
+				"processed_at": bson.M{"bsonType": bson.A{"date", "null"}},
+			}),
+			indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "created_at", Value: 1}, {Key: "id", Value: 1}},
+				},
+				{
+					// the processed messages are kept a week, the worker finds the published ones until it processes them
+					Keys:    bson.D{{Key: "processed_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+				},
+			},
+			// expired the published messages, even the ones the worker had not processed yet
+			legacyIndexes: []string{"published_at_1"},
+		},
+	}
+
+	legacyDates = []legacyDate{
+		{collection: "users", field: "created_at"},
+		{collection: "transfer", field: "created_at"},
+	}
+)
+
+// Bootstrap creates the collections of the schema with their validators and indexes, updating the validators of
+// the collections that already exist, then converts the legacy string dates to BSON dates. Every step is
+// idempotent, it runs on every start. A collection that fails does not stop the others, the first error is returned
+func (m *MongoHandler) Bootstrap(ctx context.Context) error {
+	names, err := m.db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return errors.Wrap(err, "error listing the collections")
+	}
+
+	var existing = make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	var failed error
+	for _, c := range mongoSchema {
+		if err := m.ensureCollection(ctx, c, existing[c.name]); err != nil && failed == nil {
+			failed = errors.Wrap(err, "error bootstrapping the collection "+c.name)
+		}
+	}
+
+	for _, d := range legacyDates {
+		if _, err := m.MigrateLegacyDates(ctx, d.collection, d.field); err != nil && failed == nil {
+			failed = errors.Wrap(err, "error migrating the dates of the collection "+d.collection)
+		}
+	}
+
+	if err := m.migrateIdempotencyKeyPayers(ctx); err != nil && failed == nil {
+		failed = errors.Wrap(err, "error migrating the payers of the collection idempotency_keys")
+	}
+
+	return failed
+}
+
+// migrateIdempotencyKeyPayers copies the payer of the transfer of the keys stored before the keys were scoped to
+// their payer, so they are found again by the payer
+func (m *MongoHandler) migrateIdempotencyKeyPayers(ctx context.Context) error {
+	_, err := m.db.Collection("idempotency_keys").UpdateMany(
+		ctx,
+		bson.M{"payer_id": bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{"payer_id": "$transfer.payer"}}},
+	)
+
+	return err
+}
+
+func (m *MongoHandler) ensureCollection(ctx context.Context, c mongoCollection, exists bool) error {
+	if exists {
+		err := m.db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: c.name},
+			{Key: "validator", Value: c.validator},
+			{Key: "validationLevel", Value: "moderate"},
+		}).Err()
+		if err != nil {
+			return err
+		}
+	} else {
+		opts := options.CreateCollection().
+			SetValidator(c.validator).
+			SetValidationLevel("moderate")
+
+		if err := m.db.CreateCollection(ctx, c.name, opts); err != nil && !isNamespaceExists(err) {
+			return err
+		}
+	}
+
+	for _, name := range c.legacyIndexes {
+		if _, err := m.db.Collection(c.name).Indexes().DropOne(ctx, name); err != nil && !isIndexNotFound(err) {
+			return err
+		}
+	}
+
+	return m.EnsureIndexes(ctx, c.name, c.indexes)
+}
+
+// MigrateLegacyDates converts the values of the field stored as time.Time.String values to BSON dates and returns
+// the number of documents converted. A value that does not parse is left as is and reported once all are done
+func (m *MongoHandler) MigrateLegacyDates(ctx context.Context, collection string, field string) (int, error) {
+	cursor, err := m.db.Collection(collection).Find(
+		ctx,
+		bson.M{field: bson.M{"$type": "string"}},
+		options.Find().SetProjection(bson.M{"_id": 1, field: 1}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var (
+		converted int
+		invalid   error
+	)
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return converted, err
+		}
+
+		value, _ := doc[field].(string)
+		at, err := ParseLegacyTime(value)
+		if err != nil {
+			invalid = errors.Wrap(err, "invalid "+field+" "+value)
+			continue
+		}
+
+		// the filter on the type keeps a date written since the read
+		_, err = m.db.Collection(collection).UpdateOne(
+			ctx,
+			bson.M{"_id": doc["_id"], field: bson.M{"$type": "string"}},
+			bson.M{"$set": bson.M{field: at.UTC()}},
+		)
+		if err != nil {
+			return converted, err
+		}
+
+		converted++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return converted, err
+	}
+
+	return converted, invalid
+}
+
+// ParseLegacyTime parses a time.Time.String value, dropping the monotonic clock reading when present
+func ParseLegacyTime(value string) (time.Time, error) {
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+
+	return time.Parse(legacyTimeLayout, value)
+}
+
+func jsonSchema(required []string, properties bson.M) bson.M {
+	var req = make(bson.A, 0, len(required))
+	for _, r := range required {
+		req = append(req, r)
+	}
+
+	return bson.M{"$jsonSchema": bson.M{
+		"bsonType":   "object",
+		"required":   req,
+		"properties": properties,
+	}}
+}
+
+func isNamespaceExists(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Name == "NamespaceExists"
+}
+
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Name == "IndexNotFound"
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLegacyTime(t *testing.T) {
+	var want = time.Date(2021, time.October, 3, 14, 5, 6, 123456789, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "time.Time.String value",
+			value: "2021-10-03 14:05:06.123456789 +0000 UTC",
+			want:  want,
+		},
+		{
+			name:  "Monotonic clock reading dropped",
+			value: "2021-10-03 14:05:06.123456789 +0000 UTC m=+0.000123456",
+			want:  want,
+		},
+		{
+			name:  "Offset kept",
+			value: "2021-10-03 15:05:06.123456789 +0100 WAT",
+			want:  want,
+		},
+		{
+			name:    "RFC 3339 value",
+			value:   "2021-10-03T14:05:06Z",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLegacyTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMongoSchema(t *testing.T) {
+	var names = make(map[string]bool)
+
+	for _, c := range mongoSchema {
+		if names[c.name] {
+			t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", c.name, "duplicate collection", "unique collections")
+		}
+		names[c.name] = true
+
+		// every collection is looked up by a unique key
+		var unique bool
+		for _, index := range c.indexes {
+			if index.Options != nil && index.Options.Unique != nil && *index.Options.Unique {
+				unique = true
+			}
+		}
+		if !unique {
+			t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", c.name, unique, true)
+		}
+	}
+
+	for _, d := range legacyDates {
+		if !names[d.collection] {
+			t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", d.collection, "unknown collection", "collection of the schema")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SQLHandler defines the handler of a SQL database, PostgreSQL in production and SQLite locally. The drivers are
+// registered by the files built with the postgres and sqlite tags
+type SQLHandler struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLHandler opens the database of the driver and checks it is reachable
+func NewSQLHandler(driver string, dsn string) (*SQLHandler, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &SQLHandler{db: db, driver: driver}, nil
+}
+
+// DB returns the db property
+func (s *SQLHandler) DB() *sql.DB {
+	return s.db
+}
+
+// Driver returns the driver property
+func (s *SQLHandler) Driver() string {
+	return s.driver
+}
+
+// Migrate applies the embedded migrations not applied yet
+func (s *SQLHandler) Migrate(ctx context.Context) ([]Migration, error) {
+	migrations, err := loadEmbeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return Migrate(ctx, s.db, migrations)
+}
+
+// loadEmbeddedMigrations reads the embedded migrations with their steps
+func loadEmbeddedMigrations() ([]Migration, error) {
+	migrations, err := LoadMigrations(embeddedMigrations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		migrations[i].Step = migrationSteps[migrations[i].Version]
+	}
+
+	return migrations, nil
+}
@@ -0,0 +1,6 @@
+//go:build postgres
+
+package database
+
+// registers the postgres driver
+import _ "github.com/lib/pq"
@@ -0,0 +1,6 @@
+//go:build sqlite
+
+package database
+
+// registers the sqlite driver, pure Go so the tests run without cgo
+import _ "modernc.org/sqlite"
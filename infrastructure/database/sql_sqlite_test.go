@@ -0,0 +1,95 @@
+//go:build sqlite
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLHandler_Migrate(t *testing.T) {
+	handler, err := NewSQLHandler("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handler.DB().Close()
+
+	want, err := LoadMigrations(embeddedMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{
+			name: "Every migration applied",
+			want: len(want),
+		},
+		{
+			name: "Applied migrations skipped",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handler.Migrate(context.Background())
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, nil)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLHandler_MigrateIdempotencyKeyPayers(t *testing.T) {
+	handler, err := NewSQLHandler("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handler.DB().Close()
+
+	migrations, err := loadEmbeddedMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Migrate(context.Background(), handler.DB(), migrations[:7]); err != nil {
+		t.Fatal(err)
+	}
+
+	// the transfer of a key stored before the migration, with the payer after other fields
+	if _, err := handler.DB().ExecContext(
+		context.Background(),
+		`INSERT INTO idempotency_keys (idempotency_key, fingerprint, transfer, created_at) VALUES ($1, $2, $3, $4)`,
+		"key",
+		"fingerprint",
+		`{"value": 100, "id": "7a0a2d55-3a0b-4c54-9a3e-8c1b8e0c4f10", "payer": "0db298eb-c8e7-4829-84b7-c1036b4f0791"}`,
+		time.Now().UTC(),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := handler.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var payer string
+	if err := handler.DB().QueryRowContext(
+		context.Background(),
+		`SELECT payer_id FROM idempotency_keys WHERE idempotency_key = $1`,
+		"key",
+	).Scan(&payer); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "0db298eb-c8e7-4829-84b7-c1036b4f0791"; payer != want {
+		t.Errorf("[TestCase 'Payer of a stored key'] Got: '%v' | Want: '%v'", payer, want)
+	}
+}
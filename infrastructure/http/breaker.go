@@ -0,0 +1,275 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// StateClosed lets every request through and counts the failures
+	StateClosed BreakerState = iota
+	// StateOpen rejects every request until OpenTimeout elapses
+	StateOpen
+	// StateHalfOpen lets a limited number of probes through to decide whether to close again
+	StateHalfOpen
+)
+
+var (
+	// ErrOpenState is returned when the circuit breaker is open
+	ErrOpenState = errors.New("circuit breaker is open")
+
+	// ErrTooManyRequests is returned when the half-open circuit breaker already runs all its probes
+	ErrTooManyRequests = errors.New("circuit breaker is half-open and too many requests are in progress")
+)
+
+var (
+	defaultBreakerConsecutiveFailures uint32 = 5
+	defaultBreakerMinRequests         uint32 = 20
+	defaultBreakerWindow                     = 10 * time.Second
+	defaultBreakerBuckets                    = 10
+	defaultBreakerOpenTimeout                = 30 * time.Second
+)
+
+type (
+	// BreakerState is the state of the circuit breaker
+	BreakerState int
+
+	// BreakerSettings configures the circuit breaker, the zero values take the defaults
+	BreakerSettings struct {
+		// Name identifies the breaker in OnStateChange
+		Name string
+		// ConsecutiveFailures trips the breaker after that many failures in a row, defaults to 5 when FailureRate is not set
+		ConsecutiveFailures uint32
+		// FailureRate trips the breaker when the failures over the window reach that ratio, from 0 to 1
+		FailureRate float64
+		// MinRequests is the number of requests the window needs before FailureRate applies, defaults to 20
+		MinRequests uint32
+		// Window is the rolling window FailureRate is computed over, defaults to 10s
+		Window time.Duration
+		// Buckets divides the window, the oldest bucket is dropped as the time passes, defaults to 10
+		Buckets int
+		// OpenTimeout is how long the breaker stays open before letting probes through, defaults to 30s
+		OpenTimeout time.Duration
+		// HalfOpenMaxRequests is the number of concurrent probes in half-open state,
+		// as many successes close the breaker, defaults to 1
+		HalfOpenMaxRequests uint32
+		// OnStateChange is called after every change of state, outside the lock of the breaker
+		OnStateChange func(name string, from BreakerState, to BreakerState)
+	}
+
+	// breaker is the native Breaker implementation
+	breaker struct {
+		mu       sync.Mutex
+		settings BreakerSettings
+		now      func() time.Time
+
+		state      BreakerState
+		generation uint64
+		openedAt   time.Time
+
+		window      *rollingWindow
+		consecutive uint32
+
+		probes    uint32
+		successes uint32
+	}
+
+	// transition is a change of state to report once the lock is released
+	transition struct {
+		from BreakerState
+		to   BreakerState
+	}
+)
+
+// NewBreaker returns a circuit breaker in closed state
+func NewBreaker(s BreakerSettings) Breaker {
+	return newBreaker(s, time.Now)
+}
+
+func newBreaker(s BreakerSettings, now func() time.Time) *breaker {
+	if s.ConsecutiveFailures == 0 && s.FailureRate <= 0 {
+		s.ConsecutiveFailures = defaultBreakerConsecutiveFailures
+	}
+	if s.MinRequests == 0 {
+		s.MinRequests = defaultBreakerMinRequests
+	}
+	if s.Window <= 0 {
+		s.Window = defaultBreakerWindow
+	}
+	if s.Buckets <= 0 {
+		s.Buckets = defaultBreakerBuckets
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = defaultBreakerOpenTimeout
+	}
+	if s.HalfOpenMaxRequests == 0 {
+		s.HalfOpenMaxRequests = 1
+	}
+
+	return &breaker{
+		settings: s,
+		now:      now,
+		window:   newRollingWindow(s.Window, s.Buckets, now()),
+	}
+}
+
+// String returns the name of the state
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown state: %d", int(s))
+	}
+}
+
+// Execute runs fn unless the breaker rejects it, an error returned by fn or a panic counts as a failure
+func (b *breaker) Execute(fn func() (interface{}, error)) (interface{}, error) {
+	generation, err := b.before()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b.after(generation, false)
+			panic(r)
+		}
+	}()
+
+	res, err := fn()
+	b.after(generation, err == nil)
+
+	return res, err
+}
+
+// State returns the current state of the breaker
+func (b *breaker) State() BreakerState {
+	b.mu.Lock()
+	state, changed := b.current(b.now())
+	b.mu.Unlock()
+
+	b.notify(changed)
+
+	return state
+}
+
+func (b *breaker) before() (uint64, error) {
+	b.mu.Lock()
+	state, changed := b.current(b.now())
+
+	var err error
+	switch {
+	case state == StateOpen:
+		err = ErrOpenState
+	case state == StateHalfOpen && b.probes >= b.settings.HalfOpenMaxRequests:
+		err = ErrTooManyRequests
+	case state == StateHalfOpen:
+		b.probes++
+	}
+	generation := b.generation
+	b.mu.Unlock()
+
+	b.notify(changed)
+
+	return generation, err
+}
+
+func (b *breaker) after(generation uint64, success bool) {
+	b.mu.Lock()
+	now := b.now()
+	state, changed := b.current(now)
+
+	// the result of a request started before the last change of state does not tell anything about the current one
+	if generation != b.generation {
+		b.mu.Unlock()
+		b.notify(changed)
+		return
+	}
+
+	switch state {
+	case StateClosed:
+		b.window.add(now, success)
+		if success {
+			b.consecutive = 0
+		} else {
+			b.consecutive++
+		}
+
+		if b.tripped(now) {
+			changed = append(changed, b.setState(StateOpen, now))
+		}
+	case StateHalfOpen:
+		if !success {
+			changed = append(changed, b.setState(StateOpen, now))
+			break
+		}
+
+		b.successes++
+		if b.successes >= b.settings.HalfOpenMaxRequests {
+			changed = append(changed, b.setState(StateClosed, now))
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(changed)
+}
+
+// current moves the open breaker to half-open once OpenTimeout elapsed
+func (b *breaker) current(now time.Time) (BreakerState, []transition) {
+	if b.state == StateOpen && !now.Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		return b.state, []transition{b.setState(StateHalfOpen, now)}
+	}
+
+	return b.state, nil
+}
+
+func (b *breaker) tripped(now time.Time) bool {
+	if b.settings.ConsecutiveFailures > 0 && b.consecutive >= b.settings.ConsecutiveFailures {
+		return true
+	}
+
+	if b.settings.FailureRate <= 0 {
+		return false
+	}
+
+	requests, failures := b.window.counts(now)
+	if requests < b.settings.MinRequests {
+		return false
+	}
+
+	return float64(failures)/float64(requests) >= b.settings.FailureRate
+}
+
+// setState starts a new generation, the counters of the previous state are dropped
+func (b *breaker) setState(state BreakerState, now time.Time) transition {
+	t := transition{from: b.state, to: state}
+
+	b.state = state
+	b.generation++
+	b.consecutive = 0
+	b.probes = 0
+	b.successes = 0
+	b.window.reset(now)
+	if state == StateOpen {
+		b.openedAt = now
+	}
+
+	return t
+}
+
+func (b *breaker) notify(changed []transition) {
+	if b.settings.OnStateChange == nil {
+		return
+	}
+
+	for _, t := range changed {
+		b.settings.OnStateChange(b.settings.Name, t.from, t.to)
+	}
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// step runs a request after waiting, fail tells whether the request fails
+type step struct {
+	wait time.Duration
+	fail bool
+}
+
+func failures(n int) []step {
+	steps := make([]step, n)
+	for i := range steps {
+		steps[i] = step{fail: true}
+	}
+	return steps
+}
+
+func successes(n int) []step {
+	return make([]step, n)
+}
+
+func TestBreaker_Execute(t *testing.T) {
+	tests := []struct {
+		name            string
+		settings        BreakerSettings
+		steps           [][]step
+		wantState       BreakerState
+		wantTransitions []string
+		wantErr         error
+	}{
+		{
+			name:      "Closed breaker lets the requests through",
+			settings:  BreakerSettings{ConsecutiveFailures: 3},
+			steps:     [][]step{failures(2), successes(1), failures(2)},
+			wantState: StateClosed,
+		},
+		{
+			name:            "Consecutive failures open the breaker",
+			settings:        BreakerSettings{ConsecutiveFailures: 3},
+			steps:           [][]step{failures(3)},
+			wantState:       StateOpen,
+			wantTransitions: []string{"closed->open"},
+			wantErr:         ErrOpenState,
+		},
+		{
+			name:            "Failure rate opens the breaker",
+			settings:        BreakerSettings{FailureRate: 0.5, MinRequests: 4},
+			steps:           [][]step{{{fail: true}, {}, {fail: true}, {}}},
+			wantState:       StateOpen,
+			wantTransitions: []string{"closed->open"},
+			wantErr:         ErrOpenState,
+		},
+		{
+			name:      "Failure rate waits for the minimum of requests",
+			settings:  BreakerSettings{FailureRate: 0.5, MinRequests: 4},
+			steps:     [][]step{failures(3)},
+			wantState: StateClosed,
+		},
+		{
+			name: "Failures older than the window are dropped",
+			settings: BreakerSettings{
+				FailureRate: 0.5,
+				MinRequests: 4,
+				Window:      10 * time.Second,
+				Buckets:     10,
+			},
+			steps:     [][]step{failures(3), {{wait: 11 * time.Second, fail: true}}},
+			wantState: StateClosed,
+		},
+		{
+			name:            "Open breaker lets a probe through after the timeout",
+			settings:        BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second},
+			steps:           [][]step{failures(1), {{wait: time.Second}}},
+			wantState:       StateClosed,
+			wantTransitions: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name:     "Half-open breaker needs every probe to succeed",
+			settings: BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second, HalfOpenMaxRequests: 2},
+			steps:    [][]step{failures(1), {{wait: time.Second}}},
+			// the second probe is still allowed
+			wantState:       StateHalfOpen,
+			wantTransitions: []string{"closed->open", "open->half-open"},
+		},
+		{
+			name:            "Failed probe opens the breaker again",
+			settings:        BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second},
+			steps:           [][]step{failures(1), {{wait: time.Second, fail: true}}},
+			wantState:       StateOpen,
+			wantTransitions: []string{"closed->open", "open->half-open", "half-open->open"},
+			wantErr:         ErrOpenState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				clock       = &fakeClock{now: time.Now()}
+				transitions []string
+			)
+
+			tt.settings.OnStateChange = func(_ string, from, to BreakerState) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			}
+			b := newBreaker(tt.settings, clock.Now)
+
+			for _, steps := range tt.steps {
+				for _, s := range steps {
+					clock.now = clock.now.Add(s.wait)
+					_, _ = b.Execute(func() (interface{}, error) {
+						if s.fail {
+							return nil, errFail
+						}
+						return nil, nil
+					})
+				}
+			}
+
+			if got := b.State(); got != tt.wantState {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantState)
+			}
+
+			if strings.Join(transitions, ",") != strings.Join(tt.wantTransitions, ",") {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, transitions, tt.wantTransitions)
+			}
+
+			var called bool
+			_, err := b.Execute(func() (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+			if called == (tt.wantErr != nil) {
+				t.Errorf("[TestCase '%s'] Got: 'called %v' | Want: 'called %v'", tt.name, called, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestBreaker_HalfOpenProbeLimit(t *testing.T) {
+	var (
+		clock   = &fakeClock{now: time.Now()}
+		b       = newBreaker(BreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second}, clock.Now)
+		release = make(chan struct{})
+		started = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+
+	_, _ = b.Execute(func() (interface{}, error) { return nil, errFail })
+	clock.now = clock.now.Add(time.Second)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = b.Execute(func() (interface{}, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+	}()
+	<-started
+
+	_, err := b.Execute(func() (interface{}, error) { return nil, nil })
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", "Second probe is rejected", err, ErrTooManyRequests)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := b.State(); got != StateClosed {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", "Probe closes the breaker", got, StateClosed)
+	}
+}
+
+func TestHostBreakers_Get(t *testing.T) {
+	hb := NewHostBreakers(BreakerSettings{Name: "authorizer", ConsecutiveFailures: 1})
+
+	_, _ = hb.Get("down.local").Execute(func() (interface{}, error) { return nil, errFail })
+
+	if _, err := hb.Get("down.local").Execute(func() (interface{}, error) { return nil, nil }); !errors.Is(err, ErrOpenState) {
+		t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", "Host down is open", err, ErrOpenState)
+	}
+
+	if _, err := hb.Get("up.local").Execute(func() (interface{}, error) { return nil, nil }); err != nil {
+		t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", "Other host is closed", err, nil)
+	}
+}
+
+func TestRetry_RoundTripWithCircuitBreaker(t *testing.T) {
+	var calls int
+	rt := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	cb := NewHostCircuitBreaker(NewHostBreakers(BreakerSettings{ConsecutiveFailures: 2})).WithTransport(rt)
+	r := NewRetry(5, nil, time.Millisecond).WithTransport(cb)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://authorizer.local", nil)
+	_, err := r.RoundTrip(req)
+	if !errors.Is(err, ErrOpenState) {
+		t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", "Retry stops on open breaker", err, ErrOpenState)
+	}
+
+	if calls != 2 {
+		t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", "Retry stops on open breaker", calls, 2)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -17,31 +18,73 @@ type (
 
 	// CircuitBreaker is the application http transport
 	CircuitBreaker struct {
-		rt		http.RoundTripper
-		breaker	Breaker
+		rt      http.RoundTripper
+		breaker func(*http.Request) Breaker
+	}
+
+	// HostBreakers keeps a circuit breaker per host, so a host that is down does not trip the requests to the others
+	HostBreakers struct {
+		mu       sync.Mutex
+		settings BreakerSettings
+		breakers map[string]Breaker
 	}
 )
 
 // NewCircuitBreaker returns a new configured CircuitBreaker with circuit breaker
 func NewCircuitBreaker(cb Breaker) *CircuitBreaker {
-	rt := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout: 10.*time.Second,
-			KeepAlive: 90 * time.Second,
-			DualStack: true,
-		}).DialContext,
+	return &CircuitBreaker{
+		rt: newTransport(),
+		breaker: func(*http.Request) Breaker {
+			return cb
+		},
 	}
+}
 
+// NewHostCircuitBreaker returns a new configured CircuitBreaker with a circuit breaker per host
+func NewHostCircuitBreaker(hb *HostBreakers) *CircuitBreaker {
 	return &CircuitBreaker{
-		rt: rt,
-		breaker: cb,
+		rt: newTransport(),
+		breaker: func(r *http.Request) Breaker {
+			return hb.Get(r.URL.Host)
+		},
 	}
 }
 
+// NewHostBreakers returns the breakers of the hosts, created with the settings on the first request to each host
+func NewHostBreakers(s BreakerSettings) *HostBreakers {
+	return &HostBreakers{
+		settings: s,
+		breakers: make(map[string]Breaker),
+	}
+}
+
+// Get returns the breaker of the host, the host is appended to the name given in the settings
+func (h *HostBreakers) Get(host string) Breaker {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if b, ok := h.breakers[host]; ok {
+		return b
+	}
+
+	s := h.settings
+	if s.Name == "" {
+		s.Name = host
+	} else {
+		s.Name = s.Name + ":" + host
+	}
+
+	b := NewBreaker(s)
+	h.breakers[host] = b
+
+	return b
+}
+
 // RoundTrip decorates rt.RoundTrip with a circuit breaker.
-// An error is returned if the circuit breaker rejects the request.
+// An error is returned if the circuit breaker rejects the request, a server error response counts as a failure
+// of the breaker but is still returned so the caller can read it.
 func (t *CircuitBreaker) RoundTrip(r *http.Request) (*http.Response, error) {
-	res, err := t.breaker.Execute(func() (interface{}, error) {
+	res, err := t.breaker(r).Execute(func() (interface{}, error) {
 		res, err := t.rt.RoundTrip(r)
 		if err != nil {
 			return nil, err
@@ -54,9 +97,27 @@ func (t *CircuitBreaker) RoundTrip(r *http.Request) (*http.Response, error) {
 		return res, err
 	})
 
-	if err != nil {
-		return nil, err
+	if res, ok := res.(*http.Response); ok && res != nil {
+		return res, nil
 	}
 
-	return res.(*http.Response), err
-}
\ No newline at end of file
+	return nil, err
+}
+
+// WithTransport returns a copy of the CircuitBreaker decorating rt instead of the default transport
+func (t *CircuitBreaker) WithTransport(rt http.RoundTripper) *CircuitBreaker {
+	return &CircuitBreaker{
+		rt:      rt,
+		breaker: t.breaker,
+	}
+}
+
+func newTransport() http.RoundTripper {
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 90 * time.Second,
+			DualStack: true,
+		}).DialContext,
+	}
+}
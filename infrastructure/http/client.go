@@ -1,6 +1,10 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -19,4 +23,34 @@ func NewClient(r *Request) *Client {
 // Get executes a GET http request
 func (c *Client) Get(url string) (*http.Response, error) {
 	return c.req.Do(http.MethodGet, url, "application/json", nil)
-}
\ No newline at end of file
+}
+
+// GetWithContext executes a GET http request bound to ctx, the given headers are added to the request
+func (c *Client) GetWithContext(ctx context.Context, url string, headers http.Header) (*http.Response, error) {
+	return c.req.DoWithContext(ctx, http.MethodGet, url, "application/json", nil, headers)
+}
+
+// Post executes a POST http request with body encoded as JSON
+func (c *Client) Post(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, url, body, headers)
+}
+
+// Put executes a PUT http request with body encoded as JSON
+func (c *Client) Put(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error) {
+	return c.send(ctx, http.MethodPut, url, body, headers)
+}
+
+// send encodes body in a bytes.Reader, so the request can be replayed by the retry
+func (c *Client) send(
+	ctx context.Context,
+	method, url string,
+	body interface{},
+	headers http.Header,
+) (*http.Response, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %v", err)
+	}
+
+	return c.req.DoWithContext(ctx, method, url, "application/json", bytes.NewReader(b), headers)
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_Send(t *testing.T) {
+	type received struct {
+		method      string
+		body        string
+		contentType string
+		correlation string
+	}
+
+	tests := []struct {
+		name string
+		send func(c *Client, url string) (*http.Response, error)
+		want received
+	}{
+		{
+			name: "Post JSON body with headers",
+			send: func(c *Client, url string) (*http.Response, error) {
+				return c.Post(context.Background(), url, map[string]int{"amount": 100}, http.Header{"X-Correlation-Id": []string{"abc"}})
+			},
+			want: received{method: http.MethodPost, body: `{"amount":100}`, contentType: "application/json", correlation: "abc"},
+		},
+		{
+			name: "Put JSON body",
+			send: func(c *Client, url string) (*http.Response, error) {
+				return c.Put(context.Background(), url, []string{"a"}, nil)
+			},
+			want: received{method: http.MethodPut, body: `["a"]`, contentType: "application/json"},
+		},
+		{
+			name: "Get without body",
+			send: func(c *Client, url string) (*http.Response, error) {
+				return c.Get(url)
+			},
+			want: received{method: http.MethodGet, contentType: "application/json"},
+		},
+		{
+			name: "Get with headers",
+			send: func(c *Client, url string) (*http.Response, error) {
+				return c.GetWithContext(context.Background(), url, http.Header{"X-Correlation-Id": []string{"abc"}})
+			},
+			want: received{method: http.MethodGet, contentType: "application/json", correlation: "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got received
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				got = received{
+					method:      r.Method,
+					body:        string(b),
+					contentType: r.Header.Get("Content-Type"),
+					correlation: r.Header.Get("X-Correlation-Id"),
+				}
+			}))
+			defer srv.Close()
+
+			res, err := tt.send(NewClient(NewRequest()), srv.URL)
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, nil)
+			}
+			res.Body.Close()
+
+			if got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_SendBody(t *testing.T) {
+	const (
+		requests = 10
+		body     = `{"decision":"approved"}`
+	)
+
+	// the body is written after the headers, it is read once Post has returned
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	var (
+		c  = NewClient(NewRequest())
+		wg sync.WaitGroup
+	)
+
+	// the requests share the client, they run at once so the race detector sees a write to it
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res, err := c.Post(context.Background(), srv.URL, nil, nil)
+			if err != nil {
+				t.Errorf("[TestCase 'Body read after the response'] Err: '%v' | WantErr: '%v'", err, nil)
+				return
+			}
+			defer res.Body.Close()
+
+			got, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("[TestCase 'Body read after the response'] Err: '%v' | WantErr: '%v'", err, nil)
+				return
+			}
+			if string(got) != body {
+				t.Errorf("[TestCase 'Body read after the response'] Got: '%v' | Want: '%v'", string(got), body)
+			}
+		}()
+	}
+	wg.Wait()
+}
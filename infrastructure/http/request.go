@@ -22,32 +22,43 @@ type (
 	}
 )
 
-// NewRequest returns a new configured Request.
+// NewRequest returns a new configured Request, the requests time out after defaultTimeout unless WithTimeout is given.
 func NewRequest(opts ...RequestOption) *Request {
 	r := &Request{client: new(http.Client)}
 	for _, o := range opts {
 		o(r)
 	}
+
+	if r.client.Timeout == 0 {
+		r.client.Timeout = defaultTimeout
+	}
 	return r
 }
 
 // Do is a convenient method for executing http requests.
 func (r *Request) Do(method, url, contentType string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(method, url, body)
+	return r.DoWithContext(context.Background(), method, url, contentType, body, nil)
+}
+
+// DoWithContext executes the http request bound to ctx, the given headers are added to the request.
+// The timeout of the client covers reading the response body, which is left to the caller.
+func (r *Request) DoWithContext(
+	ctx context.Context,
+	method, url, contentType string,
+	body io.Reader,
+	headers http.Header,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request %v: ", err)
 	}
 
-	req.Header.Set("Content-Type", contentType)
-
-	if r.client.Timeout == 0 {
-		r.client.Timeout = defaultTimeout
+	for key, values := range headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
 	}
-
-	ctx, cancel := context.WithTimeout(req.Context(), r.client.Timeout)
-	defer cancel()
-
-	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
 
 	return r.client.Do(req)
 }
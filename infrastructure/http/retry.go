@@ -1,24 +1,68 @@
 package http
 
 import (
-	"fmt"
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
+	"strconv"
+	"syscall"
 	"time"
 )
 
 var (
 	defaultSleep = 500 * time.Millisecond
+
+	// defaultRetryStatusCodes are the responses telling the request may succeed later
+	defaultRetryStatusCodes = []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
 )
 
 type (
 	// Retry is mechanism the application retry.
 	Retry struct {
-		attempts    int
-		sleep       time.Duration
-		statusCodes []int
-		rt          http.RoundTripper
+		policy RetryPolicy
+		rt     http.RoundTripper
+		after  func(time.Duration) <-chan time.Time
+	}
+
+	// RetryPolicy decides which attempts are retried and how long to wait before the next one
+	RetryPolicy struct {
+		// MaxAttempts is the number of attempts, the first one included
+		MaxAttempts int
+		// Backoff is the wait before the second attempt, it doubles with some jitter on every retry, defaults to 500ms
+		Backoff time.Duration
+		// MaxBackoff caps the wait between two attempts, zero means no cap
+		MaxBackoff time.Duration
+		// MaxElapsed caps the time spent since the first attempt, a retry that would start after it is not made
+		MaxElapsed time.Duration
+		// StatusCodes are the retried response status codes, defaults to 429, 502, 503 and 504
+		StatusCodes []int
+		// OnAttempt is called after every attempt, e.g. for logging and metrics
+		OnAttempt func(Attempt)
+	}
+
+	// Attempt describes the outcome of an attempt
+	Attempt struct {
+		// Number of the attempt, starting at 1
+		Number  int
+		Request *http.Request
+		// StatusCode of the response, zero when the attempt failed with Err
+		StatusCode int
+		Err        error
+		// Elapsed is the time spent since the first attempt
+		Elapsed time.Duration
+		// Retry tells whether another attempt follows after Wait
+		Retry bool
+		Wait  time.Duration
 	}
 
 	// Func is the function to be executed and eventually retried.
@@ -27,49 +71,242 @@ type (
 
 // NewRetry returns a new configured CircuitBreaker with retry.
 func NewRetry(attempts int, statusCode []int, sleep time.Duration) *Retry {
-	rt := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
-			KeepAlive: 90 * time.Second,
-			DualStack: true,
-		}).DialContext,
+	return NewRetryWithPolicy(RetryPolicy{
+		MaxAttempts: attempts,
+		Backoff:     sleep,
+		StatusCodes: statusCode,
+	})
+}
+
+// NewRetryWithPolicy returns a new configured Retry following the policy
+func NewRetryWithPolicy(p RetryPolicy) *Retry {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = defaultSleep
+	}
+	if len(p.StatusCodes) == 0 {
+		p.StatusCodes = defaultRetryStatusCodes
 	}
 
 	return &Retry{
-		attempts:    attempts,
-		sleep:       sleep,
-		statusCodes: statusCode,
-		rt:          rt,
+		policy: p,
+		rt:     newTransport(),
+		after:  time.After,
 	}
 }
 
+// WithTransport returns a copy of the Retry decorating rt instead of the default transport,
+// e.g. a CircuitBreaker so every attempt is counted by the breaker
+func (r *Retry) WithTransport(rt http.RoundTripper) *Retry {
+	c := *r
+	c.rt = rt
+
+	return &c
+}
+
 // RoundTrip decorates RoundTrip with a retry.
+// The last response is returned when the attempts are exhausted so the caller can read it, a request whose body
+// can not be replayed or rejected by a circuit breaker is not retried, and waiting stops when the context is done.
 func (r *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
-	var res *http.Response
-	var err error
+	var (
+		ctx   = req.Context()
+		start = time.Now()
+		sleep = r.policy.Backoff
+	)
 
-	fn := func() (*http.Response, error) {
-		res, err := r.rt.RoundTrip(req)
+	for number := 1; ; number++ {
+		attemptReq, err := r.replay(req, number)
 		if err != nil {
+			return nil, err
+		}
+
+		res, err := r.rt.RoundTrip(attemptReq)
+
+		attempt := Attempt{
+			Number:  number,
+			Request: attemptReq,
+			Err:     err,
+			Elapsed: time.Since(start),
+		}
+		if res != nil {
+			attempt.StatusCode = res.StatusCode
+		}
+
+		var wait time.Duration
+		attempt.Retry, wait = r.next(req, res, err, number, sleep, attempt.Elapsed)
+		if attempt.Retry {
+			attempt.Wait = wait
+		}
+		if r.policy.OnAttempt != nil {
+			r.policy.OnAttempt(attempt)
+		}
+
+		if !attempt.Retry {
 			return res, err
 		}
 
-		for _, statusCode := range r.statusCodes {
-			if res.StatusCode == statusCode {
-				return nil, fmt.Errorf("failed to request: %v ", http.StatusText(statusCode))
-			}
+		// the response is dropped, its connection goes back to the pool
+		if res != nil {
+			drain(res)
 		}
 
-		return res, err
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-r.after(wait):
+		}
+
+		sleep *= 2
+	}
+}
+
+// replay returns the request of the attempt, its body is read again from GetBody after the first attempt
+func (r *Retry) replay(req *http.Request, number int) (*http.Request, error) {
+	if number == 1 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
 	}
 
-	err = retry(func() error {
-		var err error
-		res, err = fn()
-		return err
-	}, r.attempts, r.sleep)
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	replay := req.Clone(req.Context())
+	replay.Body = body
+
+	return replay, nil
+}
+
+// next tells whether the attempt is retried and how long to wait before
+func (r *Retry) next(
+	req *http.Request,
+	res *http.Response,
+	err error,
+	number int,
+	sleep time.Duration,
+	elapsed time.Duration,
+) (bool, time.Duration) {
+	if number >= r.policy.MaxAttempts || req.Context().Err() != nil {
+		return false, 0
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false, 0
+	}
+
+	var wait = jitter(sleep)
+
+	switch {
+	case err != nil:
+		if !retryableError(err) {
+			return false, 0
+		}
+	case !r.retryableStatus(res.StatusCode):
+		return false, 0
+	default:
+		if after, ok := retryAfter(res, time.Now()); ok {
+			wait = after
+		}
+	}
+
+	// a Retry-After of the server is held to the cap as well
+	if r.policy.MaxBackoff > 0 && wait > r.policy.MaxBackoff {
+		wait = r.policy.MaxBackoff
+	}
+
+	if r.policy.MaxElapsed > 0 && elapsed+wait > r.policy.MaxElapsed {
+		return false, 0
+	}
+
+	return true, wait
+}
+
+func (r *Retry) retryableStatus(statusCode int) bool {
+	for _, s := range r.policy.StatusCodes {
+		if s == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryableError tells whether the error is a network failure that may not happen again
+func retryableError(err error) bool {
+	if errors.Is(err, ErrOpenState) || errors.Is(err, ErrTooManyRequests) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalid          x509.CertificateInvalidError
+		hostname         x509.HostnameError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &invalid) || errors.As(err, &hostname) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryAfter reads the Retry-After header of 429 and 503 responses, in seconds or as an http date
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res.StatusCode != http.StatusTooManyRequests && res.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if at.Before(now) {
+		return 0, true
+	}
+
+	return at.Sub(now), true
+}
+
+func drain(res *http.Response) {
+	if res.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	_ = res.Body.Close()
+}
 
-	return res, err
+// jitter slightly increases the sleep value, preventing thundering herd problem (https://en.wikipedia.org/wiki/Thundering_herd_problem)
+func jitter(sleep time.Duration) time.Duration {
+	return sleep + (time.Duration(rand.Int63n(int64(sleep))))/2
 }
 
 // retry runs the passed function until the number of attempts is reached.
@@ -87,8 +324,7 @@ func retry(fn Func, attempts int, sleep time.Duration) error {
 			return err
 		}
 
-		// preventing thundering herd problem (https://en.wikipedia.org/wiki/Thundering_herd_problem)
-		sleep += (time.Duration(rand.Int63n(int64(sleep)))) / 2
+		sleep = jitter(sleep)
 		time.Sleep(sleep)
 
 		return retry(fn, attempts, 2*sleep)
@@ -1,7 +1,13 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -67,4 +73,200 @@ func testRetryWithFail(t *testing.T) {
 	if attemptsCount != 2 {
 		t.Errorf("attemptsCount returned wrong count value: got %v want %v", attemptsCount, 2)
 	}
-}
\ No newline at end of file
+}
+// scriptedTransport answers the attempts in order and records the bodies it received
+type scriptedTransport struct {
+	responses []func() (*http.Response, error)
+	bodies    []string
+}
+
+func (s *scriptedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		b, _ := ioutil.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(b))
+	}
+
+	next := s.responses[len(s.bodies)-1]
+	return next()
+}
+
+func status(code int, header http.Header) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return &http.Response{
+			StatusCode: code,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+}
+
+func failure(err error) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return nil, err
+	}
+}
+
+func TestRetry_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		responses  []func() (*http.Response, error)
+		getBody    bool
+		cancel     bool
+		wantStatus int
+		wantErr    error
+		wantWaits  []time.Duration
+		wantBodies int
+	}{
+		{
+			name:       "Retry the status codes and replay the body",
+			policy:     RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){status(http.StatusBadGateway, nil), status(http.StatusOK, nil)},
+			getBody:    true,
+			wantStatus: http.StatusOK,
+			wantBodies: 2,
+		},
+		{
+			name:       "Return the last response when the attempts are exhausted",
+			policy:     RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){status(http.StatusBadGateway, nil), status(http.StatusBadGateway, nil)},
+			getBody:    true,
+			wantStatus: http.StatusBadGateway,
+			wantBodies: 2,
+		},
+		{
+			name:       "Do not retry other status codes",
+			policy:     RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){status(http.StatusBadRequest, nil)},
+			getBody:    true,
+			wantStatus: http.StatusBadRequest,
+			wantBodies: 1,
+		},
+		{
+			name:       "Do not retry a body that can not be replayed",
+			policy:     RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){status(http.StatusBadGateway, nil)},
+			wantStatus: http.StatusBadGateway,
+			wantBodies: 1,
+		},
+		{
+			name:   "Honor Retry-After",
+			policy: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+			responses: []func() (*http.Response, error){
+				status(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"2"}}),
+				status(http.StatusOK, nil),
+			},
+			getBody:    true,
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{2 * time.Second},
+			wantBodies: 2,
+		},
+		{
+			name:   "Retry-After is capped at the max backoff",
+			policy: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Second},
+			responses: []func() (*http.Response, error){
+				status(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"120"}}),
+				status(http.StatusOK, nil),
+			},
+			getBody:    true,
+			wantStatus: http.StatusOK,
+			wantWaits:  []time.Duration{time.Second},
+			wantBodies: 2,
+		},
+		{
+			name:   "Retry-After over the elapsed cap stops the retries",
+			policy: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, MaxElapsed: time.Second},
+			responses: []func() (*http.Response, error){
+				status(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"2"}}),
+			},
+			getBody:    true,
+			wantStatus: http.StatusTooManyRequests,
+			wantBodies: 1,
+		},
+		{
+			name:       "Retry a connection refused",
+			policy:     RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){failure(syscall.ECONNREFUSED), status(http.StatusOK, nil)},
+			getBody:    true,
+			wantStatus: http.StatusOK,
+			wantBodies: 2,
+		},
+		{
+			name:       "Do not retry an unknown error",
+			policy:     RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+			responses:  []func() (*http.Response, error){failure(errFail)},
+			getBody:    true,
+			wantErr:    errFail,
+			wantBodies: 1,
+		},
+		{
+			name:       "Stop waiting when the context is done",
+			policy:     RetryPolicy{MaxAttempts: 2, Backoff: time.Hour},
+			responses:  []func() (*http.Response, error){status(http.StatusBadGateway, nil)},
+			getBody:    true,
+			cancel:     true,
+			wantErr:    context.Canceled,
+			wantBodies: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				transport = &scriptedTransport{responses: tt.responses}
+				waits     []time.Duration
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tt.policy.OnAttempt = func(a Attempt) {
+				if a.Retry && a.Err == nil && a.StatusCode == http.StatusServiceUnavailable {
+					waits = append(waits, a.Wait)
+				}
+				if tt.cancel {
+					cancel()
+				}
+			}
+
+			r := NewRetryWithPolicy(tt.policy).WithTransport(transport)
+			r.after = func(d time.Duration) <-chan time.Time {
+				if d >= time.Hour {
+					return nil
+				}
+				return time.After(time.Millisecond)
+			}
+
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://authorizer.local", strings.NewReader("{}"))
+			if !tt.getBody {
+				req.GetBody = nil
+			}
+
+			res, err := r.RoundTrip(req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			var got int
+			if res != nil {
+				got = res.StatusCode
+			}
+			if got != tt.wantStatus {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantStatus)
+			}
+
+			if len(transport.bodies) != tt.wantBodies {
+				t.Errorf("[TestCase '%s'] Got: '%v attempts' | Want: '%v attempts'", tt.name, len(transport.bodies), tt.wantBodies)
+			}
+			for _, b := range transport.bodies {
+				if b != "{}" {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, b, "{}")
+				}
+			}
+
+			if len(tt.wantWaits) > 0 && !reflect.DeepEqual(waits, tt.wantWaits) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, waits, tt.wantWaits)
+			}
+		})
+	}
+}
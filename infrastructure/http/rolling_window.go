@@ -0,0 +1,78 @@
+package http
+
+import "time"
+
+type (
+	// rollingWindow counts the requests and failures of the last window, split in buckets
+	rollingWindow struct {
+		width   time.Duration
+		buckets []bucket
+		current int
+		start   time.Time
+	}
+
+	bucket struct {
+		requests uint32
+		failures uint32
+	}
+)
+
+func newRollingWindow(window time.Duration, buckets int, now time.Time) *rollingWindow {
+	width := window / time.Duration(buckets)
+	if width <= 0 {
+		width = 1
+	}
+
+	return &rollingWindow{
+		width:   width,
+		buckets: make([]bucket, buckets),
+		start:   now,
+	}
+}
+
+func (w *rollingWindow) add(now time.Time, success bool) {
+	w.advance(now)
+
+	w.buckets[w.current].requests++
+	if !success {
+		w.buckets[w.current].failures++
+	}
+}
+
+func (w *rollingWindow) counts(now time.Time) (requests uint32, failures uint32) {
+	w.advance(now)
+
+	for _, b := range w.buckets {
+		requests += b.requests
+		failures += b.failures
+	}
+
+	return requests, failures
+}
+
+func (w *rollingWindow) reset(now time.Time) {
+	for i := range w.buckets {
+		w.buckets[i] = bucket{}
+	}
+	w.current = 0
+	w.start = now
+}
+
+// advance drops the buckets older than the window
+func (w *rollingWindow) advance(now time.Time) {
+	elapsed := int(now.Sub(w.start) / w.width)
+	if elapsed <= 0 {
+		return
+	}
+
+	if elapsed >= len(w.buckets) {
+		w.reset(now)
+		return
+	}
+
+	for i := 0; i < elapsed; i++ {
+		w.current = (w.current + 1) % len(w.buckets)
+		w.buckets[w.current] = bucket{}
+	}
+	w.start = w.start.Add(time.Duration(elapsed) * w.width)
+}
@@ -1,106 +1,492 @@
 package infrastructure
 
 import (
+	"context"
+	"crypto/rand"
 	"encoding/json"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/handler"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/api/middleware"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/fx"
 	adapterhttp "github.com/ofiliobi/urban-octo-fortnight/adapter/http"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/limits"
 	adapterlogger "github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
-	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
 	adapterqueue "github.com/ofiliobi/urban-octo-fortnight/adapter/queue"
-	"github.com/ofiliobi/urban-octo-fortnight/adapter/repository"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/presenter"
+	"github.com/ofiliobi/urban-octo-fortnight/adapter/rules"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
 	infrahttp "github.com/ofiliobi/urban-octo-fortnight/infrastructure/http"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/queue"
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/router"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/security"
 	"github.com/ofiliobi/urban-octo-fortnight/usecase"
 )
 
 // HTTPServer define an application structure
 type HTTPServer struct {
-	database *database.MongoHandler
-	logger   adapterlogger.Logger
-	router   router.Router
-	queue    *queue.RabbitMQHandler
+	database     *database.MongoHandler
+	repositories repositories
+	broker       *queue.InMemoryHandler
+	rabbitMQ     *queue.RabbitMQHandler
+	logger       adapterlogger.Logger
+	router       router.Router
+	events       *usecase.EventBus
 }
 
-// NewHTTPServer creates new HTTPServer with its dependencies
+// NewHTTPServer creates new HTTPServer with its dependencies, the backends are chosen by DATABASE_BACKEND
+// and QUEUE_BACKEND. MongoDB is only dialed by the mongodb backend and RabbitMQ when the server relays the outbox to it,
+// the SQL backends are migrated on start
 func NewHTTPServer() *HTTPServer {
-	return &HTTPServer{
-		database: database.NewMongoHandler(),
-		logger:   logger.NewLogrus(),
-		router:   router.NewMux(),
-		queue:    queue.NewRabbitMQHandler(),
+	log := logger.NewLogrus()
+
+	server := &HTTPServer{
+		logger: log,
+		router: router.NewMux(),
+		events: newEventBus(log),
+	}
+
+	switch databaseBackend() {
+	case memoryBackend:
+		server.repositories = newMemoryRepositories(database.NewInMemory())
+	case postgresBackend, sqliteBackend:
+		server.repositories = newSQLRepositories(openSQLDatabase(databaseBackend(), log))
+	default:
+		server.database = database.NewMongoHandler()
+		server.repositories = newMongoRepositories(server.database)
+	}
+
+	// the relay and the worker processes cannot reach the memory backends of the server, they run inside it
+	switch {
+	case queueBackend() == memoryBackend:
+		server.broker = queue.NewInMemoryHandler()
+	case databaseBackend() == memoryBackend:
+		server.rabbitMQ = queue.NewRabbitMQHandler()
 	}
+
+	return server
 }
 
 // Start run the application
 func (a HTTPServer) Start() {
+	if a.database != nil {
+		a.bootstrapDatabase()
+	}
+	a.startWorkers()
+
+	for _, name := range []string{
+		entity.UserCreatedEvent,
+		entity.TransferCompletedEvent,
+		entity.TransferFailedEvent,
+		entity.WalletDebitedEvent,
+		entity.WalletCreditedEvent,
+	} {
+		a.events.SubscribeAsync(name, a.logEvent)
+	}
+
+	tokens := a.tokens()
+	authenticated := middleware.NewAuthentication(tokens).Execute
+
 	a.router.GET("/health", healthCheck)
 
+	a.router.POST("/auth/login", a.loginHandler(tokens))
+	a.router.POST("/auth/refresh", a.refreshTokenHandler(tokens))
+
 	a.router.POST("/users", a.createUserHandler())
-	a.router.GET("/users/{user_id}", a.findUserByIDHandler())
+	a.router.GET("/users/{user_id}", a.findUserByIDHandler(), authenticated)
+	a.router.GET("/users/{user_id}/ledger/balance", a.reconcileWalletHandler(), authenticated)
+	a.router.GET("/users/{user_id}/transfers", a.findTransfersByUserHandler(), authenticated)
 
-	a.router.POST("/transfers", a.createTransferHandler())
+	a.router.POST("/transfers", a.createTransferHandler(), authenticated)
+	a.router.GET("/transfers/{transfer_id}", a.findTransferByIDHandler(), authenticated)
+	a.router.POST("/transfers/{transfer_id}/reversals", a.reverseTransferHandler(), authenticated)
 
 	a.logger.WithFields(adapterlogger.Fields{"port": os.Getenv("APP_PORT")}).Infof("Starting HTTP Server")
 	a.router.SERVE(os.Getenv("APP_PORT"))
 }
 
-func (a HTTPServer) createTransferHandler() http.HandlerFunc {
-	authorizer := adapterhttp.NewAuthorizer(
-		infrahttp.NewClient(
-			infrahttp.NewRequest(
-				infrahttp.WithRetry(infrahttp.NewRetry(3, []int{http.StatusInternalServerError}, 400*time.Millisecond)),
-				infrahttp.WithTimeout(5*time.Second),
-			),
-		),
-		a.logger,
-	)
+// bootstrapDatabase creates the collections, validators and indexes of MongoDB and converts the legacy dates,
+// the server does not start without them
+func (a HTTPServer) bootstrapDatabase() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
 
-	notifier := adapterhttp.NewNotifier(
-		infrahttp.NewClient(
-			infrahttp.NewRequest(
-				infrahttp.WithRetry(infrahttp.NewRetry(3, []int{http.StatusInternalServerError}, 400*time.Millisecond)),
-				infrahttp.WithTimeout(5*time.Second),
-			),
-		),
-		adapterqueue.NewProducer(a.queue.Channel(), a.queue.Queue().Name, a.logger),
-		a.logger,
-	)
+	if err := a.database.Bootstrap(ctx); err != nil {
+		log.Fatalf("error bootstrapping the database: %v", err)
+	}
+}
+
+// startWorkers runs the outbox relay and the notify worker inside the server when the outbox or the queue are kept
+// in memory, the worker tells the messages already processed from the outbox of the server
+func (a HTTPServer) startWorkers() {
+	ctx := context.Background()
+
+	switch {
+	case a.broker != nil:
+		cfg := notifyWorkerConfig(a.broker.Queue().Name)
+		if err := a.broker.DeclareRetryQueues(cfg.RetryQueue, cfg.DeadLetterQueue); err != nil {
+			log.Fatalf("error declaring the retry queues: %v", err)
+		}
+
+		go consumeNotifyQueue(ctx, a.broker, cfg, a.repositories, a.logger)
+		go relayOutbox(
+			ctx,
+			a.repositories,
+			adapterqueue.NewOutboxPublisher(adapterqueue.NewProducer(a.broker, cfg.Queue, a.logger)),
+			cfg.Queue,
+			a.logger,
+		)
+	case a.rabbitMQ != nil:
+		cfg := notifyWorkerConfig(a.rabbitMQ.Queue().Name)
+		if err := a.rabbitMQ.DeclareRetryQueues(cfg.RetryQueue, cfg.DeadLetterQueue); err != nil {
+			log.Fatalf("error declaring the retry queues: %v", err)
+		}
+
+		// the confirmed producer of the relay owns its channel, the worker publishes its retries on another one
+		channel, err := a.rabbitMQ.Conn().Channel()
+		if err != nil {
+			log.Fatalf("error opening the notify worker channel: %v", err)
+		}
+
+		producer, err := adapterqueue.NewConfirmedProducer(a.rabbitMQ.Channel(), cfg.Queue, a.logger)
+		if err != nil {
+			log.Fatalf("error enabling publisher confirms: %v", err)
+		}
 
+		go consumeNotifyQueue(ctx, channel, cfg, a.repositories, a.logger)
+		go relayOutbox(ctx, a.repositories, adapterqueue.NewOutboxPublisher(producer), cfg.Queue, a.logger)
+	}
+}
+
+func (a HTTPServer) createTransferHandler() http.HandlerFunc {
 	uc := usecase.NewCreateTransferInteractor(
-		repository.NewCreateTransferRepository(a.database),
-		repository.NewUpdateUserWalletRepository(a.database),
-		repository.NewFindUserByIDUserRepository(a.database),
-		authorizer,
-		notifier,
+		a.repositories.transferCreator,
+		a.repositories.transferUpdater,
+		a.repositories.userUpdater,
+		a.repositories.userFinder,
+		a.repositories.ledgerCreator,
+		a.repositories.idempotencyCreator,
+		a.repositories.idempotencyFinder,
+		a.repositories.outboxCreator,
+		a.repositories.transferAggregator,
+		a.rates(),
+		a.limits(),
+		a.authorizer(),
+		a.events,
 		presenter.NewCreateTransferPresenter(),
 	)
 
 	return handler.NewCreateTransferHandler(uc, a.logger).Handle
 }
 
+// authorizer chains the authorizers listed in AUTHORIZER_CHAIN in order, "rules" reads AUTHORIZER_RULES_FILE and
+// "http" requests AUTHORIZER_URI, the http authorizer alone by default
+func (a HTTPServer) authorizer() usecase.Authorizer {
+	var names = os.Getenv("AUTHORIZER_CHAIN")
+	if names == "" {
+		names = "http"
+	}
+
+	var chain = []usecase.Authorizer{}
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "rules":
+			engine, err := rules.NewFileEngine(
+				os.Getenv("AUTHORIZER_RULES_FILE"),
+				a.repositories.userFinder,
+				a.repositories.transferAggregator,
+				a.logger,
+			)
+			if err != nil {
+				log.Fatalf("error loading authorizer rules: %v", err)
+			}
+			chain = append(chain, engine)
+		case "":
+		case "http":
+			chain = append(chain, adapterhttp.NewAuthorizer(
+				infrahttp.NewClient(
+					infrahttp.NewRequest(
+						infrahttp.WithRetry(
+							a.retry("authorizer").WithTransport(infrahttp.NewHostCircuitBreaker(a.breakers("authorizer"))),
+						),
+						infrahttp.WithTimeout(5*time.Second),
+					),
+				),
+				a.logger,
+			))
+		default:
+			log.Fatalf("unknown authorizer %q", name)
+		}
+	}
+
+	return rules.NewChain(chain...)
+}
+
+// retry returns the retry transport of an external service, the attempts that fail are logged
+func (a HTTPServer) retry(name string) *infrahttp.Retry {
+	return infrahttp.NewRetryWithPolicy(infrahttp.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     400 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		MaxElapsed:  4 * time.Second,
+		StatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		OnAttempt: func(attempt infrahttp.Attempt) {
+			if attempt.Err == nil && attempt.StatusCode < http.StatusInternalServerError &&
+				attempt.StatusCode != http.StatusTooManyRequests {
+				return
+			}
+
+			log := a.logger.WithFields(adapterlogger.Fields{
+				"key":         "http_retry",
+				"service":     name,
+				"attempt":     attempt.Number,
+				"http_status": attempt.StatusCode,
+				"elapsed":     attempt.Elapsed.String(),
+				"retry":       attempt.Retry,
+				"wait":        attempt.Wait.String(),
+			})
+			if attempt.Err != nil {
+				log = log.WithError(attempt.Err)
+			}
+			log.Warnf("http request attempt failed")
+		},
+	})
+}
+
+// breakers returns the circuit breakers of the hosts of an external service, every attempt of the retry is counted
+func (a HTTPServer) breakers(name string) *infrahttp.HostBreakers {
+	return infrahttp.NewHostBreakers(infrahttp.BreakerSettings{
+		Name:                name,
+		ConsecutiveFailures: 5,
+		FailureRate:         0.5,
+		MinRequests:         20,
+		Window:              30 * time.Second,
+		Buckets:             10,
+		OpenTimeout:         15 * time.Second,
+		HalfOpenMaxRequests: 2,
+		OnStateChange: func(name string, from, to infrahttp.BreakerState) {
+			a.logger.WithFields(adapterlogger.Fields{
+				"key":     "circuit_breaker",
+				"breaker": name,
+				"from":    from.String(),
+				"to":      to.String(),
+			}).Warnf("circuit breaker changed state")
+		},
+	})
+}
+
+func (a HTTPServer) reverseTransferHandler() http.HandlerFunc {
+	uc := usecase.NewReverseTransferInteractor(
+		a.repositories.transferCreator,
+		a.repositories.transferUpdater,
+		a.repositories.transferFinder,
+		a.repositories.userUpdater,
+		a.repositories.userFinder,
+		a.repositories.ledgerCreator,
+		a.repositories.outboxCreator,
+		a.events,
+		presenter.NewReverseTransferPresenter(),
+	)
+
+	return handler.NewReverseTransferHandler(uc, a.logger).Handle
+}
+
+// rates quotes the rates of the file in FX_RATES_FILE, without it only same currency transfers are possible
+func (a HTTPServer) rates() usecase.FXRateProvider {
+	path := os.Getenv("FX_RATES_FILE")
+	if path == "" {
+		return fx.NewStaticRateProvider()
+	}
+
+	rates, err := fx.NewFileRateProvider(path)
+	if err != nil {
+		log.Fatalf("error loading exchange rates: %v", err)
+	}
+
+	return rates
+}
+
+// limits enforces the limits of the file in TRANSFER_LIMITS_FILE, without it the transfers are unlimited
+func (a HTTPServer) limits() usecase.TransferLimitProvider {
+	path := os.Getenv("TRANSFER_LIMITS_FILE")
+	if path == "" {
+		return limits.NewStaticLimitProvider()
+	}
+
+	l, err := limits.NewFileLimitProvider(path)
+	if err != nil {
+		log.Fatalf("error loading transfer limits: %v", err)
+	}
+
+	return l
+}
+
+func (a HTTPServer) findTransferByIDHandler() http.HandlerFunc {
+	uc := usecase.NewFindTransferByIDInteractor(
+		a.repositories.transferFinder,
+		presenter.NewFindTransferByIDPresenter())
+
+	return handler.NewFindTransferByIDHandler(uc, a.logger).Handle
+}
+
+func (a HTTPServer) findTransfersByUserHandler() http.HandlerFunc {
+	uc := usecase.NewFindTransfersByUserInteractor(
+		a.repositories.userFinder,
+		a.repositories.transferFinder,
+		presenter.NewFindTransfersByUserPresenter())
+
+	return handler.NewFindTransfersByUserHandler(uc, a.logger).Handle
+}
+
 func (a HTTPServer) createUserHandler() http.HandlerFunc {
 	uc := usecase.NewCreateUserInteractor(
-		repository.NewCreateUserRepository(a.database),
+		a.repositories.userCreator,
+		a.repositories.ledgerCreator,
+		a.passwordHasher(),
+		a.events,
 		presenter.NewCreateUserPresenter())
 
 	return handler.NewCreateUserHandler(uc, a.logger).Handle
 }
 
+func (a HTTPServer) loginHandler(tokens usecase.TokenIssuer) http.HandlerFunc {
+	uc := usecase.NewLoginInteractor(
+		a.repositories.userFinder,
+		a.repositories.userUpdater,
+		a.repositories.refreshTokenCreator,
+		a.passwordHasher(),
+		tokens,
+		presenter.NewTokenPresenter())
+
+	return handler.NewLoginHandler(uc, a.logger).Handle
+}
+
+func (a HTTPServer) refreshTokenHandler(tokens usecase.TokenIssuer) http.HandlerFunc {
+	uc := usecase.NewRefreshTokenInteractor(
+		a.repositories.refreshTokenCreator,
+		a.repositories.refreshTokenFinder,
+		a.repositories.refreshTokenRevoker,
+		a.repositories.userFinder,
+		tokens,
+		presenter.NewTokenPresenter())
+
+	return handler.NewRefreshTokenHandler(uc, a.logger).Handle
+}
+
+// tokens signs access tokens with JWT_SECRET, the server does not start without a valid secret. A random secret is only
+// used with the JWT_EPHEMERAL_SECRET=true opt-in for development, its tokens do not survive a restart
+func (a HTTPServer) tokens() security.JWT {
+	cfg := security.JWTConfig{
+		Secret:     []byte(os.Getenv("JWT_SECRET")),
+		Issuer:     "urban-octo-fortnight",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 30 * 24 * time.Hour,
+	}
+	if v := os.Getenv("JWT_ACCESS_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("error loading JWT_ACCESS_TTL: %v", err)
+		}
+		cfg.AccessTTL = ttl
+	}
+	if v := os.Getenv("JWT_REFRESH_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("error loading JWT_REFRESH_TTL: %v", err)
+		}
+		cfg.RefreshTTL = ttl
+	}
+
+	if len(cfg.Secret) == 0 && os.Getenv("JWT_EPHEMERAL_SECRET") == "true" {
+		a.logger.Warnf("signing tokens with a random secret, they do not survive a restart nor work on other instances")
+
+		cfg.Secret = make([]byte, 32)
+		_, _ = rand.Read(cfg.Secret)
+	}
+
+	tokens, err := security.NewJWT(cfg)
+	if err != nil {
+		log.Fatalf("error loading jwt config: %v", err)
+	}
+
+	return tokens
+}
+
+// passwordHasher hashes with PASSWORD_HASH_ALGORITHM (argon2id or bcrypt), argon2id by default. The server does not
+// start with an invalid config
+func (a HTTPServer) passwordHasher() usecase.PasswordHasher {
+	cfg := security.DefaultConfig()
+	if algorithm := os.Getenv("PASSWORD_HASH_ALGORITHM"); algorithm != "" {
+		cfg.Algorithm = security.Algorithm(algorithm)
+	}
+	if v := os.Getenv("PASSWORD_BCRYPT_COST"); v != "" {
+		cost, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("error loading PASSWORD_BCRYPT_COST: %v", err)
+		}
+		cfg.BcryptCost = cost
+	}
+
+	hasher, err := security.NewPasswordHasher(cfg)
+	if err != nil {
+		log.Fatalf("error loading password hasher config: %v", err)
+	}
+
+	return hasher
+}
+
 func (a HTTPServer) findUserByIDHandler() http.HandlerFunc {
 	uc := usecase.NewFindUserByIDInteractor(
-		repository.NewFindUserByIDUserRepository(a.database),
+		a.repositories.userFinder,
 		presenter.NewFindUserByIDPresenter())
 
 	return handler.NewFindUserByIDHandler(uc, a.logger).Handle
 }
 
+func (a HTTPServer) reconcileWalletHandler() http.HandlerFunc {
+	uc := usecase.NewReconcileWalletInteractor(
+		a.repositories.userFinder,
+		a.repositories.ledgerFinder,
+		presenter.NewReconcileWalletPresenter())
+
+	return handler.NewReconcileWalletHandler(uc, a.logger).Handle
+}
+
+// logEvent keeps a trace of the domain events in the logs
+func (a HTTPServer) logEvent(_ context.Context, event entity.Event) error {
+	a.logger.WithFields(adapterlogger.Fields{
+		"key":         "domain_event",
+		"event":       event.EventName(),
+		"occurred_at": event.OccurredAt(),
+	}).Infof("domain event")
+
+	return nil
+}
+
+// newEventBus creates the in-process event bus, logging the failures of its subscribers
+func newEventBus(log adapterlogger.Logger) *usecase.EventBus {
+	return usecase.NewEventBus(func(event entity.Event, err error) {
+		log.WithFields(adapterlogger.Fields{
+			"key":   "domain_event",
+			"event": event.EventName(),
+			"error": err.Error(),
+		}).Errorf("error handling domain event")
+	})
+}
+
 func healthCheck(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
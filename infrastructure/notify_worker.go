@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	adapterhttp "github.com/ofiliobi/urban-octo-fortnight/adapter/http"
+	adapterlogger "github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	adapterqueue "github.com/ofiliobi/urban-octo-fortnight/adapter/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	infrahttp "github.com/ofiliobi/urban-octo-fortnight/infrastructure/http"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+// NotifyWorker define the worker sending the notifications of the transfers relayed from the outbox and
+// retrying the ones that failed, the outbox tells the transfer messages already processed
+type NotifyWorker struct {
+	repositories repositories
+	logger       adapterlogger.Logger
+	queue        *queue.RabbitMQHandler
+}
+
+// NewNotifyWorker creates new NotifyWorker with its dependencies, with a memory backend the worker runs
+// inside the HTTP server instead
+func NewNotifyWorker() *NotifyWorker {
+	if databaseBackend() == memoryBackend || queueBackend() == memoryBackend {
+		log.Fatalf("the notify worker runs inside the HTTP server with the memory backends")
+	}
+
+	worker := &NotifyWorker{
+		logger: logger.NewLogrus(),
+		queue:  queue.NewRabbitMQHandler(),
+	}
+
+	switch databaseBackend() {
+	case postgresBackend, sqliteBackend:
+		worker.repositories = newSQLRepositories(openSQLDatabase(databaseBackend(), worker.logger))
+	default:
+		worker.repositories = newMongoRepositories(database.NewMongoHandler())
+	}
+
+	return worker
+}
+
+// Start consumes the notify queue until SIGINT or SIGTERM
+func (w NotifyWorker) Start() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := notifyWorkerConfig(w.queue.Queue().Name)
+	if err := w.queue.DeclareRetryQueues(cfg.RetryQueue, cfg.DeadLetterQueue); err != nil {
+		w.logger.WithError(err).Errorf("error declaring the retry queues")
+		return
+	}
+
+	consumeNotifyQueue(ctx, w.queue.Channel(), cfg, w.repositories, w.logger)
+
+	if err := w.queue.Conn().Close(); err != nil {
+		w.logger.WithError(err).Errorf("error closing the rabbitmq connection")
+	}
+}
+
+// consumeNotifyQueue consumes the notify queue of the channel until ctx is done
+func consumeNotifyQueue(
+	ctx context.Context,
+	ch adapterqueue.Channel,
+	cfg adapterqueue.ConsumerConfig,
+	repos repositories,
+	log adapterlogger.Logger,
+) {
+	client := infrahttp.NewClient(
+		infrahttp.NewRequest(
+			infrahttp.WithTimeout(5 * time.Second),
+		),
+	)
+
+	// the notifier publishes the notifications that fail to the notify queue, they come back as retries
+	events := newEventBus(log)
+	events.Subscribe(entity.TransferCompletedEvent, usecase.NewNotifyTransferSubscriber(
+		adapterhttp.NewNotifier(
+			client,
+			adapterqueue.NewProducer(ch, cfg.Queue, log),
+			log,
+		),
+	))
+
+	consumer := adapterqueue.NewConsumer(
+		ch,
+		cfg,
+		adapterhttp.NewNotifyRetry(client, repos.outboxFinder, repos.outboxUpdater, events, log),
+		log,
+	)
+
+	log.WithFields(adapterlogger.Fields{"queue": cfg.Queue}).Infof("Starting notify worker")
+	if err := consumer.Consume(ctx); err != nil {
+		log.WithError(err).Errorf("error consuming the notify queue")
+	}
+}
+
+// notifyWorkerConfig reads NOTIFY_WORKER_* variables, the unset ones keep their defaults
+func notifyWorkerConfig(queue string) adapterqueue.ConsumerConfig {
+	cfg := adapterqueue.ConsumerConfig{
+		Queue:           queue,
+		RetryQueue:      queue + ".retry",
+		DeadLetterQueue: queue + ".dlq",
+		Prefetch:        10,
+		Concurrency:     4,
+		MaxAttempts:     5,
+		Backoff:         time.Second,
+		MaxBackoff:      5 * time.Minute,
+		Timeout:         30 * time.Second,
+	}
+	if v, err := strconv.Atoi(os.Getenv("NOTIFY_WORKER_PREFETCH")); err == nil {
+		cfg.Prefetch = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("NOTIFY_WORKER_CONCURRENCY")); err == nil {
+		cfg.Concurrency = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("NOTIFY_WORKER_MAX_ATTEMPTS")); err == nil {
+		cfg.MaxAttempts = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("NOTIFY_WORKER_BACKOFF")); err == nil {
+		cfg.Backoff = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("NOTIFY_WORKER_MAX_BACKOFF")); err == nil {
+		cfg.MaxBackoff = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("NOTIFY_WORKER_TIMEOUT")); err == nil {
+		cfg.Timeout = v
+	}
+
+	return cfg
+}
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	adapterlogger "github.com/ofiliobi/urban-octo-fortnight/adapter/logger"
+	adapterqueue "github.com/ofiliobi/urban-octo-fortnight/adapter/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/database"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/logger"
+	"github.com/ofiliobi/urban-octo-fortnight/infrastructure/queue"
+	"github.com/ofiliobi/urban-octo-fortnight/usecase"
+)
+
+// OutboxRelay define the process publishing the outbox messages to the notify queue
+type OutboxRelay struct {
+	repositories repositories
+	logger       adapterlogger.Logger
+	queue        *queue.RabbitMQHandler
+}
+
+// NewOutboxRelay creates new OutboxRelay with its dependencies, with a memory backend the relay runs inside the
+// HTTP server instead
+func NewOutboxRelay() *OutboxRelay {
+	if databaseBackend() == memoryBackend || queueBackend() == memoryBackend {
+		log.Fatalf("the outbox relay runs inside the HTTP server with the memory backends")
+	}
+
+	relay := &OutboxRelay{
+		logger: logger.NewLogrus(),
+		queue:  queue.NewRabbitMQHandler(),
+	}
+
+	switch databaseBackend() {
+	case postgresBackend, sqliteBackend:
+		relay.repositories = newSQLRepositories(openSQLDatabase(databaseBackend(), relay.logger))
+	default:
+		relay.repositories = newMongoRepositories(database.NewMongoHandler())
+	}
+
+	return relay
+}
+
+// Start relays the outbox until SIGINT or SIGTERM
+func (o OutboxRelay) Start() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	producer, err := adapterqueue.NewConfirmedProducer(o.queue.Channel(), o.queue.Queue().Name, o.logger)
+	if err != nil {
+		o.logger.WithError(err).Errorf("error enabling publisher confirms")
+		return
+	}
+
+	relayOutbox(ctx, o.repositories, adapterqueue.NewOutboxPublisher(producer), o.queue.Queue().Name, o.logger)
+}
+
+// relayOutbox polls the outbox every OUTBOX_RELAY_INTERVAL until ctx is done, a full batch is followed
+// by the next one at once. Running a single relay keeps the messages in order
+func relayOutbox(
+	ctx context.Context,
+	repos repositories,
+	publisher usecase.OutboxPublisher,
+	queueName string,
+	log adapterlogger.Logger,
+) {
+	var (
+		interval = time.Second
+		limit    = 100
+	)
+	if v, err := time.ParseDuration(os.Getenv("OUTBOX_RELAY_INTERVAL")); err == nil && v > 0 {
+		interval = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("OUTBOX_RELAY_BATCH_SIZE")); err == nil && v > 0 {
+		limit = v
+	}
+
+	uc := usecase.NewRelayOutboxInteractor(repos.outboxFinder, repos.outboxUpdater, publisher)
+
+	log.WithFields(adapterlogger.Fields{"queue": queueName}).Infof("Starting outbox relay")
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("outbox relay stopped")
+			return
+		case <-timer.C:
+		}
+
+		output, err := uc.Execute(ctx, usecase.RelayOutboxInput{Limit: limit, PublishedAt: time.Now()})
+		if err != nil {
+			log.WithError(err).Errorf("error relaying the outbox")
+		}
+
+		next := interval
+		if err == nil && output.Published == limit {
+			next = 0
+		}
+		timer.Reset(next)
+	}
+}
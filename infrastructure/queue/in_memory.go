@@ -0,0 +1,231 @@
+package queue
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+var errQueueNotDeclared = errors.New("queue not declared")
+
+type (
+	// InMemoryHandler defines a broker keeping the queues in the memory of the process, it stands in for RabbitMQ
+	// when the server runs without external services and the messages are lost on restart. Like the default
+	// exchange it routes a message to the queue named by its key, and a queue declared with a dead-letter queue
+	// moves its messages there once they expire
+	InMemoryHandler struct {
+		mu        sync.Mutex
+		queue     amqp.Queue
+		queues    map[string]*memoryQueue
+		consumers map[string]chan struct{}
+		tag       uint64
+	}
+
+	memoryQueue struct {
+		name       string
+		deadLetter string
+		messages   []amqp.Delivery
+		ready      chan struct{}
+	}
+
+	// memoryAcknowledger puts the messages nacked or rejected with requeue back in their queue
+	memoryAcknowledger struct {
+		handler *InMemoryHandler
+		queue   string
+		msg     amqp.Publishing
+	}
+)
+
+// NewInMemoryHandler creates new InMemoryHandler with the notify queue declared
+func NewInMemoryHandler() *InMemoryHandler {
+	h := &InMemoryHandler{
+		queue:     amqp.Queue{Name: NotifyQueue},
+		queues:    make(map[string]*memoryQueue),
+		consumers: make(map[string]chan struct{}),
+	}
+	h.declare(h.queue.Name, "")
+
+	return h
+}
+
+// Queue returns the queue property
+func (h *InMemoryHandler) Queue() amqp.Queue {
+	return h.queue
+}
+
+// DeclareRetryQueues declares the queue holding the messages waiting for a retry, which moves them back to the
+// queue once they expire, and the queue of the messages that ran out of attempts
+func (h *InMemoryHandler) DeclareRetryQueues(retryQueue, deadLetterQueue string) error {
+	h.declare(retryQueue, h.queue.Name)
+	h.declare(deadLetterQueue, "")
+
+	return nil
+}
+
+func (h *InMemoryHandler) declare(name string, deadLetter string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.queues[name]; ok {
+		return
+	}
+
+	h.queues[name] = &memoryQueue{
+		name:       name,
+		deadLetter: deadLetter,
+		ready:      make(chan struct{}, 1),
+	}
+}
+
+// Qos is a no-op, the messages are handed over one at a time
+func (h *InMemoryHandler) Qos(int, int, bool) error {
+	return nil
+}
+
+// Publish puts the message in the queue named by key, a message with an expiration published to a queue with
+// a dead-letter queue is moved there once it expires
+func (h *InMemoryHandler) Publish(_ string, key string, _ bool, _ bool, msg amqp.Publishing) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	q, ok := h.queues[key]
+	if !ok {
+		return errQueueNotDeclared
+	}
+
+	if q.deadLetter != "" && msg.Expiration != "" {
+		ttl, err := strconv.ParseInt(msg.Expiration, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		msg.Expiration = ""
+		time.AfterFunc(time.Duration(ttl)*time.Millisecond, func() {
+			_ = h.Publish("", q.deadLetter, false, false, msg)
+		})
+
+		return nil
+	}
+
+	h.tag++
+	q.messages = append(q.messages, amqp.Delivery{
+		Acknowledger: memoryAcknowledger{handler: h, queue: q.name, msg: msg},
+		Headers:      msg.Headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: msg.DeliveryMode,
+		MessageId:    msg.MessageId,
+		DeliveryTag:  h.tag,
+		RoutingKey:   key,
+		Body:         msg.Body,
+	})
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Consume delivers the messages of the queue until the consumer is canceled, the deliveries are closed then
+func (h *InMemoryHandler) Consume(
+	queue string,
+	consumer string,
+	_ bool,
+	_ bool,
+	_ bool,
+	_ bool,
+	_ amqp.Table,
+) (<-chan amqp.Delivery, error) {
+	h.mu.Lock()
+	q, ok := h.queues[queue]
+	if !ok {
+		h.mu.Unlock()
+		return nil, errQueueNotDeclared
+	}
+
+	var stop = make(chan struct{})
+	h.consumers[consumer] = stop
+	h.mu.Unlock()
+
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+		for {
+			d, ok := h.pop(q)
+			if !ok {
+				select {
+				case <-q.ready:
+					continue
+				case <-stop:
+					return
+				}
+			}
+
+			select {
+			case deliveries <- d:
+			case <-stop:
+				h.unshift(q, d)
+				return
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// Cancel stops the deliveries of the consumer
+func (h *InMemoryHandler) Cancel(consumer string, _ bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if stop, ok := h.consumers[consumer]; ok {
+		close(stop)
+		delete(h.consumers, consumer)
+	}
+
+	return nil
+}
+
+func (h *InMemoryHandler) pop(q *memoryQueue) (amqp.Delivery, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(q.messages) == 0 {
+		return amqp.Delivery{}, false
+	}
+
+	d := q.messages[0]
+	q.messages = q.messages[1:]
+
+	return d, true
+}
+
+func (h *InMemoryHandler) unshift(q *memoryQueue, d amqp.Delivery) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	q.messages = append([]amqp.Delivery{d}, q.messages...)
+}
+
+// Ack is a no-op, a message is removed from its queue once delivered
+func (a memoryAcknowledger) Ack(uint64, bool) error {
+	return nil
+}
+
+// Nack puts the message back in its queue when requeue is set
+func (a memoryAcknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	if !requeue {
+		return nil
+	}
+
+	return a.handler.Publish("", a.queue, false, false, a.msg)
+}
+
+// Reject puts the message back in its queue when requeue is set
+func (a memoryAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
@@ -0,0 +1,65 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+func TestInMemoryHandler_Consume(t *testing.T) {
+	var h = NewInMemoryHandler()
+	if err := h.DeclareRetryQueues("notify.retry", "notify.dlq"); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := h.Consume(NotifyQueue, "consumer", false, false, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		msg  amqp.Publishing
+	}{
+		{
+			name: "Message published to the queue",
+			key:  NotifyQueue,
+			msg:  amqp.Publishing{MessageId: "1", Body: []byte(`{"id":"1"}`)},
+		},
+		{
+			name: "Retry moved to the queue once expired",
+			key:  "notify.retry",
+			msg:  amqp.Publishing{MessageId: "2", Body: []byte(`{"id":"2"}`), Expiration: "10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := h.Publish("", tt.key, false, false, tt.msg); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case d := <-deliveries:
+				if d.MessageId != tt.msg.MessageId {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, d.MessageId, tt.msg.MessageId)
+				}
+			case <-time.After(time.Second):
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, nil, tt.msg.MessageId)
+			}
+		})
+	}
+
+	if err := h.Cancel("consumer", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := <-deliveries; ok {
+		t.Errorf("[TestCase 'Deliveries closed on cancel'] Got: '%v' | Want: '%v'", ok, false)
+	}
+
+	if err := h.Publish("", "unknown", false, false, amqp.Publishing{}); err != errQueueNotDeclared {
+		t.Errorf("[TestCase 'Unknown queue'] Err: '%v' | WantErr: '%v'", err, errQueueNotDeclared)
+	}
+}
@@ -7,6 +7,16 @@ import (
 	"github.com/streadway/amqp"
 )
 
+// NotifyQueue is the durable queue of the transfer notifications. The former "notify" queue was declared
+// non-durable and RabbitMQ refuses to redeclare an existing queue as durable (PRECONDITION_FAILED), so the
+// durable queue has its own name. On upgrade, the messages left in "notify" are moved with the shovel plugin
+// and the legacy queue is deleted once empty:
+//
+//	rabbitmqctl set_parameter shovel notify-migration '{"src-uri":"amqp://","src-queue":"notify",
+//	  "dest-uri":"amqp://","dest-queue":"notify.durable","src-delete-after":"queue-length"}'
+//	rabbitmqctl delete_queue notify
+const NotifyQueue = "notify.durable"
+
 // RabbitMQHandler defines the RabbitMQ handler
 type RabbitMQHandler struct {
 	conn		*amqp.Connection
@@ -26,9 +36,10 @@ func NewRabbitMQHandler() *RabbitMQHandler {
 		log.Fatalln(err)
 	}
 
+	// durable, the messages relayed from the outbox must survive a restart of the server
 	queue, err := channel.QueueDeclare(
-		"notify",
-		false,
+		NotifyQueue,
+		true,
 		false,
 		false,
 		false,
@@ -58,3 +69,32 @@ func (r RabbitMQHandler) Queue() amqp.Queue {
 func (r RabbitMQHandler) Channel() *amqp.Channel {
 	return r.channel
 }
+
+// DeclareRetryQueues declares the queue holding the messages waiting for a retry, which dead-letters
+// them back to the queue once they expire, and the queue of the messages that ran out of attempts
+func (r RabbitMQHandler) DeclareRetryQueues(retryQueue, deadLetterQueue string) error {
+	if _, err := r.channel.QueueDeclare(
+		retryQueue,
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": r.queue.Name,
+		},
+	); err != nil {
+		return err
+	}
+
+	_, err := r.channel.QueueDeclare(
+		deadLetterQueue,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+
+	return err
+}
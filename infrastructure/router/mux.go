@@ -20,12 +20,21 @@ func NewMux() *Mux {
 	}
 }
 
-func (m *Mux) GET(uri string, f func(w http.ResponseWriter, r *http.Request)) {
-	m.router.HandleFunc(uri, f).Methods(http.MethodGet)
+func (m *Mux) GET(uri string, f func(w http.ResponseWriter, r *http.Request), middlewares ...Middleware) {
+	m.router.Handle(uri, chain(f, middlewares)).Methods(http.MethodGet)
 }
 
-func (m *Mux) POST(uri string, f func(w http.ResponseWriter, r *http.Request)) {
-	m.router.HandleFunc(uri, f).Methods(http.MethodPost)
+func (m *Mux) POST(uri string, f func(w http.ResponseWriter, r *http.Request), middlewares ...Middleware) {
+	m.router.Handle(uri, chain(f, middlewares)).Methods(http.MethodPost)
+}
+
+// chain wraps the handler with the middlewares, the first middleware is the outermost one
+func chain(f func(w http.ResponseWriter, r *http.Request), middlewares []Middleware) http.Handler {
+	var h http.Handler = http.HandlerFunc(f)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
 }
 
 func (m *Mux) SERVE(port string) {
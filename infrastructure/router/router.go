@@ -2,8 +2,11 @@ package router
 
 import "net/http"
 
+// Middleware wraps the handler of a route
+type Middleware func(http.Handler) http.Handler
+
 type Router interface {
-	GET(uri string, f func(w http.ResponseWriter, r *http.Request))
-	POST(uri string, f func(w http.ResponseWriter, r *http.Request))
+	GET(uri string, f func(w http.ResponseWriter, r *http.Request), middlewares ...Middleware)
+	POST(uri string, f func(w http.ResponseWriter, r *http.Request), middlewares ...Middleware)
 	SERVE(port string)
 }
\ No newline at end of file
@@ -0,0 +1,106 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+const (
+	minJWTSecretLength = 32
+	refreshTokenLength = 32
+
+	// placeholderJWTSecret is the example secret of the docs, anyone can sign tokens with it
+	placeholderJWTSecret = "change-me-to-a-random-secret-of-32-bytes"
+)
+
+var (
+	ErrInvalidJWTConfig = errors.New("invalid jwt config, the secret must be a random value of at least 32 bytes")
+
+	ErrInvalidAccessToken = errors.New("invalid access token")
+)
+
+type (
+	// JWTConfig defines the HMAC secret and the lifetime of the tokens
+	JWTConfig struct {
+		Secret     []byte
+		Issuer     string
+		AccessTTL  time.Duration
+		RefreshTTL time.Duration
+	}
+
+	// JWT signs HS256 access tokens and generates opaque refresh tokens
+	JWT struct {
+		cfg JWTConfig
+	}
+)
+
+// NewJWT creates new JWT with its config
+func NewJWT(cfg JWTConfig) (JWT, error) {
+	if len(cfg.Secret) < minJWTSecretLength || string(cfg.Secret) == placeholderJWTSecret || cfg.AccessTTL <= 0 || cfg.RefreshTTL <= 0 {
+		return JWT{}, ErrInvalidJWTConfig
+	}
+
+	return JWT{cfg: cfg}, nil
+}
+
+// IssueAccessToken signs an access token whose subject is the user ID
+func (j JWT) IssueAccessToken(userID vo.Uuid, issuedAt time.Time) (string, time.Time, error) {
+	var (
+		expiresAt = issuedAt.Add(j.cfg.AccessTTL)
+		claims    = jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    j.cfg.Issuer,
+			Subject:   userID.Value(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		}
+	)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.cfg.Secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// IssueRefreshToken generates a random refresh token
+func (j JWT) IssueRefreshToken(issuedAt time.Time) (string, time.Time, error) {
+	var b = make([]byte, refreshTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), issuedAt.Add(j.cfg.RefreshTTL), nil
+}
+
+// Parse validates the signature, issuer and expiration of the access token and returns its subject
+func (j JWT) Parse(token string) (vo.Uuid, error) {
+	var claims jwt.RegisteredClaims
+
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(*jwt.Token) (interface{}, error) { return j.cfg.Secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(j.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return vo.Uuid{}, ErrInvalidAccessToken
+	}
+
+	userID, err := vo.NewUuid(claims.Subject)
+	if err != nil {
+		return vo.Uuid{}, ErrInvalidAccessToken
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,96 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func TestJWT_Parse(t *testing.T) {
+	var (
+		cfg = JWTConfig{
+			Secret:     []byte("0123456789abcdef0123456789abcdef"),
+			Issuer:     "urban-octo-fortnight",
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: time.Hour,
+		}
+		other = JWTConfig{
+			Secret:     []byte("fedcba9876543210fedcba9876543210"),
+			Issuer:     cfg.Issuer,
+			AccessTTL:  cfg.AccessTTL,
+			RefreshTTL: cfg.RefreshTTL,
+		}
+		otherIssuer = JWTConfig{
+			Secret:     cfg.Secret,
+			Issuer:     "another",
+			AccessTTL:  cfg.AccessTTL,
+			RefreshTTL: cfg.RefreshTTL,
+		}
+	)
+
+	tests := []struct {
+		name     string
+		signer   JWTConfig
+		issuedAt time.Time
+		wantErr  error
+	}{
+		{
+			name:     "Valid access token",
+			signer:   cfg,
+			issuedAt: time.Now(),
+		},
+		{
+			name:     "Expired access token",
+			signer:   cfg,
+			issuedAt: time.Now().Add(-time.Hour),
+			wantErr:  ErrInvalidAccessToken,
+		},
+		{
+			name:     "Access token signed with another secret",
+			signer:   other,
+			issuedAt: time.Now(),
+			wantErr:  ErrInvalidAccessToken,
+		},
+		{
+			name:     "Access token of another issuer",
+			signer:   otherIssuer,
+			issuedAt: time.Now(),
+			wantErr:  ErrInvalidAccessToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewJWT(tt.signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, _, err := signer.IssueAccessToken(vo.NewUuidStaticTest(), tt.issuedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parser, _ := NewJWT(cfg)
+			got, err := parser.Parse(token)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr == nil && got != vo.NewUuidStaticTest() {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, vo.NewUuidStaticTest())
+			}
+		})
+	}
+}
+
+func TestNewJWT(t *testing.T) {
+	if _, err := NewJWT(JWTConfig{Secret: []byte("short"), AccessTTL: time.Minute, RefreshTTL: time.Hour}); err != ErrInvalidJWTConfig {
+		t.Errorf("[TestCase 'Short secret'] Got: '%v' | Want: '%v'", err, ErrInvalidJWTConfig)
+	}
+
+	if _, err := NewJWT(JWTConfig{Secret: []byte(placeholderJWTSecret), AccessTTL: time.Minute, RefreshTTL: time.Hour}); err != ErrInvalidJWTConfig {
+		t.Errorf("[TestCase 'Placeholder secret'] Got: '%v' | Want: '%v'", err, ErrInvalidJWTConfig)
+	}
+}
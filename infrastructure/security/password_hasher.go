@@ -0,0 +1,211 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+const (
+	Argon2id Algorithm = "argon2id"
+	Bcrypt   Algorithm = "bcrypt"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hash algorithm")
+
+	ErrInvalidHasherConfig = errors.New("invalid password hasher config")
+)
+
+type (
+	// Algorithm defines the password hash algorithm
+	Algorithm string
+
+	// Config defines the algorithm used to hash new passwords and its cost
+	Config struct {
+		Algorithm     Algorithm
+		BcryptCost    int
+		Argon2Time    uint32
+		Argon2Memory  uint32 // in KiB
+		Argon2Threads uint8
+	}
+
+	// PasswordHasher hashes passwords with the configured algorithm and verifies hashes of any supported algorithm,
+	// so hashes made with an old algorithm or cost keep working until they are rehashed
+	PasswordHasher struct {
+		cfg Config
+	}
+
+	argon2Params struct {
+		time    uint32
+		memory  uint32
+		threads uint8
+		salt    []byte
+		key     []byte
+	}
+)
+
+// DefaultConfig returns argon2id with the parameters recommended by RFC 9106 for memory-constrained environments
+func DefaultConfig() Config {
+	return Config{
+		Algorithm:     Argon2id,
+		BcryptCost:    12,
+		Argon2Time:    3,
+		Argon2Memory:  64 * 1024,
+		Argon2Threads: 2,
+	}
+}
+
+// NewPasswordHasher creates new PasswordHasher with its config
+func NewPasswordHasher(cfg Config) (PasswordHasher, error) {
+	switch cfg.Algorithm {
+	case Bcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return PasswordHasher{}, ErrInvalidHasherConfig
+		}
+	case Argon2id:
+		if cfg.Argon2Time == 0 || cfg.Argon2Memory == 0 || cfg.Argon2Threads == 0 {
+			return PasswordHasher{}, ErrInvalidHasherConfig
+		}
+	default:
+		return PasswordHasher{}, ErrUnsupportedAlgorithm
+	}
+
+	return PasswordHasher{cfg: cfg}, nil
+}
+
+// Hash hashes the password with the configured algorithm
+func (h PasswordHasher) Hash(password vo.Password) (vo.HashedPassword, error) {
+	var (
+		hash string
+		err  error
+	)
+
+	switch h.cfg.Algorithm {
+	case Bcrypt:
+		var b []byte
+		b, err = bcrypt.GenerateFromPassword([]byte(password.Value()), h.cfg.BcryptCost)
+		hash = string(b)
+	case Argon2id:
+		hash, err = h.argon2id(password.Value())
+	default:
+		err = ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return vo.HashedPassword{}, err
+	}
+
+	return vo.NewHashedPassword(hash)
+}
+
+// Verify checks the password against the hash in constant time, a mismatch is not an error
+func (h PasswordHasher) Verify(password string, hash vo.HashedPassword) (bool, error) {
+	switch algorithm(hash) {
+	case Bcrypt:
+		err := bcrypt.CompareHashAndPassword([]byte(hash.Value()), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return err == nil, err
+	case Argon2id:
+		p, err := parseArgon2id(hash.Value())
+		if err != nil {
+			return false, err
+		}
+
+		var key = argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+		return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+	}
+
+	return false, ErrUnsupportedAlgorithm
+}
+
+// NeedsRehash reports whether the hash was made with another algorithm or cost than the configured ones,
+// callers should rehash the password right after a successful Verify
+func (h PasswordHasher) NeedsRehash(hash vo.HashedPassword) bool {
+	if algorithm(hash) != h.cfg.Algorithm {
+		return true
+	}
+
+	switch h.cfg.Algorithm {
+	case Bcrypt:
+		cost, err := bcrypt.Cost([]byte(hash.Value()))
+		return err != nil || cost != h.cfg.BcryptCost
+	case Argon2id:
+		p, err := parseArgon2id(hash.Value())
+		return err != nil ||
+			p.time != h.cfg.Argon2Time ||
+			p.memory != h.cfg.Argon2Memory ||
+			p.threads != h.cfg.Argon2Threads
+	}
+
+	return true
+}
+
+func (h PasswordHasher) argon2id(password string) (string, error) {
+	var salt = make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	var key = argon2.IDKey([]byte(password), salt, h.cfg.Argon2Time, h.cfg.Argon2Memory, h.cfg.Argon2Threads, argon2KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.cfg.Argon2Memory,
+		h.cfg.Argon2Time,
+		h.cfg.Argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// parseArgon2id parses the PHC string format "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>"
+func parseArgon2id(hash string) (argon2Params, error) {
+	var parts = strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != string(Argon2id) {
+		return argon2Params{}, vo.ErrInvalidHashedPassword
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2Params{}, vo.ErrInvalidHashedPassword
+	}
+
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return argon2Params{}, vo.ErrInvalidHashedPassword
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2Params{}, vo.ErrInvalidHashedPassword
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return argon2Params{}, vo.ErrInvalidHashedPassword
+	}
+
+	return p, nil
+}
+
+func algorithm(hash vo.HashedPassword) Algorithm {
+	switch hash.Algorithm() {
+	case "2a", "2b", "2y":
+		return Bcrypt
+	case string(Argon2id):
+		return Argon2id
+	}
+	return ""
+}
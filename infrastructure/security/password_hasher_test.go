@@ -0,0 +1,143 @@
+package security
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+var (
+	bcryptConfig = Config{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost}
+
+	argon2idConfig = Config{Algorithm: Argon2id, Argon2Time: 1, Argon2Memory: 1024, Argon2Threads: 1}
+)
+
+func TestPasswordHasher_Verify(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           Config
+		password      string
+		wantAlgorithm string
+	}{
+		{
+			name:          "bcrypt",
+			cfg:           bcryptConfig,
+			password:      "Passw0rd",
+			wantAlgorithm: "2a",
+		},
+		{
+			name:          "argon2id",
+			cfg:           argon2idConfig,
+			password:      "Passw0rd",
+			wantAlgorithm: "argon2id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewPasswordHasher(tt.cfg)
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Err: '%v'", tt.name, err)
+			}
+
+			hash, err := h.Hash(vo.NewPasswordTest(tt.password))
+			if err != nil {
+				t.Fatalf("[TestCase '%s'] Err: '%v'", tt.name, err)
+			}
+
+			if hash.Algorithm() != tt.wantAlgorithm {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, hash.Algorithm(), tt.wantAlgorithm)
+			}
+
+			if ok, err := h.Verify(tt.password, hash); !ok || err != nil {
+				t.Errorf("[TestCase '%s'] Got: '%v' '%v' | Want: 'true' '<nil>'", tt.name, ok, err)
+			}
+
+			if ok, err := h.Verify("Wr0ngPassword", hash); ok || err != nil {
+				t.Errorf("[TestCase '%s'] Got: '%v' '%v' | Want: 'false' '<nil>'", tt.name, ok, err)
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	var (
+		bcryptHasher, _   = NewPasswordHasher(bcryptConfig)
+		argon2idHasher, _ = NewPasswordHasher(argon2idConfig)
+		bcryptHash, _     = bcryptHasher.Hash(vo.NewPasswordTest("Passw0rd"))
+		argon2idHash, _   = argon2idHasher.Hash(vo.NewPasswordTest("Passw0rd"))
+	)
+
+	stronger := argon2idConfig
+	stronger.Argon2Time = 2
+	strongerHasher, _ := NewPasswordHasher(stronger)
+
+	tests := []struct {
+		name   string
+		hasher PasswordHasher
+		hash   vo.HashedPassword
+		want   bool
+	}{
+		{
+			name:   "Same algorithm and cost",
+			hasher: argon2idHasher,
+			hash:   argon2idHash,
+			want:   false,
+		},
+		{
+			name:   "Upgrade from bcrypt to argon2id",
+			hasher: argon2idHasher,
+			hash:   bcryptHash,
+			want:   true,
+		},
+		{
+			name:   "Upgrade the argon2id cost",
+			hasher: strongerHasher,
+			hash:   argon2idHash,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.want)
+			}
+
+			// hashes of older algorithms must keep verifying until they are rehashed
+			if ok, err := tt.hasher.Verify("Passw0rd", tt.hash); !ok || err != nil {
+				t.Errorf("[TestCase '%s'] Verify: '%v' '%v' | Want: 'true' '<nil>'", tt.name, ok, err)
+			}
+		})
+	}
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "Default config",
+			cfg:  DefaultConfig(),
+		},
+		{
+			name:    "Unsupported algorithm",
+			cfg:     Config{Algorithm: "md5"},
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name:    "Invalid bcrypt cost",
+			cfg:     Config{Algorithm: Bcrypt, BcryptCost: 1},
+			wantErr: ErrInvalidHasherConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPasswordHasher(tt.cfg); err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,10 +1,24 @@
 package main
 
 import (
-	_"encoding/json"
+	"os"
+
 	"github.com/ofiliobi/urban-octo-fortnight/infrastructure"
 )
 
 func main() {
-	infrastructure.NewHTTPServer().Start()
+	var mode string
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	// `worker` runs the notification worker and `relay` the outbox relay instead of the HTTP server
+	switch mode {
+	case "worker":
+		infrastructure.NewNotifyWorker().Start()
+	case "relay":
+		infrastructure.NewOutboxRelay().Start()
+	default:
+		infrastructure.NewHTTPServer().Start()
+	}
 }
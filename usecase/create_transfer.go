@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
@@ -10,13 +13,38 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	AuthorizationApproved AuthorizationOutcome = "approved"
+	AuthorizationDenied   AuthorizationOutcome = "denied"
+	AuthorizationReview   AuthorizationOutcome = "review"
+)
+
 type (
 	//Authorizer port
 	Authorizer interface {
-		Authorized(ctx context.Context, transfer entity.Transfer) (bool, error)
+		Authorized(ctx context.Context, transfer entity.Transfer) (AuthorizationDecision, error)
+	}
+
+	// AuthorizationOutcome is the answer of the authorizer
+	AuthorizationOutcome string
+
+	// AuthorizationDecision is the outcome of the authorizer, ReasonCode explains a denial or a review
+	AuthorizationDecision struct {
+		Outcome    AuthorizationOutcome
+		ReasonCode string
+	}
+
+	// FXRateProvider port, quotes the rate to convert from one currency to another
+	FXRateProvider interface {
+		Rate(ctx context.Context, from vo.Currency, to vo.Currency) (vo.ExchangeRate, error)
+	}
+
+	// TransferLimitProvider port, returns the limits of a KYC tier in a currency
+	TransferLimitProvider interface {
+		Limit(ctx context.Context, tier vo.KYCTier, currency vo.Currency) (entity.TransferLimit, error)
 	}
 
-	// Notifier port
+	// Notifier port, notifies the parties of a transfer
 	Notifier interface {
 		Notify(ctx context.Context, transfer entity.Transfer)
 	}
@@ -28,11 +56,12 @@ type (
 
 	// Input data
 	CreateTransferInput struct {
-		ID		vo.Uuid
-		PayerID	vo.Uuid
-		PayeeID	vo.Uuid
-		Value	vo.Money
-		CreatedAt time.Time
+		ID             vo.Uuid
+		PayerID        vo.Uuid
+		PayeeID        vo.Uuid
+		Value          vo.Money
+		IdempotencyKey string
+		CreatedAt      time.Time
 	}
 
 	//Output port
@@ -42,114 +71,353 @@ type (
 
 	//Output data
 	CreateTransferOutput struct {
-		ID		string	`json:"id"`
-		PayerID	string	`json:"payer"`
-		PayeeID	string	`json:"payee"`
-		Value	int64	`json:"value"`
-		CreatedAt string	`json:"created_at"`
+		ID               string `json:"id"`
+		PayerID          string `json:"payer"`
+		PayeeID          string `json:"payee"`
+		Value            int64  `json:"value"`
+		Currency         string `json:"currency"`
+		CreditedValue    int64  `json:"credited_value"`
+		CreditedCurrency string `json:"credited_currency"`
+		Rate             string `json:"rate"`
+		Status           string `json:"status"`
+		CreatedAt        string `json:"created_at"`
 	}
 
 	createTransferInteractor struct {
-		repoTransferCreator		entity.TransferRepositoryCreator
-		repoUserUpdater			entity.UserRepositoryUpdater
-		repoUserFinder			entity.UserRepositoryFinder
-		pre						CreateTransferPresenter
-		authorizer				Authorizer
-		notifier				Notifier
+		repoTransferCreator    entity.TransferRepositoryCreator
+		repoTransferUpdater    entity.TransferRepositoryUpdater
+		repoUserUpdater        entity.UserRepositoryUpdater
+		repoUserFinder         entity.UserRepositoryFinder
+		repoLedgerCreator      entity.LedgerRepositoryCreator
+		repoIdemCreator        entity.IdempotencyRepositoryCreator
+		repoIdemFinder         entity.IdempotencyRepositoryFinder
+		pre                    CreateTransferPresenter
+		rates                  FXRateProvider
+		repoOutboxCreator      entity.OutboxRepositoryCreator
+		repoTransferAggregator entity.TransferRepositoryAggregator
+		limits                 TransferLimitProvider
+		authorizer             Authorizer
+		events                 EventPublisher
 	}
 )
 
 // NewCreateTransferInteractor creates new createTransferInteractor with its dependencies
 func NewCreateTransferInteractor(
 	repoTransferCreator entity.TransferRepositoryCreator,
+	repoTransferUpdater entity.TransferRepositoryUpdater,
 	repoUserUpdater entity.UserRepositoryUpdater,
 	repoUserFinder entity.UserRepositoryFinder,
+	repoLedgerCreator entity.LedgerRepositoryCreator,
+	repoIdemCreator entity.IdempotencyRepositoryCreator,
+	repoIdemFinder entity.IdempotencyRepositoryFinder,
+	repoOutboxCreator entity.OutboxRepositoryCreator,
+	repoTransferAggregator entity.TransferRepositoryAggregator,
+	rates FXRateProvider,
+	limits TransferLimitProvider,
 	authorizer Authorizer,
-	notifier Notifier,
+	events EventPublisher,
 	pre CreateTransferPresenter,
 ) CreateTransferUseCase {
 	return createTransferInteractor{
-		repoTransferCreator: repoTransferCreator,
-		repoUserUpdater: repoUserUpdater,
-		repoUserFinder: repoUserFinder,
-		authorizer: authorizer,
-		notifier: notifier,
-		pre: pre,
+		repoTransferCreator:    repoTransferCreator,
+		repoTransferUpdater:    repoTransferUpdater,
+		repoUserUpdater:        repoUserUpdater,
+		repoUserFinder:         repoUserFinder,
+		repoLedgerCreator:      repoLedgerCreator,
+		repoIdemCreator:        repoIdemCreator,
+		repoIdemFinder:         repoIdemFinder,
+		repoOutboxCreator:      repoOutboxCreator,
+		repoTransferAggregator: repoTransferAggregator,
+		rates:                  rates,
+		limits:                 limits,
+		authorizer:             authorizer,
+		events:                 events,
+		pre:                    pre,
 	}
 }
+
 // Execute orchestrates the use case
 func (c createTransferInteractor) Execute(ctx context.Context, i CreateTransferInput) (CreateTransferOutput, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var (
-		transfer entity.Transfer
-		err      error
+		transfer    entity.Transfer
+		events      []entity.Event
+		fingerprint = c.fingerprint(i)
+		validated   bool
+		err         error
 	)
 
-	err = c.repoTransferCreator.WithTransaction(ctx, func(sessCtx context.Context) error {
-		if err = c.process(sessCtx, i.PayerID, i.PayeeID, i.Value); err != nil {
-			return err
-		}
+	// both sides would debit and credit the same wallet read at the same version, the second update could never apply
+	if i.PayerID == i.PayeeID {
+		return c.pre.Output(entity.Transfer{}), entity.ErrSelfTransfer
+	}
 
-		transfer, err = c.repoTransferCreator.Create(sessCtx, entity.NewTransfer(
-			i.ID,
-			i.PayerID,
-			i.PayeeID,
-			i.Value,
-			i.CreatedAt,
-		))
-		if err != nil {
-			return err
-		}
+	if i.IdempotencyKey != "" {
+		record, err := c.repoIdemFinder.FindByKey(ctx, i.PayerID, i.IdempotencyKey)
+		switch err {
+		case nil:
+			if err := record.Matches(fingerprint); err != nil {
+				return c.pre.Output(entity.Transfer{}), err
+			}
 
-		ok, err := c.authorizer.Authorized(sessCtx, transfer)
-		if err != nil || !ok {
-			return err
+			return c.pre.Output(record.Transfer()), nil
+		case entity.ErrNotFoundIdempotencyKey:
+		default:
+			return c.pre.Output(entity.Transfer{}), err
 		}
+	}
 
-		return nil
+	// the authorizer is asked once, a transaction run again after a conflict applies the same decision
+	decision, err := c.authorizer.Authorized(ctx, entity.NewTransfer(i.ID, i.PayerID, i.PayeeID, i.Value, i.CreatedAt))
+	if err != nil {
+		return c.pre.Output(entity.Transfer{}), err
+	}
+
+	// a wallet updated by a concurrent transfer since it was read aborts the transaction, which is run again
+	err = retryOnConflict(ctx, func() error {
+		return c.repoTransferCreator.WithTransaction(ctx, func(sessCtx context.Context) error {
+			validated = false
+
+			payer, payee, err := c.parties(sessCtx, i.PayerID, i.PayeeID)
+			if err != nil {
+				return err
+			}
+
+			// the request is valid from here, the transfer is recorded as FAILED when it does not go through
+			validated = true
+
+			if err = decision.err(); err != nil {
+				return err
+			}
+
+			// a transfer held for review moves no money, it stays PENDING until the review decides on it
+			if decision.Outcome == AuthorizationReview {
+				transfer, err = c.repoTransferCreator.Create(
+					sessCtx,
+					entity.NewTransfer(i.ID, i.PayerID, i.PayeeID, i.Value, i.CreatedAt),
+				)
+				if err != nil {
+					return err
+				}
+
+				events = nil
+				return c.remember(sessCtx, i, fingerprint, transfer)
+			}
+
+			credit, rate, wallets, err := c.process(sessCtx, payer, payee, i.Value)
+			if err != nil {
+				return err
+			}
+
+			transfer, err = c.repoTransferCreator.Create(sessCtx, entity.NewTransfer(
+				i.ID,
+				i.PayerID,
+				i.PayeeID,
+				i.Value,
+				i.CreatedAt,
+			).WithConversion(credit, rate))
+			if err != nil {
+				return err
+			}
+
+			entry, err := entity.NewTransferJournalEntry(vo.NewUuidRandom(), transfer)
+			if err != nil {
+				return err
+			}
+
+			if _, err = c.repoLedgerCreator.Create(sessCtx, entry); err != nil {
+				return err
+			}
+
+			if err = transfer.Authorize(time.Now()); err != nil {
+				return err
+			}
+
+			if err = transfer.Complete(time.Now()); err != nil {
+				return err
+			}
+
+			if err = c.repoTransferUpdater.Update(sessCtx, transfer); err != nil {
+				return err
+			}
+
+			message, err := newTransferMessage(entity.TransferCompletedMessage, transfer)
+			if err != nil {
+				return err
+			}
+
+			if err = c.repoOutboxCreator.Create(sessCtx, message); err != nil {
+				return err
+			}
+
+			// the callback may be retried, only the events of the attempt that commits are kept
+			events = append(wallets, transfer.PullEvents()...)
+
+			return c.remember(sessCtx, i, fingerprint, transfer)
+		})
 	})
 	if err != nil {
+		if validated {
+			c.fail(ctx, i, err)
+		}
+
 		return c.pre.Output(entity.Transfer{}), err
 	}
 
-	c.notifier.Notify(ctx, transfer)
+	// the transfer is committed, the failures of the subscribers are reported by the publisher
+	_ = c.events.Publish(ctx, events...)
 
 	return c.pre.Output(transfer), nil
 }
 
-func (c createTransferInteractor) process(ctx context.Context, payerID vo.Uuid, payeeID vo.Uuid, value vo.Money) error {
+// remember stores the transfer under the idempotency key of the request, a retry of the request gets it back
+func (c createTransferInteractor) remember(ctx context.Context, i CreateTransferInput, fingerprint string, transfer entity.Transfer) error {
+	if i.IdempotencyKey == "" {
+		return nil
+	}
+
+	return c.repoIdemCreator.Create(ctx, entity.NewIdempotencyKey(i.IdempotencyKey, fingerprint, transfer, i.CreatedAt))
+}
+
+// fail records the rolled back transfer as FAILED, so the attempt stays visible after the transaction aborts
+func (c createTransferInteractor) fail(ctx context.Context, i CreateTransferInput, cause error) {
+	for _, err := range []error{entity.ErrIdempotencyKeyInUse, context.Canceled, context.DeadlineExceeded} {
+		if errors.Is(cause, err) {
+			return
+		}
+	}
+
+	transfer := entity.NewTransfer(i.ID, i.PayerID, i.PayeeID, i.Value, i.CreatedAt)
+	if err := transfer.Fail(cause.Error(), time.Now()); err != nil {
+		return
+	}
+
+	if _, err := c.repoTransferCreator.Create(ctx, transfer); err != nil {
+		return
+	}
+
+	_ = c.events.Publish(ctx, transfer.PullEvents()...)
+}
+
+// parties loads the payer and the payee of the transfer, the payer must be allowed to send money
+func (c createTransferInteractor) parties(ctx context.Context, payerID vo.Uuid, payeeID vo.Uuid) (entity.User, entity.User, error) {
 	payer, err := c.repoUserFinder.FindByID(ctx, payerID)
 	if err != nil {
-		return err
+		return entity.User{}, entity.User{}, err
 	}
 
 	if err := payer.CanTransfer(); err != nil {
-		return errors.Wrap(err, entity.ErrUnauthorizedTransfer.Error())
+		return entity.User{}, entity.User{}, errors.Wrap(err, entity.ErrUnauthorizedTransfer.Error())
 	}
 
 	payee, err := c.repoUserFinder.FindByID(ctx, payeeID)
 	if err != nil {
-		return err
+		return entity.User{}, entity.User{}, err
+	}
+
+	return payer, payee, nil
+}
+
+// process moves the value between the wallets, converting it to the currency of the payee wallet when they differ.
+// It returns the value credited to the payee, the rate applied and the events of the wallets
+func (c createTransferInteractor) process(
+	ctx context.Context,
+	payer entity.User,
+	payee entity.User,
+	value vo.Money,
+) (vo.Money, vo.ExchangeRate, []entity.Event, error) {
+	var (
+		rate = vo.NewIdentityExchangeRate(value.Currency())
+		err  error
+	)
+	if to := payee.Wallet().Money().Currency(); to != value.Currency() {
+		rate, err = c.rates.Rate(ctx, value.Currency(), to)
+		if err != nil {
+			return vo.Money{}, vo.ExchangeRate{}, nil, err
+		}
+	}
+
+	credit, err := rate.Convert(value)
+	if err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
+	}
+
+	if err = payer.Withdraw(value, time.Now()); err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
 	}
 
-	err = payer.Withdraw(value)
+	if err = payee.Deposit(credit, time.Now()); err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
+	}
+
+	if err = c.repoUserUpdater.UpdateWallet(ctx, payer.ID(), payer.Wallet()); err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
+	}
+
+	// the versioned update of the payer wallet serializes the transfers of the payer, a concurrent one is aborted
+	// so the totals read from here already count every transfer committed before
+	if err = c.checkLimit(ctx, payer, value); err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
+	}
+
+	if err = c.repoUserUpdater.UpdateWallet(ctx, payee.ID(), payee.Wallet()); err != nil {
+		return vo.Money{}, vo.ExchangeRate{}, nil, err
+	}
+
+	return credit, rate, append(payer.PullEvents(), payee.PullEvents()...), nil
+}
+
+// checkLimit checks the value against the limits of the KYC tier of the payer, with what it sent today and this month
+func (c createTransferInteractor) checkLimit(ctx context.Context, payer entity.User, value vo.Money) error {
+	limit, err := c.limits.Limit(ctx, payer.KYCTier(), value.Currency())
 	if err != nil {
 		return err
 	}
 
-	payee.Deposit(value)
+	var (
+		now   = time.Now().UTC()
+		day   = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	)
 
-	err = c.repoUserUpdater.UpdateWallet(ctx, payerID, payer.Wallet().Money())
+	today, err := c.repoTransferAggregator.SumSent(ctx, payer.ID(), value.Currency(), day)
 	if err != nil {
 		return err
 	}
 
-	err = c.repoUserUpdater.UpdateWallet(ctx, payeeID, payee.Wallet().Money())
+	thisMonth, err := c.repoTransferAggregator.SumSent(ctx, payer.ID(), value.Currency(), month)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return limit.Check(value, today, thisMonth)
+}
+
+// fingerprint identifies the content of the request, so a reused idempotency key can be matched against it
+func (c createTransferInteractor) fingerprint(i CreateTransferInput) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		i.PayerID.Value(),
+		i.PayeeID.Value(),
+		i.Value.Currency().String(),
+		i.Value.Amount().String(),
+	}, "|")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// err returns the error of a denial, the reason code is kept in the failure of the transfer. A transfer approved or
+// held for review goes on
+func (d AuthorizationDecision) err() error {
+	switch d.Outcome {
+	case AuthorizationApproved, AuthorizationReview:
+		return nil
+	}
+
+	if d.ReasonCode == "" {
+		return entity.ErrUnauthorizedTransfer
+	}
+
+	return errors.Wrapf(entity.ErrUnauthorizedTransfer, "reason code %s", d.ReasonCode)
 }
@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +13,9 @@ import (
 	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
 )
 
+// payeeIDTest is the payee of the transfers, the payer is vo.NewUuidStaticTest
+var payeeIDTest, _ = vo.NewUuid("5f1d2c7a-3b4e-4f6a-9c8d-2e1b0a9f8e7d")
+
 type stubTransferRepoCreator struct {
 	result entity.Transfer
 	err    error
@@ -28,13 +33,21 @@ func (s stubTransferRepoCreator) WithTransaction(_ context.Context, fn func(cont
 	return nil
 }
 
+type stubTransferRepoUpdater struct {
+	err error
+}
+
+func (s stubTransferRepoUpdater) Update(_ context.Context, _ entity.Transfer) error {
+	return s.err
+}
+
 type spyUserRepoUpdater struct {
 	errUpdatePayer error
 	errUpdatePayee error
 	invoked        bool
 }
 
-func (s *spyUserRepoUpdater) UpdateWallet(_ context.Context, _ vo.Uuid, _ vo.Money) error {
+func (s *spyUserRepoUpdater) UpdateWallet(_ context.Context, _ vo.Uuid, _ *vo.Wallet) error {
 	if s.invoked == true {
 		return s.errUpdatePayee
 	}
@@ -43,6 +56,10 @@ func (s *spyUserRepoUpdater) UpdateWallet(_ context.Context, _ vo.Uuid, _ vo.Mon
 	return s.errUpdatePayer
 }
 
+func (s *spyUserRepoUpdater) UpdatePassword(_ context.Context, _ vo.Uuid, _ vo.HashedPassword) error {
+	return nil
+}
+
 type spyUserRepoFinder struct {
 	findPayer func() (entity.User, error)
 	findPayee func() (entity.User, error)
@@ -60,18 +77,97 @@ func (f *spyUserRepoFinder) FindByID(_ context.Context, _ vo.Uuid) (entity.User,
 	return f.findPayer()
 }
 
+func (f *spyUserRepoFinder) FindByEmail(_ context.Context, _ vo.Email) (entity.User, error) {
+	return f.findPayer()
+}
+
+type stubLedgerRepoCreator struct {
+	err error
+}
+
+func (s stubLedgerRepoCreator) Create(_ context.Context, j entity.JournalEntry) (entity.JournalEntry, error) {
+	return j, s.err
+}
+
+type stubIdempotencyRepo struct {
+	result entity.IdempotencyKey
+	err    error
+}
+
+func (s stubIdempotencyRepo) Create(_ context.Context, _ entity.IdempotencyKey) error {
+	if s.err == entity.ErrNotFoundIdempotencyKey {
+		return nil
+	}
+
+	return s.err
+}
+
+func (s stubIdempotencyRepo) FindByKey(_ context.Context, _ vo.Uuid, _ string) (entity.IdempotencyKey, error) {
+	return s.result, s.err
+}
+
 type stubAuthorizer struct {
-	result bool
+	result AuthorizationDecision
+	err    error
+}
+
+func (s stubAuthorizer) Authorized(_ context.Context, _ entity.Transfer) (AuthorizationDecision, error) {
+	return s.result, s.err
+}
+
+type spyAuthorizer struct {
+	stubAuthorizer
+	calls int
+}
+
+func (s *spyAuthorizer) Authorized(ctx context.Context, t entity.Transfer) (AuthorizationDecision, error) {
+	s.calls++
+	return s.stubAuthorizer.Authorized(ctx, t)
+}
+
+type stubTransferRepoAggregator struct {
+	today entity.TransferTotal
+	month entity.TransferTotal
+	err   error
+}
+
+// SumSent returns today when since is the start of the day, month otherwise
+func (s stubTransferRepoAggregator) SumSent(_ context.Context, _ vo.Uuid, _ vo.Currency, since time.Time) (entity.TransferTotal, error) {
+	if since.Equal(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return s.today, s.err
+	}
+	return s.month, s.err
+}
+
+// stubTransferLimitProvider returns no limit unless result is set
+type stubTransferLimitProvider struct {
+	result *entity.TransferLimit
+	err    error
+}
+
+func (s stubTransferLimitProvider) Limit(_ context.Context, tier vo.KYCTier, currency vo.Currency) (entity.TransferLimit, error) {
+	if s.result == nil {
+		return entity.NewUnlimitedTransferLimit(tier, currency), s.err
+	}
+	return *s.result, s.err
+}
+
+type stubFXRateProvider struct {
+	result vo.ExchangeRate
 	err    error
 }
 
-func (s stubAuthorizer) Authorized(_ context.Context, _ entity.Transfer) (bool, error) {
+func (s stubFXRateProvider) Rate(_ context.Context, _ vo.Currency, _ vo.Currency) (vo.ExchangeRate, error) {
 	return s.result, s.err
 }
 
-type stubNotifier struct{}
+type stubOutboxRepoCreator struct {
+	err error
+}
 
-func (s stubNotifier) Notify(_ context.Context, _ entity.Transfer) {}
+func (s stubOutboxRepoCreator) Create(_ context.Context, _ entity.OutboxMessage) error {
+	return s.err
+}
 
 type stubCreateTransferPresenter struct {
 	result CreateTransferOutput
@@ -88,7 +184,6 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 		repoUserFinder      entity.UserRepositoryFinder
 		pre                 CreateTransferPresenter
 		authorizer          Authorizer
-		notifier            Notifier
 	}
 	type args struct {
 		i CreateTransferInput
@@ -123,7 +218,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -134,7 +229,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -151,16 +246,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -191,7 +285,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -202,7 +296,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -213,16 +307,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -247,7 +340,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -258,7 +351,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -269,16 +362,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: false,
+					result: AuthorizationDecision{},
 					err:    errors.New("authorization denied"),
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -303,7 +395,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -314,7 +406,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -325,16 +417,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -365,16 +456,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.Money{},
 					CreatedAt: time.Time{},
 				},
@@ -399,7 +489,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -413,16 +503,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.Money{},
 					CreatedAt: time.Time{},
 				},
@@ -447,7 +536,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -458,7 +547,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -469,16 +558,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -503,7 +591,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -514,7 +602,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -525,16 +613,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(100)),
 					CreatedAt: time.Time{},
 				},
@@ -559,7 +646,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Test testing"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -570,7 +657,7 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 							vo.NewUuidStaticTest(),
 							vo.NewFullName("Merchant user"),
 							vo.NewEmailTest("test@testing.com"),
-							vo.NewPassword("passw"),
+							vo.NewHashedPasswordTest("$2a$10$passw"),
 							vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 							vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 							time.Now(),
@@ -581,16 +668,15 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 					result: CreateTransferOutput{},
 				},
 				authorizer: stubAuthorizer{
-					result: true,
+					result: AuthorizationDecision{Outcome: AuthorizationApproved},
 					err:    nil,
 				},
-				notifier: stubNotifier{},
 			},
 			args: args{
 				i: CreateTransferInput{
 					ID:        vo.NewUuidStaticTest(),
 					PayerID:   vo.NewUuidStaticTest(),
-					PayeeID:   vo.NewUuidStaticTest(),
+					PayeeID:   payeeIDTest,
 					Value:     vo.NewMoneyNGN(vo.NewAmountTest(150)),
 					CreatedAt: time.Time{},
 				},
@@ -603,10 +689,18 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := NewCreateTransferInteractor(
 				tt.fields.repoTransferCreator,
+				stubTransferRepoUpdater{},
 				tt.fields.repoUserUpdater,
 				tt.fields.repoUserFinder,
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubOutboxRepoCreator{},
+				stubTransferRepoAggregator{},
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				stubTransferLimitProvider{},
 				tt.fields.authorizer,
-				tt.fields.notifier,
+				&spyEventPublisher{},
 				tt.fields.pre,
 			)
 
@@ -622,3 +716,784 @@ func Test_createTransferInteractor_Execute(t *testing.T) {
 		})
 	}
 }
+
+type spyCreateTransferPresenter struct{}
+
+func (s spyCreateTransferPresenter) Output(t entity.Transfer) CreateTransferOutput {
+	return CreateTransferOutput{
+		ID:               t.ID().Value(),
+		PayerID:          t.Payer().Value(),
+		PayeeID:          t.Payee().Value(),
+		Value:            t.Value().Amount().Value(),
+		Status:           t.Status().String(),
+		CreditedValue:    t.Credit().Amount().Value(),
+		CreditedCurrency: t.Credit().Currency().String(),
+		Rate:             t.Rate().String(),
+	}
+}
+
+func Test_createTransferInteractor_ExecuteIdempotency(t *testing.T) {
+	var (
+		input = CreateTransferInput{
+			ID:             vo.NewUuidStaticTest(),
+			PayerID:        vo.NewUuidStaticTest(),
+			PayeeID:        payeeIDTest,
+			Value:          vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			IdempotencyKey: "key",
+		}
+		stored = entity.NewTransfer(
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			time.Time{},
+		)
+		fingerprint = createTransferInteractor{}.fingerprint(input)
+	)
+
+	tests := []struct {
+		name    string
+		repo    stubIdempotencyRepo
+		want    CreateTransferOutput
+		wantErr error
+	}{
+		{
+			name: "Replay transfer with same idempotency key and body",
+			repo: stubIdempotencyRepo{
+				result: entity.NewIdempotencyKey("key", fingerprint, stored, time.Time{}),
+			},
+			want: CreateTransferOutput{
+				ID:               vo.NewUuidStaticTest().Value(),
+				PayerID:          vo.NewUuidStaticTest().Value(),
+				PayeeID:          vo.NewUuidStaticTest().Value(),
+				Value:            100,
+				Status:           vo.PENDING.String(),
+				CreditedValue:    100,
+				CreditedCurrency: vo.NGN.String(),
+				Rate:             "1",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Reject idempotency key reused with different body",
+			repo: stubIdempotencyRepo{
+				result: entity.NewIdempotencyKey("key", "other", stored, time.Time{}),
+			},
+			want:    CreateTransferOutput{},
+			wantErr: entity.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name: "Idempotency key lookup error",
+			repo: stubIdempotencyRepo{
+				err: errors.New("fail database"),
+			},
+			want:    CreateTransferOutput{},
+			wantErr: errors.New("fail database"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateTransferInteractor(
+				stubTransferRepoCreator{err: errors.New("must not create transfer")},
+				stubTransferRepoUpdater{},
+				&spyUserRepoUpdater{},
+				stubUserRepoFinder{err: errors.New("must not find users")},
+				stubLedgerRepoCreator{},
+				tt.repo,
+				tt.repo,
+				stubOutboxRepoCreator{},
+				stubTransferRepoAggregator{},
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				stubTransferLimitProvider{},
+				stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+				&spyEventPublisher{},
+				spyCreateTransferPresenter{},
+			)
+
+			got, err := c.Execute(context.Background(), input)
+			if (err == nil) != (tt.wantErr == nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createTransferInteractor_ExecuteLifecycle(t *testing.T) {
+	var newUser = func(user func(vo.Uuid, vo.FullName, vo.Email, vo.HashedPassword, vo.Document, *vo.Wallet, time.Time) entity.User) func() (entity.User, error) {
+		return func() (entity.User, error) {
+			return user(
+				vo.NewUuidStaticTest(),
+				vo.NewFullName("Test testing"),
+				vo.NewEmailTest("test@testing.com"),
+				vo.NewHashedPasswordTest("$2a$10$passw"),
+				vo.NewDocumentTest(vo.CPF, "07091054954"),
+				vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
+				time.Now(),
+			), nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		authorizer Authorizer
+		outbox     stubOutboxRepoCreator
+		want       CreateTransferOutput
+		wantEvents []string
+		wantErr    bool
+	}{
+		{
+			name:       "Authorized transfer is completed",
+			authorizer: stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+			want: CreateTransferOutput{
+				ID:      vo.NewUuidStaticTest().Value(),
+				PayerID: vo.NewUuidStaticTest().Value(),
+				PayeeID: payeeIDTest.Value(),
+				Value:   100,
+				Status:  vo.COMPLETED.String(),
+			},
+			wantEvents: []string{
+				entity.WalletDebitedEvent,
+				entity.WalletCreditedEvent,
+				entity.TransferCompletedEvent,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Denied transfer fails",
+			authorizer: stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationDenied, ReasonCode: "R01"}},
+			want: CreateTransferOutput{
+				Status: "",
+			},
+			wantEvents: []string{entity.TransferFailedEvent},
+			wantErr:    true,
+		},
+		{
+			name:       "Transfer held for review stays pending without moving money",
+			authorizer: stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationReview, ReasonCode: "R10"}},
+			want: CreateTransferOutput{
+				Status: vo.PENDING.String(),
+			},
+			wantEvents: nil,
+			wantErr:    false,
+		},
+		{
+			name:       "Outbox failure rolls the transfer back",
+			authorizer: stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+			outbox:     stubOutboxRepoCreator{err: entity.ErrCreateOutboxMessage},
+			want: CreateTransferOutput{
+				Status: "",
+			},
+			wantEvents: []string{entity.TransferFailedEvent},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events = &spyEventPublisher{}
+
+			c := NewCreateTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				&spyUserRepoUpdater{},
+				&spyUserRepoFinder{
+					findPayer: newUser(entity.NewCommonUser),
+					findPayee: newUser(entity.NewMerchantUser),
+				},
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				tt.outbox,
+				stubTransferRepoAggregator{},
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				stubTransferLimitProvider{},
+				tt.authorizer,
+				events,
+				spyCreateTransferPresenter{},
+			)
+
+			got, err := c.Execute(context.Background(), CreateTransferInput{
+				ID:      vo.NewUuidStaticTest(),
+				PayerID: vo.NewUuidStaticTest(),
+				PayeeID: payeeIDTest,
+				Value:   vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got.Status != tt.want.Status {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+
+			if !reflect.DeepEqual(events.names, tt.wantEvents) {
+				t.Errorf("[TestCase '%s'] Events: '%v' | Want: '%v'", tt.name, events.names, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_createTransferInteractor_ExecuteFailed(t *testing.T) {
+	var (
+		payer = func(amount int64) func() (entity.User, error) {
+			return func() (entity.User, error) { return newWalletUser(amount), nil }
+		}
+		notFound = func() (entity.User, error) { return entity.User{}, entity.ErrNotFoundUser }
+	)
+
+	tests := []struct {
+		name       string
+		finder     *spyUserRepoFinder
+		updater    *spyUserRepoUpdater
+		wantErr    error
+		wantEvents []string
+	}{
+		{
+			name:       "Payer not found is not recorded",
+			finder:     &spyUserRepoFinder{findPayer: notFound, findPayee: payer(0)},
+			updater:    &spyUserRepoUpdater{},
+			wantErr:    entity.ErrNotFoundUser,
+			wantEvents: nil,
+		},
+		{
+			name:       "Payee not found is not recorded",
+			finder:     &spyUserRepoFinder{findPayer: payer(1000), findPayee: notFound},
+			updater:    &spyUserRepoUpdater{},
+			wantErr:    entity.ErrNotFoundUser,
+			wantEvents: nil,
+		},
+		{
+			name:       "Wrapped cancellation is not recorded",
+			finder:     &spyUserRepoFinder{findPayer: payer(1000), findPayee: payer(0)},
+			updater:    &spyUserRepoUpdater{errUpdatePayer: fmt.Errorf("error updating wallet: %w", context.Canceled)},
+			wantErr:    context.Canceled,
+			wantEvents: nil,
+		},
+		{
+			name:       "Insufficient balance is recorded as FAILED",
+			finder:     &spyUserRepoFinder{findPayer: payer(10), findPayee: payer(0)},
+			updater:    &spyUserRepoUpdater{},
+			wantErr:    entity.ErrUserInsufficientBalance,
+			wantEvents: []string{entity.TransferFailedEvent},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events = &spyEventPublisher{}
+
+			c := NewCreateTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				tt.updater,
+				tt.finder,
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubOutboxRepoCreator{},
+				stubTransferRepoAggregator{},
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				stubTransferLimitProvider{},
+				stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+				events,
+				spyCreateTransferPresenter{},
+			)
+
+			_, err := c.Execute(context.Background(), CreateTransferInput{
+				ID:      vo.NewUuidStaticTest(),
+				PayerID: vo.NewUuidStaticTest(),
+				PayeeID: payeeIDTest,
+				Value:   vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(events.names, tt.wantEvents) {
+				t.Errorf("[TestCase '%s'] Events: '%v' | Want: '%v'", tt.name, events.names, tt.wantEvents)
+			}
+		})
+	}
+}
+
+type echoTransferRepoCreator struct{}
+
+func (e echoTransferRepoCreator) Create(_ context.Context, t entity.Transfer) (entity.Transfer, error) {
+	return t, nil
+}
+
+func (e echoTransferRepoCreator) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_createTransferInteractor_ExecuteCrossCurrency(t *testing.T) {
+	var newUser = func(user func(vo.Uuid, vo.FullName, vo.Email, vo.HashedPassword, vo.Document, *vo.Wallet, time.Time) entity.User, money vo.Money) func() (entity.User, error) {
+		return func() (entity.User, error) {
+			return user(
+				vo.NewUuidStaticTest(),
+				vo.NewFullName("Test testing"),
+				vo.NewEmailTest("test@testing.com"),
+				vo.NewHashedPasswordTest("$2a$10$passw"),
+				vo.NewDocumentTest(vo.CPF, "07091054954"),
+				vo.NewWallet(money),
+				time.Now(),
+			), nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		payer   vo.Money
+		payee   vo.Money
+		rates   FXRateProvider
+		want    CreateTransferOutput
+		wantErr error
+	}{
+		{
+			name:    "Payer wallet in a different currency error",
+			payer:   vo.NewMoneyUSD(vo.NewAmountTest(100000)),
+			payee:   vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			rates:   stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+			wantErr: entity.ErrCurrencyMismatch,
+		},
+		{
+			name:    "Payee wallet in a currency without rate error",
+			payer:   vo.NewMoneyNGN(vo.NewAmountTest(100000)),
+			payee:   vo.NewMoneyUSD(vo.NewAmountTest(100)),
+			rates:   stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+			wantErr: vo.ErrExchangeRateUnavailable,
+		},
+		{
+			name:  "Payee wallet in a different currency is credited with the converted value",
+			payer: vo.NewMoneyNGN(vo.NewAmountTest(100000)),
+			payee: vo.NewMoneyUSD(vo.NewAmountTest(100)),
+			rates: stubFXRateProvider{result: vo.NewExchangeRateTest(vo.NGN, vo.USD, "0.00066")},
+			want: CreateTransferOutput{
+				ID:               vo.NewUuidStaticTest().Value(),
+				PayerID:          vo.NewUuidStaticTest().Value(),
+				PayeeID:          payeeIDTest.Value(),
+				Value:            75000,
+				Status:           vo.COMPLETED.String(),
+				CreditedValue:    50,
+				CreditedCurrency: vo.USD.String(),
+				Rate:             "0.00066",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				&spyUserRepoUpdater{},
+				&spyUserRepoFinder{
+					findPayer: newUser(entity.NewCommonUser, tt.payer),
+					findPayee: newUser(entity.NewMerchantUser, tt.payee),
+				},
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubOutboxRepoCreator{},
+				stubTransferRepoAggregator{},
+				tt.rates,
+				stubTransferLimitProvider{},
+				stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+				&spyEventPublisher{},
+				spyCreateTransferPresenter{},
+			)
+
+			got, err := c.Execute(context.Background(), CreateTransferInput{
+				ID:      vo.NewUuidStaticTest(),
+				PayerID: vo.NewUuidStaticTest(),
+				PayeeID: payeeIDTest,
+				Value:   vo.NewMoneyNGN(vo.NewAmountTest(75000)),
+			})
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createTransferInteractor_ExecuteLimits(t *testing.T) {
+	var newUser = func(user func(vo.Uuid, vo.FullName, vo.Email, vo.HashedPassword, vo.Document, *vo.Wallet, time.Time) entity.User) func() (entity.User, error) {
+		return func() (entity.User, error) {
+			return user(
+				vo.NewUuidStaticTest(),
+				vo.NewFullName("Test testing"),
+				vo.NewEmailTest("test@testing.com"),
+				vo.NewHashedPasswordTest("$2a$10$passw"),
+				vo.NewDocumentTest(vo.CPF, "07091054954"),
+				vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100000))),
+				time.Now(),
+			), nil
+		}
+	}
+
+	var ngn = vo.NewMoneyNGN(vo.NewAmountTest(0)).Currency()
+
+	var limit = func(perTransaction, daily, monthly int64, dailyCount int) *entity.TransferLimit {
+		l := entity.NewUnlimitedTransferLimit(vo.DefaultKYCTier, ngn)
+		l.PerTransaction, l.Daily, l.Monthly, l.DailyCount = perTransaction, daily, monthly, dailyCount
+		return &l
+	}
+
+	tests := []struct {
+		name       string
+		limits     TransferLimitProvider
+		totals     stubTransferRepoAggregator
+		wantLimit  *entity.LimitExceededError
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name:       "Transfer within the limits",
+			limits:     stubTransferLimitProvider{result: limit(10000, 20000, 50000, 5)},
+			totals:     stubTransferRepoAggregator{today: entity.TransferTotal{Count: 1, Amount: 5000}},
+			wantStatus: vo.COMPLETED.String(),
+		},
+		{
+			name:   "Transfer above the per transaction limit error",
+			limits: stubTransferLimitProvider{result: limit(5000, entity.NoLimit, entity.NoLimit, entity.NoLimit)},
+			wantLimit: &entity.LimitExceededError{
+				Limit:     entity.PerTransactionLimit,
+				Currency:  ngn,
+				Max:       5000,
+				Remaining: 5000,
+			},
+		},
+		{
+			name:   "Transfer above the daily limit error",
+			limits: stubTransferLimitProvider{result: limit(10000, 20000, 50000, 5)},
+			totals: stubTransferRepoAggregator{
+				today: entity.TransferTotal{Count: 2, Amount: 15000},
+				month: entity.TransferTotal{Count: 2, Amount: 15000},
+			},
+			wantLimit: &entity.LimitExceededError{
+				Limit:     entity.DailyLimit,
+				Currency:  ngn,
+				Max:       20000,
+				Remaining: 5000,
+			},
+		},
+		{
+			name:   "Transfer above the daily count limit error",
+			limits: stubTransferLimitProvider{result: limit(10000, 20000, 50000, 2)},
+			totals: stubTransferRepoAggregator{
+				today: entity.TransferTotal{Count: 2, Amount: 2000},
+				month: entity.TransferTotal{Count: 2, Amount: 2000},
+			},
+			wantLimit: &entity.LimitExceededError{
+				Limit:     entity.DailyCountLimit,
+				Currency:  ngn,
+				Max:       2,
+				Remaining: 0,
+			},
+		},
+		{
+			name:    "Transfer totals error",
+			limits:  stubTransferLimitProvider{result: limit(10000, 20000, 50000, 5)},
+			totals:  stubTransferRepoAggregator{err: entity.ErrSumTransfers},
+			wantErr: entity.ErrSumTransfers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				&spyUserRepoUpdater{},
+				&spyUserRepoFinder{
+					findPayer: newUser(entity.NewCommonUser),
+					findPayee: newUser(entity.NewMerchantUser),
+				},
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubOutboxRepoCreator{},
+				tt.totals,
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				tt.limits,
+				stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+				&spyEventPublisher{},
+				spyCreateTransferPresenter{},
+			)
+
+			got, err := c.Execute(context.Background(), CreateTransferInput{
+				ID:      vo.NewUuidStaticTest(),
+				PayerID: vo.NewUuidStaticTest(),
+				PayeeID: payeeIDTest,
+				Value:   vo.NewMoneyNGN(vo.NewAmountTest(10000)),
+			})
+
+			if tt.wantLimit != nil {
+				var limitErr *entity.LimitExceededError
+				if !errors.As(err, &limitErr) || !errors.Is(err, entity.ErrLimitExceeded) {
+					t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantLimit)
+					return
+				}
+
+				if !reflect.DeepEqual(limitErr, tt.wantLimit) {
+					t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, limitErr, tt.wantLimit)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got.Status != tt.wantStatus {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// versionedWalletRepo keeps the wallets of the users like a database does, a wallet is only updated at the version
+// it was read at
+type versionedWalletRepo struct {
+	mu    sync.Mutex
+	users map[vo.Uuid]entity.User
+}
+
+func newVersionedWalletRepo(users ...entity.User) *versionedWalletRepo {
+	r := &versionedWalletRepo{users: make(map[vo.Uuid]entity.User)}
+	for _, u := range users {
+		r.users[u.ID()] = u
+	}
+
+	return r
+}
+
+func (r *versionedWalletRepo) FindByID(_ context.Context, ID vo.Uuid) (entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[ID]
+	if !ok {
+		return entity.User{}, entity.ErrNotFoundUser
+	}
+
+	return entity.NewUser(
+		u.ID(),
+		u.FullName(),
+		u.Email(),
+		u.Password(),
+		u.Document(),
+		vo.NewVersionedWallet(u.Wallet().Money(), u.Wallet().Version()),
+		u.TypeUser(),
+		u.CreatedAt(),
+	)
+}
+
+func (r *versionedWalletRepo) FindByEmail(_ context.Context, _ vo.Email) (entity.User, error) {
+	return entity.User{}, entity.ErrNotFoundUser
+}
+
+func (r *versionedWalletRepo) UpdateWallet(_ context.Context, ID vo.Uuid, wallet *vo.Wallet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[ID]
+	if !ok {
+		return entity.ErrNotFoundUser
+	}
+
+	if u.Wallet().Version() != wallet.Version() {
+		return entity.ErrConcurrentModification
+	}
+
+	r.users[ID] = entity.NewCommonUser(
+		u.ID(),
+		u.FullName(),
+		u.Email(),
+		u.Password(),
+		u.Document(),
+		vo.NewVersionedWallet(wallet.Money(), wallet.Version()+1),
+		u.CreatedAt(),
+	)
+
+	return nil
+}
+
+func (r *versionedWalletRepo) UpdatePassword(_ context.Context, _ vo.Uuid, _ vo.HashedPassword) error {
+	return nil
+}
+
+// concurrentWriterRepo commits a concurrent update of the payer wallet between the read and the update of the first
+// attempts, the update of the transfer is then made at a stale version and rejected like a database does
+type concurrentWriterRepo struct {
+	*versionedWalletRepo
+	payer     vo.Uuid
+	conflicts int
+	calls     int
+}
+
+func (c *concurrentWriterRepo) UpdateWallet(ctx context.Context, ID vo.Uuid, wallet *vo.Wallet) error {
+	if ID != c.payer {
+		return c.versionedWalletRepo.UpdateWallet(ctx, ID, wallet)
+	}
+
+	c.calls++
+	if c.calls <= c.conflicts {
+		stored, err := c.versionedWalletRepo.FindByID(ctx, ID)
+		if err != nil {
+			return err
+		}
+		if err := c.versionedWalletRepo.UpdateWallet(ctx, ID, stored.Wallet()); err != nil {
+			return err
+		}
+	}
+
+	return c.versionedWalletRepo.UpdateWallet(ctx, ID, wallet)
+}
+
+func newWalletUser(amount int64) entity.User {
+	return entity.NewCommonUser(
+		vo.NewUuidRandom(),
+		vo.NewFullName("Test testing"),
+		vo.NewEmailTest("test@testing.com"),
+		vo.NewHashedPasswordTest("$2a$10$passw"),
+		vo.NewDocumentTest(vo.CPF, "07091054954"),
+		vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(amount))),
+		time.Now(),
+	)
+}
+
+func Test_createTransferInteractor_ExecuteConflict(t *testing.T) {
+	tests := []struct {
+		name        string
+		conflicts   int
+		wantCalls   int
+		wantBalance int64
+		wantErr     error
+	}{
+		{
+			name:        "Transfer retried after a concurrent update of the payer wallet",
+			conflicts:   1,
+			wantCalls:   2,
+			wantBalance: 900,
+		},
+		{
+			name:        "Transfer retried after several concurrent updates of the payer wallet",
+			conflicts:   maxConflictAttempts - 1,
+			wantCalls:   maxConflictAttempts,
+			wantBalance: 900,
+		},
+		{
+			name:        "Concurrent updates of the payer wallet exhaust the attempts",
+			conflicts:   maxConflictAttempts,
+			wantCalls:   maxConflictAttempts,
+			wantBalance: 1000,
+			wantErr:     entity.ErrConcurrentModification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				payer      = newWalletUser(1000)
+				payee      = newWalletUser(0)
+				repo       = &concurrentWriterRepo{versionedWalletRepo: newVersionedWalletRepo(payer, payee), payer: payer.ID(), conflicts: tt.conflicts}
+				authorizer = &spyAuthorizer{stubAuthorizer: stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}}}
+			)
+
+			c := NewCreateTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				repo,
+				repo,
+				stubLedgerRepoCreator{},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+				stubOutboxRepoCreator{},
+				stubTransferRepoAggregator{},
+				stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+				stubTransferLimitProvider{},
+				authorizer,
+				&spyEventPublisher{},
+				spyCreateTransferPresenter{},
+			)
+
+			_, err := c.Execute(context.Background(), CreateTransferInput{
+				ID:      vo.NewUuidRandom(),
+				PayerID: payer.ID(),
+				PayeeID: payee.ID(),
+				Value:   vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			// every attempt reads the payer wallet again and updates it at the version it read
+			if repo.calls != tt.wantCalls {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, repo.calls, tt.wantCalls)
+			}
+
+			stored, _ := repo.FindByID(context.Background(), payer.ID())
+			if got := stored.Wallet().Money().Amount().Value(); got != tt.wantBalance {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, got, tt.wantBalance)
+			}
+
+			// the attempts after a conflict reuse the decision, the authorizer is not asked again
+			if authorizer.calls != 1 {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, authorizer.calls, 1)
+			}
+		})
+	}
+}
+
+func Test_createTransferInteractor_ExecuteSelfTransfer(t *testing.T) {
+	var (
+		payer   = newWalletUser(1000)
+		updater = &spyUserRepoUpdater{}
+		events  = &spyEventPublisher{}
+	)
+
+	c := NewCreateTransferInteractor(
+		stubTransferRepoCreator{err: errors.New("must not create transfer")},
+		stubTransferRepoUpdater{},
+		updater,
+		newVersionedWalletRepo(payer),
+		stubLedgerRepoCreator{},
+		stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+		stubIdempotencyRepo{err: entity.ErrNotFoundIdempotencyKey},
+		stubOutboxRepoCreator{},
+		stubTransferRepoAggregator{},
+		stubFXRateProvider{err: vo.ErrExchangeRateUnavailable},
+		stubTransferLimitProvider{},
+		stubAuthorizer{result: AuthorizationDecision{Outcome: AuthorizationApproved}},
+		events,
+		spyCreateTransferPresenter{},
+	)
+
+	got, err := c.Execute(context.Background(), CreateTransferInput{
+		ID:      vo.NewUuidRandom(),
+		PayerID: payer.ID(),
+		PayeeID: payer.ID(),
+		Value:   vo.NewMoneyNGN(vo.NewAmountTest(100)),
+	})
+	if !errors.Is(err, entity.ErrSelfTransfer) {
+		t.Fatalf("[TestCase 'Self transfer'] Err: '%v' | WantErr: '%v'", err, entity.ErrSelfTransfer)
+	}
+
+	if got.ID != "" {
+		t.Errorf("[TestCase 'Self transfer'] Got: '%+v' | Want: '%+v'", got.ID, "")
+	}
+
+	// the transfer is rejected before the transaction, nothing is debited nor recorded as FAILED
+	if updater.invoked || len(events.names) != 0 {
+		t.Errorf("[TestCase 'Self transfer'] Got: '%v' updated, '%v' events | Want: no update and no event", updater.invoked, events.names)
+	}
+}
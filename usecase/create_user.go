@@ -26,6 +26,13 @@ type (
 		CreatedAt time.Time
 	}
 
+	// PasswordHasher port, hashes the passwords before they are stored
+	PasswordHasher interface {
+		Hash(vo.Password) (vo.HashedPassword, error)
+		Verify(password string, hash vo.HashedPassword) (bool, error)
+		NeedsRehash(vo.HashedPassword) bool
+	}
+
 	// Output port
 	CreateUserPresenter interface {
 		Output(entity.User) CreateUserOutput
@@ -36,7 +43,6 @@ type (
 		ID        string                   `json:"id"`
 		FullName  string                   `json:"full_name"`
 		Email     string                   `json:"email"`
-		Password  string                   `json:"password"`
 		Document  CreateUserDocumentOutput `json:"document"`
 		Wallet    CreateUserWalletOutput   `json:"wallet"`
 		Roles     CreateUserRolesOutput    `json:"roles"`
@@ -62,16 +68,28 @@ type (
 	}
 
 	createUserInteractor struct {
-		repo entity.UserRepositoryCreator
-		pre  CreateUserPresenter
+		repo       entity.UserRepositoryCreator
+		repoLedger entity.LedgerRepositoryCreator
+		hasher     PasswordHasher
+		events     EventPublisher
+		pre        CreateUserPresenter
 	}
 )
 
 // NewCreateUserInteractor creates new createUserInteractor with its dependencies
-func NewCreateUserInteractor(repo entity.UserRepositoryCreator, pre CreateUserPresenter) CreateUserUseCase {
+func NewCreateUserInteractor(
+	repo entity.UserRepositoryCreator,
+	repoLedger entity.LedgerRepositoryCreator,
+	hasher PasswordHasher,
+	events EventPublisher,
+	pre CreateUserPresenter,
+) CreateUserUseCase {
 	return createUserInteractor{
-		repo: repo,
-		pre:  pre,
+		repo:       repo,
+		repoLedger: repoLedger,
+		hasher:     hasher,
+		events:     events,
+		pre:        pre,
 	}
 }
 
@@ -80,11 +98,16 @@ func (c createUserInteractor) Execute(ctx context.Context, i CreateUserInput) (C
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	password, err := c.hasher.Hash(i.Password)
+	if err != nil {
+		return c.pre.Output(entity.User{}), err
+	}
+
 	u, err := entity.NewUser(
 		i.ID,
 		i.FullName,
 		i.Email,
-		i.Password,
+		password,
 		i.Document,
 		i.Wallet,
 		i.Type,
@@ -93,12 +116,38 @@ func (c createUserInteractor) Execute(ctx context.Context, i CreateUserInput) (C
 	if err != nil {
 		return c.pre.Output(entity.User{}), err
 	}
+	u.Register(i.CreatedAt)
+
+	var user entity.User
+	err = c.repo.WithTransaction(ctx, func(sessCtx context.Context) error {
+		user, err = c.repo.Create(sessCtx, u)
+		if err != nil {
+			return err
+		}
 
-	user, err := c.repo.Create(ctx, u)
+		return c.openWallet(sessCtx, user)
+	})
 	if err != nil {
 		return c.pre.Output(entity.User{}), err
 	}
 
+	// the user is committed, the failures of the subscribers are reported by the publisher
+	_ = c.events.Publish(ctx, u.PullEvents()...)
+
 	return c.pre.Output(user), nil
 }
 
+// openWallet records the opening balance of the wallet in the ledger, so its balance can be recomputed from postings
+func (c createUserInteractor) openWallet(ctx context.Context, u entity.User) error {
+	if u.Wallet() == nil || u.Wallet().Money().Amount().Value() == 0 {
+		return nil
+	}
+
+	entry, err := entity.NewOpeningBalanceJournalEntry(vo.NewUuidRandom(), u)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.repoLedger.Create(ctx, entry)
+	return err
+}
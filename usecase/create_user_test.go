@@ -20,6 +20,33 @@ func (c stubUserRepoCreator) Create(context.Context, entity.User) (entity.User,
 	return c.result, c.err
 }
 
+func (c stubUserRepoCreator) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type stubPasswordHasher struct {
+	result   vo.HashedPassword
+	mismatch bool
+	rehash   bool
+	err      error
+	verified *int
+}
+
+func (s stubPasswordHasher) Hash(vo.Password) (vo.HashedPassword, error) {
+	return s.result, s.err
+}
+
+func (s stubPasswordHasher) Verify(string, vo.HashedPassword) (bool, error) {
+	if s.verified != nil {
+		*s.verified++
+	}
+	return !s.mismatch && s.err == nil, s.err
+}
+
+func (s stubPasswordHasher) NeedsRehash(vo.HashedPassword) bool {
+	return s.rehash
+}
+
 type stubCreateUserPresenter struct {
 	result CreateUserOutput
 }
@@ -30,8 +57,9 @@ func (c stubCreateUserPresenter) Output(_ entity.User) CreateUserOutput {
 
 func TestCreateUserInteractor_Execute(t *testing.T) {
 	type fields struct {
-		repo entity.UserRepositoryCreator
-		pre  CreateUserPresenter
+		repo   entity.UserRepositoryCreator
+		hasher PasswordHasher
+		pre    CreateUserPresenter
 	}
 
 	type args struct {
@@ -53,13 +81,14 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Test testing"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 						nil,
 						time.Time{},
 					),
 					err: nil,
 				},
+				hasher: stubPasswordHasher{result: vo.NewHashedPasswordTest("$2a$10$passw")},
 				pre: stubCreateUserPresenter{
 					result: CreateUserOutput{
 						ID:       "0db298eb-c8e7-4829-84b7-c1036b4f0791",
@@ -69,7 +98,6 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 							Value: "34018708000191",
 						},
 						Email:     "test@testing.com",
-						Password:  "passw",
 						Wallet:    CreateUserWalletOutput{},
 						Type:      vo.COMMON.String(),
 						CreatedAt: time.Time{}.String(),
@@ -81,7 +109,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					FullName: vo.NewFullName("Test testing"),
 					Document: vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 					Email:    vo.NewEmailTest("test@testing.com"),
-					Password: vo.NewPassword("passw"),
+					Password: vo.NewPasswordTest("Passw0rd"),
 					Wallet:   nil,
 					Type:     "COMMON",
 				},
@@ -94,7 +122,6 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					Value: "34018708000191",
 				},
 				Email:     "test@testing.com",
-				Password:  "passw",
 				Wallet:    CreateUserWalletOutput{},
 				Type:      vo.COMMON.String(),
 				CreatedAt: time.Time{}.String(),
@@ -109,13 +136,14 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Test testing"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 						vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
 						time.Now(),
 					),
 					err: nil,
 				},
+				hasher: stubPasswordHasher{result: vo.NewHashedPasswordTest("$2a$10$passw")},
 				pre: stubCreateUserPresenter{
 					result: CreateUserOutput{
 						ID:       "0db298eb-c8e7-4829-84b7-c1036b4f0791",
@@ -125,7 +153,6 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 							Value: "34018708000191",
 						},
 						Email:     "test@testing.com",
-						Password:  "passw",
 						Wallet:    CreateUserWalletOutput{},
 						Type:      vo.COMMON.String(),
 						CreatedAt: time.Time{}.String(),
@@ -137,7 +164,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					FullName: vo.NewFullName("Test testing"),
 					Document: vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 					Email:    vo.NewEmailTest("test@testing.com"),
-					Password: vo.NewPassword("passw"),
+					Password: vo.NewPasswordTest("Passw0rd"),
 					Wallet:   nil,
 					Type:     "COMMON",
 				},
@@ -150,7 +177,6 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					Value: "34018708000191",
 				},
 				Email:     "test@testing.com",
-				Password:  "passw",
 				Wallet:    CreateUserWalletOutput{},
 				Type:      vo.COMMON.String(),
 				CreatedAt: time.Time{}.String(),
@@ -164,6 +190,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					result: entity.User{},
 					err:    errors.New("failed created user"),
 				},
+				hasher: stubPasswordHasher{result: vo.NewHashedPasswordTest("$2a$10$passw")},
 				pre: stubCreateUserPresenter{
 					result: CreateUserOutput{},
 				},
@@ -173,7 +200,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					FullName: vo.NewFullName("Test testing"),
 					Document: vo.NewDocumentTest(vo.CNPJ, "98.521.079/0001-09"),
 					Email:    vo.NewEmailTest("test@testing.com"),
-					Password: vo.NewPassword("passw"),
+					Password: vo.NewPasswordTest("Passw0rd"),
 					Wallet:   nil,
 					Type:     "COMMON",
 				},
@@ -189,13 +216,14 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Test testing"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 						nil,
 						time.Now(),
 					),
 					err: errors.New("fail database"),
 				},
+				hasher: stubPasswordHasher{result: vo.NewHashedPasswordTest("$2a$10$passw")},
 				pre: stubCreateUserPresenter{
 					result: CreateUserOutput{},
 				},
@@ -205,7 +233,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					FullName: vo.NewFullName("Test testing"),
 					Document: vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 					Email:    vo.NewEmailTest("test@testing.com"),
-					Password: vo.NewPassword("passw"),
+					Password: vo.NewPasswordTest("Passw0rd"),
 					Wallet:   nil,
 					Type:     vo.COMMON,
 				},
@@ -221,13 +249,14 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Test testing"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 						nil,
 						time.Now(),
 					),
 					err: errors.New("fail database"),
 				},
+				hasher: stubPasswordHasher{result: vo.NewHashedPasswordTest("$2a$10$passw")},
 				pre: stubCreateUserPresenter{
 					result: CreateUserOutput{},
 				},
@@ -237,7 +266,7 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 					FullName: vo.NewFullName("Test testing"),
 					Document: vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 					Email:    vo.NewEmailTest("test@testing.com"),
-					Password: vo.NewPassword("passw"),
+					Password: vo.NewPasswordTest("Passw0rd"),
 					Wallet:   nil,
 					Type:     "Test",
 				},
@@ -245,11 +274,36 @@ func TestCreateUserInteractor_Execute(t *testing.T) {
 			want:    CreateUserOutput{},
 			wantErr: true,
 		},
+		{
+			name: "Create common user hash password error",
+			fields: fields{
+				repo:   stubUserRepoCreator{},
+				hasher: stubPasswordHasher{err: errors.New("fail hash")},
+				pre: stubCreateUserPresenter{
+					result: CreateUserOutput{},
+				},
+			},
+			args: args{
+				input: CreateUserInput{
+					FullName: vo.NewFullName("Test testing"),
+					Document: vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
+					Email:    vo.NewEmailTest("test@testing.com"),
+					Password: vo.NewPasswordTest("Passw0rd"),
+					Wallet:   nil,
+					Type:     vo.COMMON,
+				},
+			},
+			want:    CreateUserOutput{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := NewCreateUserInteractor(
 				tt.fields.repo,
+				stubLedgerRepoCreator{},
+				tt.fields.hasher,
+				&spyEventPublisher{},
 				tt.fields.pre,
 			)
 
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+)
+
+type (
+	// EventPublisher port, delivers the events recorded by the entities to their subscribers
+	EventPublisher interface {
+		Publish(ctx context.Context, events ...entity.Event) error
+	}
+
+	// EventHandler reacts to an event
+	EventHandler func(ctx context.Context, event entity.Event) error
+
+	// EventErrorHandler is told about the failures of the subscribers
+	EventErrorHandler func(event entity.Event, err error)
+
+	// EventBus dispatches the events in process to the subscribers of their names
+	EventBus struct {
+		mu      sync.RWMutex
+		sync    map[string][]EventHandler
+		async   map[string][]EventHandler
+		running sync.WaitGroup
+		onError EventErrorHandler
+	}
+)
+
+// NewEventBus creates new EventBus, onError is told about the failures of the subscribers and may be nil
+func NewEventBus(onError EventErrorHandler) *EventBus {
+	if onError == nil {
+		onError = func(entity.Event, error) {}
+	}
+
+	return &EventBus{
+		sync:    make(map[string][]EventHandler),
+		async:   make(map[string][]EventHandler),
+		onError: onError,
+	}
+}
+
+// Subscribe registers a handler run by Publish before it returns, in the order they were subscribed
+func (b *EventBus) Subscribe(name string, h EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sync[name] = append(b.sync[name], h)
+}
+
+// SubscribeAsync registers a handler run in its own goroutine, it gets a context that is not canceled with the one
+// given to Publish
+func (b *EventBus) SubscribeAsync(name string, h EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.async[name] = append(b.async[name], h)
+}
+
+// Publish runs the synchronous handlers of every event and starts the asynchronous ones.
+// A failed handler does not stop the others, the first error of the synchronous ones is returned
+func (b *EventBus) Publish(ctx context.Context, events ...entity.Event) error {
+	var first error
+	for _, event := range events {
+		b.mu.RLock()
+		handlers, async := b.sync[event.EventName()], b.async[event.EventName()]
+		b.mu.RUnlock()
+
+		for _, h := range async {
+			b.running.Add(1)
+			go func(h EventHandler, event entity.Event) {
+				defer b.running.Done()
+				if err := b.run(context.Background(), h, event); err != nil {
+					b.onError(event, err)
+				}
+			}(h, event)
+		}
+
+		for _, h := range handlers {
+			if err := b.run(ctx, h, event); err != nil {
+				b.onError(event, err)
+				if first == nil {
+					first = err
+				}
+			}
+		}
+	}
+
+	return first
+}
+
+// Wait blocks until the asynchronous handlers started so far are done
+func (b *EventBus) Wait() {
+	b.running.Wait()
+}
+
+// run calls the handler turning a panic into an error, so a faulty subscriber can not take the publisher down
+func (b *EventBus) run(ctx context.Context, h EventHandler, event entity.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber of %s panicked: %v", event.EventName(), r)
+		}
+	}()
+
+	return h(ctx, event)
+}
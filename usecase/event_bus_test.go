@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type spyEventPublisher struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (s *spyEventPublisher) Publish(_ context.Context, events ...entity.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		s.names = append(s.names, event.EventName())
+	}
+
+	return nil
+}
+
+func TestEventBus_Publish(t *testing.T) {
+	var (
+		created = entity.UserCreated{UserID: vo.NewUuidStaticTest(), At: time.Now()}
+		failed  = entity.TransferFailed{TransferID: vo.NewUuidStaticTest(), At: time.Now()}
+	)
+
+	tests := []struct {
+		name        string
+		subscribe   func(b *EventBus, calls *[]string, mu *sync.Mutex)
+		events      []entity.Event
+		wantCalls   []string
+		wantErr     bool
+		wantFailure int
+	}{
+		{
+			name: "Synchronous subscribers run in order for their event only",
+			subscribe: func(b *EventBus, calls *[]string, mu *sync.Mutex) {
+				b.Subscribe(entity.UserCreatedEvent, record("first", calls, mu, nil))
+				b.Subscribe(entity.UserCreatedEvent, record("second", calls, mu, nil))
+				b.Subscribe(entity.TransferFailedEvent, record("failed", calls, mu, nil))
+			},
+			events:    []entity.Event{created},
+			wantCalls: []string{"first", "second"},
+		},
+		{
+			name: "Asynchronous subscribers run",
+			subscribe: func(b *EventBus, calls *[]string, mu *sync.Mutex) {
+				b.SubscribeAsync(entity.TransferFailedEvent, record("async", calls, mu, nil))
+			},
+			events:    []entity.Event{failed},
+			wantCalls: []string{"async"},
+		},
+		{
+			name: "Failed synchronous subscriber does not stop the others",
+			subscribe: func(b *EventBus, calls *[]string, mu *sync.Mutex) {
+				b.Subscribe(entity.UserCreatedEvent, record("failing", calls, mu, errors.New("fail")))
+				b.Subscribe(entity.UserCreatedEvent, record("next", calls, mu, nil))
+			},
+			events:      []entity.Event{created},
+			wantCalls:   []string{"failing", "next"},
+			wantErr:     true,
+			wantFailure: 1,
+		},
+		{
+			name: "Panicking subscribers are reported",
+			subscribe: func(b *EventBus, calls *[]string, mu *sync.Mutex) {
+				b.Subscribe(entity.UserCreatedEvent, func(context.Context, entity.Event) error { panic("boom") })
+				b.SubscribeAsync(entity.UserCreatedEvent, func(context.Context, entity.Event) error { panic("boom") })
+			},
+			events:      []entity.Event{created},
+			wantErr:     true,
+			wantFailure: 2,
+		},
+		{
+			name:   "Event without subscribers",
+			events: []entity.Event{created},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				calls    []string
+				failures int
+				b        = NewEventBus(func(entity.Event, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					failures++
+				})
+			)
+			if tt.subscribe != nil {
+				tt.subscribe(b, &calls, &mu)
+			}
+
+			err := b.Publish(context.Background(), tt.events...)
+			b.Wait()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, calls, tt.wantCalls)
+			}
+
+			if failures != tt.wantFailure {
+				t.Errorf("[TestCase '%s'] Got: '%d failures' | Want: '%d failures'", tt.name, failures, tt.wantFailure)
+			}
+		})
+	}
+}
+
+func record(name string, calls *[]string, mu *sync.Mutex, err error) EventHandler {
+	return func(context.Context, entity.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*calls = append(*calls, name)
+		return err
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type (
+	// Input port
+	FindTransferByIDUseCase interface {
+		Execute(context.Context, FindTransferByIDInput) (FindTransferByIDOutput, error)
+	}
+
+	// Input data
+	FindTransferByIDInput struct {
+		ID vo.Uuid
+		// RequestedBy is the authenticated user, only the payer and the payee of the transfer can read it
+		RequestedBy vo.Uuid
+	}
+
+	// Output port
+	FindTransferByIDPresenter interface {
+		Output(entity.Transfer) FindTransferByIDOutput
+	}
+
+	// Output data
+	FindTransferByIDOutput struct {
+		ID               string                          `json:"id"`
+		PayerID          string                          `json:"payer"`
+		PayeeID          string                          `json:"payee"`
+		Value            int64                           `json:"value"`
+		Currency         string                          `json:"currency"`
+		CreditedValue    int64                           `json:"credited_value"`
+		CreditedCurrency string                          `json:"credited_currency"`
+		Rate             string                          `json:"rate"`
+		Status           string                          `json:"status"`
+		History          []FindTransferByIDHistoryOutput `json:"history"`
+		CreatedAt        string                          `json:"created_at"`
+	}
+
+	// Output data
+	FindTransferByIDHistoryOutput struct {
+		From   string `json:"from,omitempty"`
+		To     string `json:"to"`
+		Reason string `json:"reason,omitempty"`
+		At     string `json:"at"`
+	}
+
+	findTransferByIDInteractor struct {
+		repo entity.TransferRepositoryFinder
+		pre  FindTransferByIDPresenter
+	}
+)
+
+// NewFindTransferByIDInteractor creates new findTransferByIDInteractor with its dependencies
+func NewFindTransferByIDInteractor(repo entity.TransferRepositoryFinder, pre FindTransferByIDPresenter) FindTransferByIDUseCase {
+	return findTransferByIDInteractor{
+		repo: repo,
+		pre:  pre,
+	}
+}
+
+// Execute orchestrates the use case
+func (f findTransferByIDInteractor) Execute(ctx context.Context, i FindTransferByIDInput) (FindTransferByIDOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	transfer, err := f.repo.FindByID(ctx, i.ID)
+	if err != nil {
+		return f.pre.Output(entity.Transfer{}), err
+	}
+
+	// A stranger is answered as if the transfer did not exist, so transfer IDs can not be probed
+	if transfer.Payer() != i.RequestedBy && transfer.Payee() != i.RequestedBy {
+		return f.pre.Output(entity.Transfer{}), entity.ErrNotFoundTransfer
+	}
+
+	return f.pre.Output(transfer), nil
+}
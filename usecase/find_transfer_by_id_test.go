@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubTransferRepoFinder struct {
+	result  entity.Transfer
+	results []entity.Transfer
+	err     error
+}
+
+func (s stubTransferRepoFinder) FindByID(_ context.Context, _ vo.Uuid) (entity.Transfer, error) {
+	return s.result, s.err
+}
+
+func (s stubTransferRepoFinder) FindByUser(_ context.Context, _ entity.TransferFilter) ([]entity.Transfer, error) {
+	return s.results, s.err
+}
+
+type stubFindTransferByIDPresenter struct {
+	result FindTransferByIDOutput
+}
+
+func (s stubFindTransferByIDPresenter) Output(_ entity.Transfer) FindTransferByIDOutput {
+	return s.result
+}
+
+func TestFindTransferByIDInteractor_Execute(t *testing.T) {
+	type fields struct {
+		repo entity.TransferRepositoryFinder
+		pre  FindTransferByIDPresenter
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		requestedBy vo.Uuid
+		want        FindTransferByIDOutput
+		wantErr     error
+	}{
+		{
+			name: "Find transfer by id success",
+			fields: fields{
+				repo: stubTransferRepoFinder{
+					result: entity.NewTransfer(
+						vo.NewUuidStaticTest(),
+						vo.NewUuidStaticTest(),
+						vo.NewUuidStaticTest(),
+						vo.NewMoneyNGN(vo.NewAmountTest(100)),
+						time.Time{},
+					),
+				},
+				pre: stubFindTransferByIDPresenter{
+					result: FindTransferByIDOutput{
+						ID:     vo.NewUuidStaticTest().Value(),
+						Status: vo.PENDING.String(),
+					},
+				},
+			},
+			requestedBy: vo.NewUuidStaticTest(),
+			want: FindTransferByIDOutput{
+				ID:     vo.NewUuidStaticTest().Value(),
+				Status: vo.PENDING.String(),
+			},
+		},
+		{
+			name: "Find transfer by id requested by a stranger",
+			fields: fields{
+				repo: stubTransferRepoFinder{
+					result: entity.NewTransfer(
+						vo.NewUuidStaticTest(),
+						vo.NewUuidStaticTest(),
+						vo.NewUuidStaticTest(),
+						vo.NewMoneyNGN(vo.NewAmountTest(100)),
+						time.Time{},
+					),
+				},
+				pre: stubFindTransferByIDPresenter{},
+			},
+			requestedBy: vo.NewUuidRandom(),
+			want:        FindTransferByIDOutput{},
+			wantErr:     entity.ErrNotFoundTransfer,
+		},
+		{
+			name: "Find transfer by id not found",
+			fields: fields{
+				repo: stubTransferRepoFinder{
+					err: entity.ErrNotFoundTransfer,
+				},
+				pre: stubFindTransferByIDPresenter{},
+			},
+			requestedBy: vo.NewUuidStaticTest(),
+			want:        FindTransferByIDOutput{},
+			wantErr:     entity.ErrNotFoundTransfer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFindTransferByIDInteractor(tt.fields.repo, tt.fields.pre)
+
+			got, err := f.Execute(context.Background(), FindTransferByIDInput{
+				ID:          vo.NewUuidStaticTest(),
+				RequestedBy: tt.requestedBy,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
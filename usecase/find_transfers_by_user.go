@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+const (
+	defaultTransfersLimit = 20
+	maxTransfersLimit     = 100
+)
+
+type (
+	// Input port
+	FindTransfersByUserUseCase interface {
+		Execute(context.Context, FindTransfersByUserInput) (FindTransfersByUserOutput, error)
+	}
+
+	// Input data
+	FindTransfersByUserInput struct {
+		UserID    vo.Uuid
+		Direction entity.TransferDirection
+		From      time.Time
+		To        time.Time
+		MinValue  vo.Amount
+		MaxValue  vo.Amount
+		Status    vo.TransferStatus
+		Cursor    string
+		Limit     int
+	}
+
+	// Output port
+	FindTransfersByUserPresenter interface {
+		Output(userID vo.Uuid, transfers []entity.Transfer, nextCursor string) FindTransfersByUserOutput
+	}
+
+	// Output data
+	FindTransfersByUserOutput struct {
+		Transfers  []FindTransfersByUserTransferOutput `json:"transfers"`
+		NextCursor string                              `json:"next_cursor,omitempty"`
+	}
+
+	// Output data
+	FindTransfersByUserTransferOutput struct {
+		ID         string `json:"id"`
+		Direction  string `json:"direction"`
+		PayerID    string `json:"payer"`
+		PayeeID    string `json:"payee"`
+		Value      int64  `json:"value"`
+		Currency   string `json:"currency"`
+		Status     string `json:"status"`
+		ReversalOf string `json:"reversal_of,omitempty"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	// transferCursor is the content of the opaque cursor handed to the clients
+	transferCursor struct {
+		CreatedAt time.Time `json:"created_at"`
+		ID        string    `json:"id"`
+	}
+
+	findTransfersByUserInteractor struct {
+		repoUserFinder     entity.UserRepositoryFinder
+		repoTransferFinder entity.TransferRepositoryFinder
+		pre                FindTransfersByUserPresenter
+	}
+)
+
+// NewFindTransfersByUserInteractor creates new findTransfersByUserInteractor with its dependencies
+func NewFindTransfersByUserInteractor(
+	repoUserFinder entity.UserRepositoryFinder,
+	repoTransferFinder entity.TransferRepositoryFinder,
+	pre FindTransfersByUserPresenter,
+) FindTransfersByUserUseCase {
+	return findTransfersByUserInteractor{
+		repoUserFinder:     repoUserFinder,
+		repoTransferFinder: repoTransferFinder,
+		pre:                pre,
+	}
+}
+
+// Execute orchestrates the use case
+func (f findTransfersByUserInteractor) Execute(ctx context.Context, i FindTransfersByUserInput) (FindTransfersByUserOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := entity.TransferFilter{
+		UserID:    i.UserID,
+		Direction: i.Direction,
+		From:      i.From,
+		To:        i.To,
+		MinValue:  i.MinValue,
+		MaxValue:  i.MaxValue,
+		Status:    i.Status,
+		Limit:     i.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransfersLimit
+	}
+	if filter.Limit > maxTransfersLimit {
+		filter.Limit = maxTransfersLimit
+	}
+
+	if i.Cursor != "" {
+		after, err := decodeTransferCursor(i.Cursor)
+		if err != nil {
+			return f.pre.Output(i.UserID, nil, ""), err
+		}
+		filter.After = &after
+	}
+
+	if _, err := f.repoUserFinder.FindByID(ctx, i.UserID); err != nil {
+		return f.pre.Output(i.UserID, nil, ""), err
+	}
+
+	// one extra transfer is fetched to know whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	transfers, err := f.repoTransferFinder.FindByUser(ctx, filter)
+	if err != nil {
+		return f.pre.Output(i.UserID, nil, ""), err
+	}
+
+	var nextCursor string
+	if len(transfers) > limit {
+		transfers = transfers[:limit]
+		nextCursor = encodeTransferCursor(entity.NewTransferCursor(transfers[limit-1]))
+	}
+
+	return f.pre.Output(i.UserID, transfers, nextCursor), nil
+}
+
+func encodeTransferCursor(c entity.TransferCursor) string {
+	b, _ := json.Marshal(transferCursor{CreatedAt: c.CreatedAt, ID: c.ID.Value()})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeTransferCursor(value string) (entity.TransferCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.TransferCursor{}, entity.ErrInvalidTransferCursor
+	}
+
+	var c transferCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return entity.TransferCursor{}, entity.ErrInvalidTransferCursor
+	}
+
+	ID, err := vo.NewUuid(c.ID)
+	if err != nil || c.CreatedAt.IsZero() {
+		return entity.TransferCursor{}, entity.ErrInvalidTransferCursor
+	}
+
+	return entity.TransferCursor{CreatedAt: c.CreatedAt, ID: ID}, nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type spyTransferRepoFinder struct {
+	stubTransferRepoFinder
+	filter *entity.TransferFilter
+}
+
+func (s spyTransferRepoFinder) FindByUser(ctx context.Context, filter entity.TransferFilter) ([]entity.Transfer, error) {
+	*s.filter = filter
+	return s.stubTransferRepoFinder.FindByUser(ctx, filter)
+}
+
+type spyFindTransfersByUserPresenter struct{}
+
+func (s spyFindTransfersByUserPresenter) Output(_ vo.Uuid, transfers []entity.Transfer, nextCursor string) FindTransfersByUserOutput {
+	var output = make([]FindTransfersByUserTransferOutput, 0)
+	for _, t := range transfers {
+		output = append(output, FindTransfersByUserTransferOutput{ID: t.ID().Value()})
+	}
+
+	return FindTransfersByUserOutput{Transfers: output, NextCursor: nextCursor}
+}
+
+func TestFindTransfersByUserInteractor_Execute(t *testing.T) {
+	var newTransfer = func(ID string, createdAt time.Time) entity.Transfer {
+		uuid, _ := vo.NewUuid(ID)
+		return entity.NewTransfer(
+			uuid,
+			vo.NewUuidStaticTest(),
+			vo.NewUuidStaticTest(),
+			vo.NewMoneyNGN(vo.NewAmountTest(100)),
+			createdAt,
+		)
+	}
+
+	var (
+		now       = time.Date(2021, 5, 10, 12, 0, 0, 0, time.UTC)
+		transfers = []entity.Transfer{
+			newTransfer("3c9e2f6a-8d1b-4f7e-9a2c-5b6d7e8f9a01", now),
+			newTransfer("2b8d1e59-7c0a-4e6d-8f1b-4a5c6d7e8f90", now.Add(-time.Minute)),
+			newTransfer("1a7c0d48-6b9f-4d5c-9e0a-394b5c6d7e8f", now.Add(-2*time.Minute)),
+		}
+		cursor = encodeTransferCursor(entity.NewTransferCursor(transfers[1]))
+	)
+
+	tests := []struct {
+		name       string
+		user       entity.UserRepositoryFinder
+		transfers  stubTransferRepoFinder
+		input      FindTransfersByUserInput
+		want       FindTransfersByUserOutput
+		wantFilter entity.TransferFilter
+		wantErr    error
+	}{
+		{
+			name:      "First page with a next cursor",
+			user:      stubUserRepoFinder{},
+			transfers: stubTransferRepoFinder{results: transfers},
+			input: FindTransfersByUserInput{
+				UserID:    vo.NewUuidStaticTest(),
+				Direction: entity.TransferSent,
+				Limit:     2,
+			},
+			want: FindTransfersByUserOutput{
+				Transfers: []FindTransfersByUserTransferOutput{
+					{ID: transfers[0].ID().Value()},
+					{ID: transfers[1].ID().Value()},
+				},
+				NextCursor: cursor,
+			},
+			wantFilter: entity.TransferFilter{
+				UserID:    vo.NewUuidStaticTest(),
+				Direction: entity.TransferSent,
+				Limit:     3,
+			},
+		},
+		{
+			name:      "Next page with the cursor",
+			user:      stubUserRepoFinder{},
+			transfers: stubTransferRepoFinder{results: transfers[2:]},
+			input: FindTransfersByUserInput{
+				UserID: vo.NewUuidStaticTest(),
+				Cursor: cursor,
+				Limit:  2,
+			},
+			want: FindTransfersByUserOutput{
+				Transfers: []FindTransfersByUserTransferOutput{
+					{ID: transfers[2].ID().Value()},
+				},
+			},
+			wantFilter: entity.TransferFilter{
+				UserID: vo.NewUuidStaticTest(),
+				After: &entity.TransferCursor{
+					CreatedAt: transfers[1].CreatedAt(),
+					ID:        transfers[1].ID(),
+				},
+				Limit: 3,
+			},
+		},
+		{
+			name:      "Default limit",
+			user:      stubUserRepoFinder{},
+			transfers: stubTransferRepoFinder{},
+			input: FindTransfersByUserInput{
+				UserID: vo.NewUuidStaticTest(),
+				Status: vo.COMPLETED,
+			},
+			want: FindTransfersByUserOutput{
+				Transfers: []FindTransfersByUserTransferOutput{},
+			},
+			wantFilter: entity.TransferFilter{
+				UserID: vo.NewUuidStaticTest(),
+				Status: vo.COMPLETED,
+				Limit:  defaultTransfersLimit + 1,
+			},
+		},
+		{
+			name:      "Invalid cursor error",
+			user:      stubUserRepoFinder{},
+			transfers: stubTransferRepoFinder{},
+			input: FindTransfersByUserInput{
+				UserID: vo.NewUuidStaticTest(),
+				Cursor: "not-a-cursor",
+			},
+			want: FindTransfersByUserOutput{
+				Transfers: []FindTransfersByUserTransferOutput{},
+			},
+			wantErr: entity.ErrInvalidTransferCursor,
+		},
+		{
+			name:      "Not found user error",
+			user:      stubUserRepoFinder{err: entity.ErrNotFoundUser},
+			transfers: stubTransferRepoFinder{},
+			input: FindTransfersByUserInput{
+				UserID: vo.NewUuidStaticTest(),
+			},
+			want: FindTransfersByUserOutput{
+				Transfers: []FindTransfersByUserTransferOutput{},
+			},
+			wantErr: entity.ErrNotFoundUser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter entity.TransferFilter
+			f := NewFindTransfersByUserInteractor(
+				tt.user,
+				spyTransferRepoFinder{stubTransferRepoFinder: tt.transfers, filter: &filter},
+				spyFindTransfersByUserPresenter{},
+			)
+
+			got, err := f.Execute(context.Background(), tt.input)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+
+			if !reflect.DeepEqual(filter, tt.wantFilter) {
+				t.Errorf("[TestCase '%s'] Filter: '%+v' | Want: '%+v'", tt.name, filter, tt.wantFilter)
+			}
+		})
+	}
+}
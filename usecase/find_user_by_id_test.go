@@ -20,6 +20,10 @@ func (f stubUserRepoFinder) FindByID(_ context.Context, _ vo.Uuid) (entity.User,
 	return f.result, f.err
 }
 
+func (f stubUserRepoFinder) FindByEmail(_ context.Context, _ vo.Email) (entity.User, error) {
+	return f.result, f.err
+}
+
 type stubFindUserByIDPresenter struct {
 	result FindUserByIDOutput
 }
@@ -52,7 +56,7 @@ func TestFindUserByIDInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Common user"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CPF, "07091054954"),
 						nil,
 						time.Now(),
@@ -99,7 +103,7 @@ func TestFindUserByIDInteractor_Execute(t *testing.T) {
 						vo.NewUuidStaticTest(),
 						vo.NewFullName("Merchant user"),
 						vo.NewEmailTest("test@testing.com"),
-						vo.NewPassword("passw"),
+						vo.NewHashedPasswordTest("$2a$10$passw"),
 						vo.NewDocumentTest(vo.CNPJ, "20.770.438/0001-66"),
 						nil,
 						time.Now(),
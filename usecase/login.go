@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type (
+	// Input port
+	LoginUseCase interface {
+		Execute(context.Context, LoginInput) (TokenOutput, error)
+	}
+
+	// Input data
+	LoginInput struct {
+		Email vo.Email
+		// Password is not checked against the strength policy, passwords created before the policy must still log in
+		Password  string
+		CreatedAt time.Time
+	}
+
+	// TokenIssuer port, signs short-lived access tokens and generates opaque refresh tokens
+	TokenIssuer interface {
+		IssueAccessToken(userID vo.Uuid, issuedAt time.Time) (token string, expiresAt time.Time, err error)
+		IssueRefreshToken(issuedAt time.Time) (token string, expiresAt time.Time, err error)
+	}
+
+	// Tokens issued to an authenticated user
+	Tokens struct {
+		AccessToken          string
+		AccessTokenExpiresAt time.Time
+		RefreshToken         string
+		IssuedAt             time.Time
+	}
+
+	// Output port
+	TokenPresenter interface {
+		Output(Tokens) TokenOutput
+	}
+
+	// Output data
+	TokenOutput struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	loginInteractor struct {
+		repoUserFinder   entity.UserRepositoryFinder
+		repoUserUpdater  entity.UserRepositoryUpdater
+		repoTokenCreator entity.RefreshTokenRepositoryCreator
+		hasher           PasswordHasher
+		// dummyHash is verified when the email is unknown, so the response time does not reveal the registered emails
+		dummyHash vo.HashedPassword
+		tokens    TokenIssuer
+		pre       TokenPresenter
+	}
+)
+
+// dummyPassword is hashed once with the configured algorithm and cost to give the unknown emails a hash to verify
+const dummyPassword = "dummy-password-of-an-unknown-user"
+
+// NewLoginInteractor creates new loginInteractor with its dependencies
+func NewLoginInteractor(
+	repoUserFinder entity.UserRepositoryFinder,
+	repoUserUpdater entity.UserRepositoryUpdater,
+	repoTokenCreator entity.RefreshTokenRepositoryCreator,
+	hasher PasswordHasher,
+	tokens TokenIssuer,
+	pre TokenPresenter,
+) LoginUseCase {
+	var dummyHash vo.HashedPassword
+	if password, err := vo.NewPassword(dummyPassword); err == nil {
+		dummyHash, _ = hasher.Hash(password)
+	}
+
+	return loginInteractor{
+		repoUserFinder:   repoUserFinder,
+		repoUserUpdater:  repoUserUpdater,
+		repoTokenCreator: repoTokenCreator,
+		hasher:           hasher,
+		dummyHash:        dummyHash,
+		tokens:           tokens,
+		pre:              pre,
+	}
+}
+
+// Execute orchestrates the use case
+func (l loginInteractor) Execute(ctx context.Context, i LoginInput) (TokenOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := l.repoUserFinder.FindByEmail(ctx, i.Email)
+	if err != nil {
+		if err == entity.ErrNotFoundUser {
+			_, _ = l.hasher.Verify(i.Password, l.dummyHash)
+			return l.pre.Output(Tokens{}), entity.ErrInvalidCredentials
+		}
+		return l.pre.Output(Tokens{}), err
+	}
+
+	// a hash that can not be verified, like a legacy plaintext password, is a failed login as well
+	ok, err := l.hasher.Verify(i.Password, user.Password())
+	if err != nil || !ok {
+		return l.pre.Output(Tokens{}), entity.ErrInvalidCredentials
+	}
+
+	l.rehash(ctx, user, i.Password)
+
+	tokens, err := issueTokens(ctx, l.tokens, l.repoTokenCreator, user.ID(), i.CreatedAt)
+	if err != nil {
+		return l.pre.Output(Tokens{}), err
+	}
+
+	return l.pre.Output(tokens), nil
+}
+
+// rehash upgrades the hash to the configured algorithm and cost while the plaintext is at hand,
+// it is best effort, a failure only means it is tried again on the next login
+func (l loginInteractor) rehash(ctx context.Context, user entity.User, plaintext string) {
+	if !l.hasher.NeedsRehash(user.Password()) {
+		return
+	}
+
+	password, err := vo.NewPassword(plaintext)
+	if err != nil {
+		return
+	}
+
+	hash, err := l.hasher.Hash(password)
+	if err != nil {
+		return
+	}
+
+	_ = l.repoUserUpdater.UpdatePassword(ctx, user.ID(), hash)
+}
+
+// issueTokens signs an access token and stores the hash of a new refresh token for the user
+func issueTokens(
+	ctx context.Context,
+	issuer TokenIssuer,
+	repo entity.RefreshTokenRepositoryCreator,
+	userID vo.Uuid,
+	issuedAt time.Time,
+) (Tokens, error) {
+	accessToken, accessExpiresAt, err := issuer.IssueAccessToken(userID, issuedAt)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	refreshToken, refreshExpiresAt, err := issuer.IssueRefreshToken(issuedAt)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	var stored = entity.NewRefreshToken(vo.NewUuidRandom(), userID, refreshToken, refreshExpiresAt, issuedAt)
+	if err = repo.Create(ctx, stored); err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessExpiresAt,
+		RefreshToken:         refreshToken,
+		IssuedAt:             issuedAt,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubTokenIssuer struct {
+	err error
+}
+
+func (s stubTokenIssuer) IssueAccessToken(userID vo.Uuid, issuedAt time.Time) (string, time.Time, error) {
+	return "access-" + userID.Value(), issuedAt.Add(15 * time.Minute), s.err
+}
+
+func (s stubTokenIssuer) IssueRefreshToken(issuedAt time.Time) (string, time.Time, error) {
+	return "refresh", issuedAt.Add(time.Hour), s.err
+}
+
+type spyRefreshTokenRepoCreator struct {
+	created *entity.RefreshToken
+	err     error
+}
+
+func (s spyRefreshTokenRepoCreator) Create(_ context.Context, r entity.RefreshToken) error {
+	if s.created != nil {
+		*s.created = r
+	}
+	return s.err
+}
+
+type spyUserPasswordUpdater struct {
+	*spyUserRepoUpdater
+	updated *vo.HashedPassword
+}
+
+func (s spyUserPasswordUpdater) UpdatePassword(_ context.Context, _ vo.Uuid, password vo.HashedPassword) error {
+	*s.updated = password
+	return nil
+}
+
+type spyTokenPresenter struct{}
+
+func (s spyTokenPresenter) Output(tokens Tokens) TokenOutput {
+	return TokenOutput{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}
+}
+
+func TestLoginInteractor_Execute(t *testing.T) {
+	var (
+		now  = time.Date(2021, 5, 10, 12, 0, 0, 0, time.UTC)
+		user = entity.NewCommonUser(
+			vo.NewUuidStaticTest(),
+			vo.NewFullName("Test testing"),
+			vo.NewEmailTest("test@testing.com"),
+			vo.NewHashedPasswordTest("$2a$10$passw"),
+			vo.NewDocumentTest(vo.CPF, "07091054954"),
+			vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(100))),
+			now,
+		)
+		rehashed = vo.NewHashedPasswordTest("$argon2id$rehashed")
+	)
+
+	tests := []struct {
+		name         string
+		user         stubUserRepoFinder
+		hasher       stubPasswordHasher
+		tokens       stubTokenIssuer
+		password     string
+		want         TokenOutput
+		wantRehashed vo.HashedPassword
+		wantErr      error
+	}{
+		{
+			name:     "Login success",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{},
+			password: "Passw0rd",
+			want: TokenOutput{
+				AccessToken:  "access-" + vo.NewUuidStaticTest().Value(),
+				RefreshToken: "refresh",
+			},
+		},
+		{
+			name:     "Login rehashes an outdated hash",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{result: rehashed, rehash: true},
+			password: "Passw0rd",
+			want: TokenOutput{
+				AccessToken:  "access-" + vo.NewUuidStaticTest().Value(),
+				RefreshToken: "refresh",
+			},
+			wantRehashed: rehashed,
+		},
+		{
+			name:     "Login does not rehash a password that fails the policy",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{result: rehashed, rehash: true},
+			password: "passw",
+			want: TokenOutput{
+				AccessToken:  "access-" + vo.NewUuidStaticTest().Value(),
+				RefreshToken: "refresh",
+			},
+		},
+		{
+			name:     "Login with a wrong password error",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{mismatch: true},
+			password: "Wr0ngPassword",
+			wantErr:  entity.ErrInvalidCredentials,
+		},
+		{
+			name:     "Login with an unknown email error",
+			user:     stubUserRepoFinder{err: entity.ErrNotFoundUser},
+			hasher:   stubPasswordHasher{},
+			password: "Passw0rd",
+			wantErr:  entity.ErrInvalidCredentials,
+		},
+		{
+			name:     "Login with a hash that can not be verified error",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{err: vo.ErrInvalidHashedPassword},
+			password: "Passw0rd",
+			wantErr:  entity.ErrInvalidCredentials,
+		},
+		{
+			name:     "Login token issuer error",
+			user:     stubUserRepoFinder{result: user},
+			hasher:   stubPasswordHasher{},
+			tokens:   stubTokenIssuer{err: errors.New("fail sign")},
+			password: "Passw0rd",
+			wantErr:  errors.New("fail sign"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				created  entity.RefreshToken
+				rehashed vo.HashedPassword
+				verified int
+				hasher   = tt.hasher
+			)
+			hasher.verified = &verified
+
+			l := NewLoginInteractor(
+				tt.user,
+				spyUserPasswordUpdater{spyUserRepoUpdater: &spyUserRepoUpdater{}, updated: &rehashed},
+				spyRefreshTokenRepoCreator{created: &created},
+				hasher,
+				tt.tokens,
+				spyTokenPresenter{},
+			)
+
+			got, err := l.Execute(context.Background(), LoginInput{
+				Email:     vo.NewEmailTest("test@testing.com"),
+				Password:  tt.password,
+				CreatedAt: now,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+
+			// an unknown email verifies a hash as well, the response time must not reveal whether the email exists
+			if verified != 1 {
+				t.Errorf("[TestCase '%s'] Verified: '%v' | Want: '%v'", tt.name, verified, 1)
+			}
+
+			if rehashed != tt.wantRehashed {
+				t.Errorf("[TestCase '%s'] Rehashed: '%+v' | Want: '%+v'", tt.name, rehashed, tt.wantRehashed)
+			}
+
+			if tt.wantErr == nil && created.Hash() != entity.HashRefreshToken("refresh") {
+				t.Errorf("[TestCase '%s'] Stored hash: '%v' | Want: '%v'", tt.name, created.Hash(), entity.HashRefreshToken("refresh"))
+			}
+		})
+	}
+}
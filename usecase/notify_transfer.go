@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+)
+
+// NewNotifyTransferSubscriber subscribes the notifier to TransferCompleted, other events are ignored
+func NewNotifyTransferSubscriber(n Notifier) EventHandler {
+	return func(ctx context.Context, event entity.Event) error {
+		if e, ok := event.(entity.TransferCompleted); ok {
+			n.Notify(ctx, e.Transfer)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type (
+	// Input port
+	ReconcileWalletUseCase interface {
+		Execute(context.Context, ReconcileWalletInput) (ReconcileWalletOutput, error)
+	}
+
+	// Input data
+	ReconcileWalletInput struct {
+		UserID vo.Uuid
+	}
+
+	// Output port
+	ReconcileWalletPresenter interface {
+		Output(user entity.User, ledger vo.Money, postings []entity.Posting) ReconcileWalletOutput
+	}
+
+	// Output data
+	ReconcileWalletOutput struct {
+		UserID        string `json:"user_id"`
+		Currency      string `json:"currency"`
+		WalletBalance int64  `json:"wallet_balance"`
+		LedgerBalance int64  `json:"ledger_balance"`
+		Postings      int    `json:"postings"`
+		Reconciled    bool   `json:"reconciled"`
+	}
+
+	reconcileWalletInteractor struct {
+		repoUserFinder   entity.UserRepositoryFinder
+		repoLedgerFinder entity.LedgerRepositoryFinder
+		pre              ReconcileWalletPresenter
+	}
+)
+
+// NewReconcileWalletInteractor creates new reconcileWalletInteractor with its dependencies
+func NewReconcileWalletInteractor(
+	repoUserFinder entity.UserRepositoryFinder,
+	repoLedgerFinder entity.LedgerRepositoryFinder,
+	pre ReconcileWalletPresenter,
+) ReconcileWalletUseCase {
+	return reconcileWalletInteractor{
+		repoUserFinder:   repoUserFinder,
+		repoLedgerFinder: repoLedgerFinder,
+		pre:              pre,
+	}
+}
+
+// Execute orchestrates the use case
+func (r reconcileWalletInteractor) Execute(ctx context.Context, i ReconcileWalletInput) (ReconcileWalletOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := r.repoUserFinder.FindByID(ctx, i.UserID)
+	if err != nil {
+		return r.pre.Output(entity.User{}, vo.Money{}, nil), err
+	}
+
+	postings, err := r.repoLedgerFinder.FindPostingsByAccount(ctx, i.UserID)
+	if err != nil {
+		return r.pre.Output(entity.User{}, vo.Money{}, nil), err
+	}
+
+	balance, err := entity.BalanceFromPostings(i.UserID, user.Wallet().Money().Currency(), postings)
+	if err != nil {
+		return r.pre.Output(entity.User{}, vo.Money{}, nil), err
+	}
+
+	return r.pre.Output(user, balance, postings), nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubLedgerRepoFinder struct {
+	result []entity.Posting
+	err    error
+}
+
+func (s stubLedgerRepoFinder) FindPostingsByAccount(_ context.Context, _ vo.Uuid) ([]entity.Posting, error) {
+	return s.result, s.err
+}
+
+type spyReconcileWalletPresenter struct{}
+
+func (s spyReconcileWalletPresenter) Output(u entity.User, ledger vo.Money, postings []entity.Posting) ReconcileWalletOutput {
+	if u.Wallet() == nil {
+		return ReconcileWalletOutput{}
+	}
+
+	return ReconcileWalletOutput{
+		UserID:        u.ID().Value(),
+		Currency:      ledger.Currency().String(),
+		WalletBalance: u.Wallet().Money().Amount().Value(),
+		LedgerBalance: ledger.Amount().Value(),
+		Postings:      len(postings),
+		Reconciled:    u.Wallet().Money().Equals(ledger),
+	}
+}
+
+func mustPosting(account vo.Uuid, direction entity.PostingDirection, amount int64) entity.Posting {
+	p, err := entity.NewPosting(account, direction, vo.NewMoneyNGN(vo.NewAmountTest(amount)))
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func TestReconcileWalletInteractor_Execute(t *testing.T) {
+	var user = entity.NewCommonUser(
+		vo.NewUuidStaticTest(),
+		vo.NewFullName("Test testing"),
+		vo.NewEmailTest("test@testing.com"),
+		vo.NewHashedPasswordTest("$2a$10$passw"),
+		vo.NewDocumentTest(vo.CPF, "07091054954"),
+		vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(70))),
+		time.Now(),
+	)
+
+	type fields struct {
+		repoUserFinder   entity.UserRepositoryFinder
+		repoLedgerFinder entity.LedgerRepositoryFinder
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    ReconcileWalletOutput
+		wantErr bool
+	}{
+		{
+			name: "Reconcile wallet balance matches ledger",
+			fields: fields{
+				repoUserFinder: stubUserRepoFinder{result: user},
+				repoLedgerFinder: stubLedgerRepoFinder{
+					result: []entity.Posting{
+						mustPosting(vo.NewUuidStaticTest(), entity.Credit, 100),
+						mustPosting(vo.NewUuidStaticTest(), entity.Debit, 50),
+						mustPosting(vo.NewUuidStaticTest(), entity.Credit, 20),
+					},
+				},
+			},
+			want: ReconcileWalletOutput{
+				UserID:        vo.NewUuidStaticTest().Value(),
+				Currency:      "NGN",
+				WalletBalance: 70,
+				LedgerBalance: 70,
+				Postings:      3,
+				Reconciled:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reconcile wallet balance diverges from ledger",
+			fields: fields{
+				repoUserFinder: stubUserRepoFinder{result: user},
+				repoLedgerFinder: stubLedgerRepoFinder{
+					result: []entity.Posting{
+						mustPosting(vo.NewUuidStaticTest(), entity.Credit, 100),
+					},
+				},
+			},
+			want: ReconcileWalletOutput{
+				UserID:        vo.NewUuidStaticTest().Value(),
+				Currency:      "NGN",
+				WalletBalance: 70,
+				LedgerBalance: 100,
+				Postings:      1,
+				Reconciled:    false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reconcile wallet user not found",
+			fields: fields{
+				repoUserFinder:   stubUserRepoFinder{err: entity.ErrNotFoundUser},
+				repoLedgerFinder: stubLedgerRepoFinder{},
+			},
+			want:    ReconcileWalletOutput{},
+			wantErr: true,
+		},
+		{
+			name: "Reconcile wallet ledger error",
+			fields: fields{
+				repoUserFinder:   stubUserRepoFinder{result: user},
+				repoLedgerFinder: stubLedgerRepoFinder{err: errors.New("fail database")},
+			},
+			want:    ReconcileWalletOutput{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconcileWalletInteractor(
+				tt.fields.repoUserFinder,
+				tt.fields.repoLedgerFinder,
+				spyReconcileWalletPresenter{},
+			)
+
+			got, err := r.Execute(context.Background(), ReconcileWalletInput{UserID: vo.NewUuidStaticTest()})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+)
+
+type (
+	// Input port
+	RefreshTokenUseCase interface {
+		Execute(context.Context, RefreshTokenInput) (TokenOutput, error)
+	}
+
+	// Input data
+	RefreshTokenInput struct {
+		RefreshToken string
+		CreatedAt    time.Time
+	}
+
+	refreshTokenInteractor struct {
+		repoTokenCreator entity.RefreshTokenRepositoryCreator
+		repoTokenFinder  entity.RefreshTokenRepositoryFinder
+		repoTokenRevoker entity.RefreshTokenRepositoryRevoker
+		repoUserFinder   entity.UserRepositoryFinder
+		tokens           TokenIssuer
+		pre              TokenPresenter
+	}
+)
+
+// NewRefreshTokenInteractor creates new refreshTokenInteractor with its dependencies
+func NewRefreshTokenInteractor(
+	repoTokenCreator entity.RefreshTokenRepositoryCreator,
+	repoTokenFinder entity.RefreshTokenRepositoryFinder,
+	repoTokenRevoker entity.RefreshTokenRepositoryRevoker,
+	repoUserFinder entity.UserRepositoryFinder,
+	tokens TokenIssuer,
+	pre TokenPresenter,
+) RefreshTokenUseCase {
+	return refreshTokenInteractor{
+		repoTokenCreator: repoTokenCreator,
+		repoTokenFinder:  repoTokenFinder,
+		repoTokenRevoker: repoTokenRevoker,
+		repoUserFinder:   repoUserFinder,
+		tokens:           tokens,
+		pre:              pre,
+	}
+}
+
+// Execute orchestrates the use case, the refresh token is rotated so each one can be used only once
+func (r refreshTokenInteractor) Execute(ctx context.Context, i RefreshTokenInput) (TokenOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	stored, err := r.repoTokenFinder.FindByHash(ctx, entity.HashRefreshToken(i.RefreshToken))
+	if err != nil {
+		return r.pre.Output(Tokens{}), err
+	}
+
+	if err = stored.Valid(i.CreatedAt); err != nil {
+		return r.pre.Output(Tokens{}), err
+	}
+
+	if _, err = r.repoUserFinder.FindByID(ctx, stored.UserID()); err != nil {
+		if err == entity.ErrNotFoundUser {
+			return r.pre.Output(Tokens{}), entity.ErrInvalidRefreshToken
+		}
+		return r.pre.Output(Tokens{}), err
+	}
+
+	if err = r.repoTokenRevoker.Revoke(ctx, stored.ID(), i.CreatedAt); err != nil {
+		return r.pre.Output(Tokens{}), err
+	}
+
+	tokens, err := issueTokens(ctx, r.tokens, r.repoTokenCreator, stored.UserID(), i.CreatedAt)
+	if err != nil {
+		return r.pre.Output(Tokens{}), err
+	}
+
+	return r.pre.Output(tokens), nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubRefreshTokenRepoFinder struct {
+	result entity.RefreshToken
+	err    error
+}
+
+func (s stubRefreshTokenRepoFinder) FindByHash(context.Context, string) (entity.RefreshToken, error) {
+	return s.result, s.err
+}
+
+type stubRefreshTokenRepoRevoker struct {
+	err error
+}
+
+func (s stubRefreshTokenRepoRevoker) Revoke(context.Context, vo.Uuid, time.Time) error {
+	return s.err
+}
+
+func TestRefreshTokenInteractor_Execute(t *testing.T) {
+	var (
+		now    = time.Date(2021, 5, 10, 12, 0, 0, 0, time.UTC)
+		stored = entity.NewRefreshToken(vo.NewUuidRandom(), vo.NewUuidStaticTest(), "refresh-old", now.Add(time.Hour), now)
+		issued = TokenOutput{
+			AccessToken:  "access-" + vo.NewUuidStaticTest().Value(),
+			RefreshToken: "refresh",
+		}
+		revokedAt = now.Add(-time.Minute)
+	)
+
+	tests := []struct {
+		name    string
+		finder  stubRefreshTokenRepoFinder
+		revoker stubRefreshTokenRepoRevoker
+		user    stubUserRepoFinder
+		input   RefreshTokenInput
+		want    TokenOutput
+		wantErr error
+	}{
+		{
+			name:   "Refresh rotates the token",
+			finder: stubRefreshTokenRepoFinder{result: stored},
+			input:  RefreshTokenInput{RefreshToken: "refresh-old", CreatedAt: now},
+			want:   issued,
+		},
+		{
+			name:    "Refresh with an unknown token error",
+			finder:  stubRefreshTokenRepoFinder{err: entity.ErrInvalidRefreshToken},
+			input:   RefreshTokenInput{RefreshToken: "unknown", CreatedAt: now},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name:    "Refresh with an expired token error",
+			finder:  stubRefreshTokenRepoFinder{result: stored},
+			input:   RefreshTokenInput{RefreshToken: "refresh-old", CreatedAt: now.Add(2 * time.Hour)},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Refresh with a revoked token error",
+			finder: stubRefreshTokenRepoFinder{result: entity.NewRefreshTokenFromHash(
+				stored.ID(),
+				stored.UserID(),
+				stored.Hash(),
+				stored.ExpiresAt(),
+				&revokedAt,
+				stored.CreatedAt(),
+			)},
+			input:   RefreshTokenInput{RefreshToken: "refresh-old", CreatedAt: now},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name:    "Refresh with a token already rotated by a concurrent request error",
+			finder:  stubRefreshTokenRepoFinder{result: stored},
+			revoker: stubRefreshTokenRepoRevoker{err: entity.ErrInvalidRefreshToken},
+			input:   RefreshTokenInput{RefreshToken: "refresh-old", CreatedAt: now},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name:    "Refresh of a deleted user error",
+			finder:  stubRefreshTokenRepoFinder{result: stored},
+			user:    stubUserRepoFinder{err: entity.ErrNotFoundUser},
+			input:   RefreshTokenInput{RefreshToken: "refresh-old", CreatedAt: now},
+			wantErr: entity.ErrInvalidRefreshToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRefreshTokenInteractor(
+				spyRefreshTokenRepoCreator{},
+				tt.finder,
+				tt.revoker,
+				tt.user,
+				stubTokenIssuer{},
+				spyTokenPresenter{},
+			)
+
+			got, err := r.Execute(context.Background(), tt.input)
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
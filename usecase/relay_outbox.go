@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+)
+
+type (
+	// OutboxPublisher port, delivers an outbox message carrying its ID so the copies can be discarded
+	OutboxPublisher interface {
+		Publish(ctx context.Context, message entity.OutboxMessage) error
+	}
+
+	// Input port
+	RelayOutboxUseCase interface {
+		Execute(context.Context, RelayOutboxInput) (RelayOutboxOutput, error)
+	}
+
+	// Input data
+	RelayOutboxInput struct {
+		Limit       int
+		PublishedAt time.Time
+	}
+
+	// Output data
+	RelayOutboxOutput struct {
+		Published int
+	}
+
+	relayOutboxInteractor struct {
+		repoOutboxFinder  entity.OutboxRepositoryFinder
+		repoOutboxUpdater entity.OutboxRepositoryUpdater
+		publisher         OutboxPublisher
+	}
+)
+
+// NewRelayOutboxInteractor creates new relayOutboxInteractor with its dependencies
+func NewRelayOutboxInteractor(
+	repoOutboxFinder entity.OutboxRepositoryFinder,
+	repoOutboxUpdater entity.OutboxRepositoryUpdater,
+	publisher OutboxPublisher,
+) RelayOutboxUseCase {
+	return relayOutboxInteractor{
+		repoOutboxFinder:  repoOutboxFinder,
+		repoOutboxUpdater: repoOutboxUpdater,
+		publisher:         publisher,
+	}
+}
+
+// Execute publishes the pending messages in the order they were recorded, stopping at the first failure so
+// the order is kept. A message is only marked after it is published, a crash in between publishes it again
+func (r relayOutboxInteractor) Execute(ctx context.Context, i RelayOutboxInput) (RelayOutboxOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	messages, err := r.repoOutboxFinder.FindPending(ctx, i.Limit)
+	if err != nil {
+		return RelayOutboxOutput{}, err
+	}
+
+	var output RelayOutboxOutput
+	for _, message := range messages {
+		if err := r.publisher.Publish(ctx, message); err != nil {
+			return output, err
+		}
+
+		if err := r.repoOutboxUpdater.MarkPublished(ctx, message.ID(), i.PublishedAt); err != nil {
+			return output, err
+		}
+
+		output.Published++
+	}
+
+	return output, nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type stubOutboxRepoFinder struct {
+	result []entity.OutboxMessage
+	err    error
+}
+
+func (s stubOutboxRepoFinder) FindPending(_ context.Context, _ int) ([]entity.OutboxMessage, error) {
+	return s.result, s.err
+}
+
+func (s stubOutboxRepoFinder) FindByID(_ context.Context, _ vo.Uuid) (entity.OutboxMessage, error) {
+	return entity.OutboxMessage{}, entity.ErrNotFoundOutboxMessage
+}
+
+type spyOutboxRepoUpdater struct {
+	marked []vo.Uuid
+	err    error
+}
+
+func (s *spyOutboxRepoUpdater) MarkPublished(_ context.Context, ID vo.Uuid, _ time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.marked = append(s.marked, ID)
+	return nil
+}
+
+func (s *spyOutboxRepoUpdater) MarkProcessed(_ context.Context, _ vo.Uuid, _ time.Time) error {
+	return s.err
+}
+
+type spyOutboxPublisher struct {
+	published []vo.Uuid
+	failAt    int
+}
+
+func (s *spyOutboxPublisher) Publish(_ context.Context, m entity.OutboxMessage) error {
+	if s.failAt > 0 && len(s.published)+1 == s.failAt {
+		return errors.New("fail publish")
+	}
+	s.published = append(s.published, m.ID())
+	return nil
+}
+
+func TestRelayOutboxInteractor_Execute(t *testing.T) {
+	var messages = []entity.OutboxMessage{
+		entity.NewOutboxMessage(vo.NewUuidRandom(), vo.NewUuidStaticTest(), entity.TransferCompletedMessage, []byte(`{}`), time.Now()),
+		entity.NewOutboxMessage(vo.NewUuidRandom(), vo.NewUuidStaticTest(), entity.TransferCompletedMessage, []byte(`{}`), time.Now()),
+		entity.NewOutboxMessage(vo.NewUuidRandom(), vo.NewUuidStaticTest(), entity.TransferReversedMessage, []byte(`{}`), time.Now()),
+	}
+
+	tests := []struct {
+		name          string
+		finder        stubOutboxRepoFinder
+		failPublishAt int
+		updateErr     error
+		want          RelayOutboxOutput
+		wantMarked    int
+		wantErr       bool
+	}{
+		{
+			name:       "Relay publishes and marks every pending message",
+			finder:     stubOutboxRepoFinder{result: messages},
+			want:       RelayOutboxOutput{Published: 3},
+			wantMarked: 3,
+		},
+		{
+			name:   "Relay without pending messages",
+			finder: stubOutboxRepoFinder{},
+			want:   RelayOutboxOutput{},
+		},
+		{
+			name:          "Relay stops at the first publish failure keeping the order",
+			finder:        stubOutboxRepoFinder{result: messages},
+			failPublishAt: 2,
+			want:          RelayOutboxOutput{Published: 1},
+			wantMarked:    1,
+			wantErr:       true,
+		},
+		{
+			name:      "Relay mark failure leaves the message pending",
+			finder:    stubOutboxRepoFinder{result: messages},
+			updateErr: entity.ErrUpdateOutboxMessage,
+			want:      RelayOutboxOutput{},
+			wantErr:   true,
+		},
+		{
+			name:    "Relay find error",
+			finder:  stubOutboxRepoFinder{err: entity.ErrFindOutboxMessages},
+			want:    RelayOutboxOutput{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				updater   = &spyOutboxRepoUpdater{err: tt.updateErr}
+				publisher = &spyOutboxPublisher{failAt: tt.failPublishAt}
+			)
+
+			got, err := NewRelayOutboxInteractor(tt.finder, updater, publisher).Execute(
+				context.Background(),
+				RelayOutboxInput{Limit: 10, PublishedAt: time.Now()},
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+
+			if len(updater.marked) != tt.wantMarked {
+				t.Errorf("[TestCase '%s'] Got: '%d marked' | Want: '%d marked'", tt.name, len(updater.marked), tt.wantMarked)
+			}
+
+			for i, ID := range updater.marked {
+				if ID != messages[i].ID() {
+					t.Errorf("[TestCase '%s'] Got: '%v' | Want: '%v'", tt.name, ID, messages[i].ID())
+				}
+			}
+		})
+	}
+}
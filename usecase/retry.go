@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+
+	"github.com/pkg/errors"
+)
+
+// maxConflictAttempts bounds the attempts of a transaction aborted by a concurrent update of a wallet
+const maxConflictAttempts = 5
+
+// retryOnConflict runs fn again while it fails with ErrConcurrentModification, up to maxConflictAttempts times.
+// The attempts are spread by a short random delay so the transactions that conflicted do not collide again
+func retryOnConflict(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if !errors.Is(err, entity.ErrConcurrentModification) || attempt == maxConflictAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(rand.Int63n(int64(attempt) * int64(10*time.Millisecond)))):
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type (
+	// Input port
+	ReverseTransferUseCase interface {
+		Execute(context.Context, ReverseTransferInput) (ReverseTransferOutput, error)
+	}
+
+	// Input data
+	ReverseTransferInput struct {
+		ID         vo.Uuid
+		TransferID vo.Uuid
+		// Value is the amount to refund, a zero value refunds everything still refundable
+		Value vo.Amount
+		// RequestedBy is the authenticated user, only the payee of the original transfer can reverse it
+		RequestedBy vo.Uuid
+		CreatedAt   time.Time
+	}
+
+	// Output port
+	ReverseTransferPresenter interface {
+		Output(reversal entity.Transfer, original entity.Transfer) ReverseTransferOutput
+	}
+
+	// Output data
+	ReverseTransferOutput struct {
+		ID               string                        `json:"id"`
+		ReversalOf       string                        `json:"reversal_of"`
+		PayerID          string                        `json:"payer"`
+		PayeeID          string                        `json:"payee"`
+		Value            int64                         `json:"value"`
+		Currency         string                        `json:"currency"`
+		CreditedValue    int64                         `json:"credited_value"`
+		CreditedCurrency string                        `json:"credited_currency"`
+		Rate             string                        `json:"rate"`
+		Status           string                        `json:"status"`
+		Original         ReverseTransferOriginalOutput `json:"original"`
+		CreatedAt        string                        `json:"created_at"`
+	}
+
+	// Output data
+	ReverseTransferOriginalOutput struct {
+		Status     string `json:"status"`
+		Refunded   int64  `json:"refunded"`
+		Refundable int64  `json:"refundable"`
+	}
+
+	reverseTransferInteractor struct {
+		repoTransferCreator entity.TransferRepositoryCreator
+		repoTransferUpdater entity.TransferRepositoryUpdater
+		repoTransferFinder  entity.TransferRepositoryFinder
+		repoUserUpdater     entity.UserRepositoryUpdater
+		repoUserFinder      entity.UserRepositoryFinder
+		repoLedgerCreator   entity.LedgerRepositoryCreator
+		repoOutboxCreator   entity.OutboxRepositoryCreator
+		events              EventPublisher
+		pre                 ReverseTransferPresenter
+	}
+)
+
+// NewReverseTransferInteractor creates new reverseTransferInteractor with its dependencies
+func NewReverseTransferInteractor(
+	repoTransferCreator entity.TransferRepositoryCreator,
+	repoTransferUpdater entity.TransferRepositoryUpdater,
+	repoTransferFinder entity.TransferRepositoryFinder,
+	repoUserUpdater entity.UserRepositoryUpdater,
+	repoUserFinder entity.UserRepositoryFinder,
+	repoLedgerCreator entity.LedgerRepositoryCreator,
+	repoOutboxCreator entity.OutboxRepositoryCreator,
+	events EventPublisher,
+	pre ReverseTransferPresenter,
+) ReverseTransferUseCase {
+	return reverseTransferInteractor{
+		repoTransferCreator: repoTransferCreator,
+		repoTransferUpdater: repoTransferUpdater,
+		repoTransferFinder:  repoTransferFinder,
+		repoUserUpdater:     repoUserUpdater,
+		repoUserFinder:      repoUserFinder,
+		repoLedgerCreator:   repoLedgerCreator,
+		repoOutboxCreator:   repoOutboxCreator,
+		events:              events,
+		pre:                 pre,
+	}
+}
+
+// Execute orchestrates the use case
+func (r reverseTransferInteractor) Execute(ctx context.Context, i ReverseTransferInput) (ReverseTransferOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var (
+		original entity.Transfer
+		reversal entity.Transfer
+		events   []entity.Event
+	)
+
+	// a wallet updated by a concurrent transfer since it was read aborts the transaction, which is run again
+	err := retryOnConflict(ctx, func() error {
+		return r.repoTransferCreator.WithTransaction(ctx, func(sessCtx context.Context) error {
+			var err error
+			original, err = r.repoTransferFinder.FindByID(sessCtx, i.TransferID)
+			if err != nil {
+				return err
+			}
+
+			if original.Payee() != i.RequestedBy {
+				return entity.ErrReversalNotAllowed
+			}
+
+			if original.ReversalOf().Value() != "" {
+				return entity.ErrInvalidRefund
+			}
+
+			value := original.Refundable()
+			if i.Value.Value() > 0 {
+				value = vo.NewMoney(original.Value().Currency(), i.Value)
+			}
+
+			if err = original.Refund(value, time.Now()); err != nil {
+				return err
+			}
+
+			reversal, err = entity.NewReversal(i.ID, original, value, i.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			// the payer of a reversal is usually a merchant, so it is not checked with CanTransfer
+			wallets, err := r.process(sessCtx, reversal)
+			if err != nil {
+				return err
+			}
+
+			reversal, err = r.repoTransferCreator.Create(sessCtx, reversal)
+			if err != nil {
+				return err
+			}
+
+			entry, err := entity.NewTransferJournalEntry(vo.NewUuidRandom(), reversal)
+			if err != nil {
+				return err
+			}
+
+			if _, err = r.repoLedgerCreator.Create(sessCtx, entry); err != nil {
+				return err
+			}
+
+			if err = reversal.Authorize(time.Now()); err != nil {
+				return err
+			}
+
+			if err = reversal.Complete(time.Now()); err != nil {
+				return err
+			}
+
+			if err = r.repoTransferUpdater.Update(sessCtx, reversal); err != nil {
+				return err
+			}
+
+			if err = r.repoTransferUpdater.Update(sessCtx, original); err != nil {
+				return err
+			}
+
+			message, err := newTransferMessage(entity.TransferReversedMessage, reversal)
+			if err != nil {
+				return err
+			}
+
+			if err = r.repoOutboxCreator.Create(sessCtx, message); err != nil {
+				return err
+			}
+
+			// the callback may be retried, only the events of the attempt that commits are kept
+			events = append(wallets, reversal.PullEvents()...)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return r.pre.Output(entity.Transfer{}, entity.Transfer{}), err
+	}
+
+	// the reversal is committed, the failures of the subscribers are reported by the publisher
+	_ = r.events.Publish(ctx, events...)
+
+	return r.pre.Output(reversal, original), nil
+}
+
+// process moves the refund between the wallets and returns their events
+func (r reverseTransferInteractor) process(ctx context.Context, reversal entity.Transfer) ([]entity.Event, error) {
+	from, err := r.repoUserFinder.FindByID(ctx, reversal.Payer())
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := r.repoUserFinder.FindByID(ctx, reversal.Payee())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = from.Withdraw(reversal.Value(), time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err = to.Deposit(reversal.Credit(), time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err = r.repoUserUpdater.UpdateWallet(ctx, reversal.Payer(), from.Wallet()); err != nil {
+		return nil, err
+	}
+
+	if err = r.repoUserUpdater.UpdateWallet(ctx, reversal.Payee(), to.Wallet()); err != nil {
+		return nil, err
+	}
+
+	return append(from.PullEvents(), to.PullEvents()...), nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+type spyReverseTransferPresenter struct{}
+
+func (s spyReverseTransferPresenter) Output(reversal entity.Transfer, original entity.Transfer) ReverseTransferOutput {
+	return ReverseTransferOutput{
+		ID:         reversal.ID().Value(),
+		ReversalOf: reversal.ReversalOf().Value(),
+		Value:      reversal.Value().Amount().Value(),
+		Status:     reversal.Status().String(),
+		Original: ReverseTransferOriginalOutput{
+			Status:     original.Status().String(),
+			Refunded:   original.Refunded().Amount().Value(),
+			Refundable: original.Refundable().Amount().Value(),
+		},
+	}
+}
+
+func TestReverseTransferInteractor_Execute(t *testing.T) {
+	var (
+		originalID, _ = vo.NewUuid("9a1b7b0e-41f8-4a3b-8a5f-3b8f2f9c2a11")
+		newTransfer   = func(statuses ...func(*entity.Transfer, time.Time) error) entity.Transfer {
+			transfer := entity.NewTransfer(
+				originalID,
+				vo.NewUuidStaticTest(),
+				vo.NewUuidStaticTest(),
+				vo.NewMoneyNGN(vo.NewAmountTest(100)),
+				time.Time{},
+			)
+			for _, status := range statuses {
+				_ = status(&transfer, time.Time{})
+			}
+
+			return transfer
+		}
+		completed = newTransfer((*entity.Transfer).Authorize, (*entity.Transfer).Complete)
+		newUser   = func(user func(vo.Uuid, vo.FullName, vo.Email, vo.HashedPassword, vo.Document, *vo.Wallet, time.Time) entity.User, balance int64) func() (entity.User, error) {
+			return func() (entity.User, error) {
+				return user(
+					vo.NewUuidStaticTest(),
+					vo.NewFullName("Test testing"),
+					vo.NewEmailTest("test@testing.com"),
+					vo.NewHashedPasswordTest("$2a$10$passw"),
+					vo.NewDocumentTest(vo.CPF, "07091054954"),
+					vo.NewWallet(vo.NewMoneyNGN(vo.NewAmountTest(balance))),
+					time.Now(),
+				), nil
+			}
+		}
+	)
+
+	type fields struct {
+		repoTransferFinder entity.TransferRepositoryFinder
+		repoUserFinder     entity.UserRepositoryFinder
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		value       int64
+		requestedBy vo.Uuid
+		want        ReverseTransferOutput
+		wantErr     error
+	}{
+		{
+			name: "Full refund reverses the original transfer",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: completed},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 100),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value: 0,
+			want: ReverseTransferOutput{
+				ID:         vo.NewUuidStaticTest().Value(),
+				ReversalOf: originalID.Value(),
+				Value:      100,
+				Status:     vo.COMPLETED.String(),
+				Original: ReverseTransferOriginalOutput{
+					Status:     vo.REVERSED.String(),
+					Refunded:   100,
+					Refundable: 0,
+				},
+			},
+		},
+		{
+			name: "Partial refund keeps the original transfer completed",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: completed},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 100),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value: 40,
+			want: ReverseTransferOutput{
+				ID:         vo.NewUuidStaticTest().Value(),
+				ReversalOf: originalID.Value(),
+				Value:      40,
+				Status:     vo.COMPLETED.String(),
+				Original: ReverseTransferOriginalOutput{
+					Status:     vo.COMPLETED.String(),
+					Refunded:   40,
+					Refundable: 60,
+				},
+			},
+		},
+		{
+			name: "Refund above the original value error",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: completed},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 1000),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value:   150,
+			want:    spyReverseTransferPresenter{}.Output(entity.Transfer{}, entity.Transfer{}),
+			wantErr: entity.ErrRefundExceedsTransfer,
+		},
+		{
+			name: "Refund of a transfer that was not completed error",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: newTransfer()},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 100),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value:   0,
+			want:    spyReverseTransferPresenter{}.Output(entity.Transfer{}, entity.Transfer{}),
+			wantErr: entity.ErrInvalidTransferTransition,
+		},
+		{
+			name: "Refund with insufficient payee balance error",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: completed},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 10),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value:   0,
+			want:    spyReverseTransferPresenter{}.Output(entity.Transfer{}, entity.Transfer{}),
+			wantErr: entity.ErrUserInsufficientBalance,
+		},
+		{
+			name: "Refund requested by someone other than the payee error",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{result: completed},
+				repoUserFinder: &spyUserRepoFinder{
+					findPayer: newUser(entity.NewMerchantUser, 100),
+					findPayee: newUser(entity.NewCommonUser, 0),
+				},
+			},
+			value:       0,
+			requestedBy: vo.NewUuidRandom(),
+			want:        spyReverseTransferPresenter{}.Output(entity.Transfer{}, entity.Transfer{}),
+			wantErr:     entity.ErrReversalNotAllowed,
+		},
+		{
+			name: "Refund of a not found transfer error",
+			fields: fields{
+				repoTransferFinder: stubTransferRepoFinder{err: entity.ErrNotFoundTransfer},
+				repoUserFinder:     &spyUserRepoFinder{},
+			},
+			value:   0,
+			want:    spyReverseTransferPresenter{}.Output(entity.Transfer{}, entity.Transfer{}),
+			wantErr: entity.ErrNotFoundTransfer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReverseTransferInteractor(
+				echoTransferRepoCreator{},
+				stubTransferRepoUpdater{},
+				tt.fields.repoTransferFinder,
+				&spyUserRepoUpdater{},
+				tt.fields.repoUserFinder,
+				stubLedgerRepoCreator{},
+				stubOutboxRepoCreator{},
+				&spyEventPublisher{},
+				spyReverseTransferPresenter{},
+			)
+
+			requestedBy := tt.requestedBy
+			if requestedBy.Value() == "" {
+				requestedBy = vo.NewUuidStaticTest()
+			}
+
+			got, err := r.Execute(context.Background(), ReverseTransferInput{
+				ID:          vo.NewUuidStaticTest(),
+				TransferID:  originalID,
+				Value:       vo.NewAmountTest(tt.value),
+				RequestedBy: requestedBy,
+			})
+			if err != tt.wantErr {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+// ErrUnknownTransferMessage is returned when decoding a message of another kind
+var ErrUnknownTransferMessage = errors.New("unknown transfer message")
+
+// transferMessage is the payload of the outbox messages about a transfer
+type transferMessage struct {
+	ID               string `json:"id"`
+	Type             string `json:"type"`
+	TransferID       string `json:"transfer_id"`
+	ReversalOf       string `json:"reversal_of,omitempty"`
+	PayerID          string `json:"payer"`
+	PayeeID          string `json:"payee"`
+	Value            int64  `json:"value"`
+	Currency         string `json:"currency"`
+	CreditedValue    int64  `json:"credited_value"`
+	CreditedCurrency string `json:"credited_currency"`
+	Rate             string `json:"rate"`
+	Status           string `json:"status"`
+	CreatedAt        string `json:"created_at"`
+	OccurredAt       string `json:"occurred_at"`
+}
+
+// newTransferMessage records the transfer in an outbox message of the given kind
+func newTransferMessage(kind string, t entity.Transfer) (entity.OutboxMessage, error) {
+	var (
+		id  = vo.NewUuidRandom()
+		now = time.Now()
+	)
+
+	payload, err := json.Marshal(transferMessage{
+		ID:               id.Value(),
+		Type:             kind,
+		TransferID:       t.ID().Value(),
+		ReversalOf:       t.ReversalOf().Value(),
+		PayerID:          t.Payer().Value(),
+		PayeeID:          t.Payee().Value(),
+		Value:            t.Value().Amount().Value(),
+		Currency:         t.Value().Currency().String(),
+		CreditedValue:    t.Credit().Amount().Value(),
+		CreditedCurrency: t.Credit().Currency().String(),
+		Rate:             t.Rate().String(),
+		Status:           t.Status().String(),
+		CreatedAt:        t.CreatedAt().Format(time.RFC3339Nano),
+		OccurredAt:       now.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return entity.OutboxMessage{}, err
+	}
+
+	return entity.NewOutboxMessage(id, t.ID(), kind, payload, now), nil
+}
+
+// DecodeTransferMessage restores the TransferCompleted event of the transfer carried by an outbox message
+func DecodeTransferMessage(payload []byte) (entity.Event, error) {
+	var m transferMessage
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, err
+	}
+
+	switch m.Type {
+	case entity.TransferCompletedMessage, entity.TransferReversedMessage:
+	default:
+		return nil, ErrUnknownTransferMessage
+	}
+
+	ID, err := vo.NewUuid(m.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	payer, err := vo.NewUuid(m.PayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := vo.NewUuid(m.PayeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := newMessageMoney(m.Currency, m.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	credit, err := newMessageMoney(m.CreditedCurrency, m.CreditedValue)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := vo.NewExchangeRate(value.Currency(), credit.Currency(), m.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := vo.NewTransferStatus(m.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt, err := time.Parse(time.RFC3339Nano, m.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := entity.NewTransfer(ID, payer, payee, value, createdAt).
+		WithConversion(credit, rate).
+		WithStatus(status, nil)
+	if m.ReversalOf != "" {
+		reversalOf, err := vo.NewUuid(m.ReversalOf)
+		if err != nil {
+			return nil, err
+		}
+		transfer = transfer.WithReversalOf(reversalOf)
+	}
+
+	return entity.TransferCompleted{Transfer: transfer, At: occurredAt}, nil
+}
+
+func newMessageMoney(currency string, value int64) (vo.Money, error) {
+	c, err := vo.NewCurrency(currency)
+	if err != nil {
+		return vo.Money{}, err
+	}
+
+	amount, err := vo.NewAmount(value)
+	if err != nil {
+		return vo.Money{}, err
+	}
+
+	return vo.NewMoney(c, amount), nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ofiliobi/urban-octo-fortnight/domain/entity"
+	"github.com/ofiliobi/urban-octo-fortnight/domain/vo"
+)
+
+func TestDecodeTransferMessage(t *testing.T) {
+	var transfer = entity.NewTransfer(
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewUuidStaticTest(),
+		vo.NewMoneyNGN(vo.NewAmountTest(100)),
+		time.Now(),
+	).WithConversion(
+		vo.NewMoneyUSD(vo.NewAmountTest(25)),
+		vo.NewExchangeRateTest(vo.NGN, vo.USD, "0.25"),
+	).WithStatus(vo.COMPLETED, nil)
+
+	completed, err := newTransferMessage(entity.TransferCompletedMessage, transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unknown, err := newTransferMessage("transfer.unknown", transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		want    entity.Transfer
+		wantErr error
+	}{
+		{
+			name:    "Decode transfer completed message",
+			payload: completed.Payload(),
+			want:    transfer,
+		},
+		{
+			name:    "Decode message of an unknown type",
+			payload: unknown.Payload(),
+			wantErr: ErrUnknownTransferMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTransferMessage(tt.payload)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("[TestCase '%s'] Err: '%v' | WantErr: '%v'", tt.name, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			event, ok := got.(entity.TransferCompleted)
+			if !ok {
+				t.Fatalf("[TestCase '%s'] Got: '%T' | Want: '%T'", tt.name, got, entity.TransferCompleted{})
+			}
+
+			if !event.Transfer.ID().Equals(tt.want.ID()) ||
+				!event.Transfer.Credit().Equals(tt.want.Credit()) ||
+				event.Transfer.Rate().String() != tt.want.Rate().String() ||
+				event.Transfer.Status() != tt.want.Status() ||
+				!event.Transfer.CreatedAt().Equal(tt.want.CreatedAt()) {
+				t.Errorf("[TestCase '%s'] Got: '%+v' | Want: '%+v'", tt.name, event.Transfer, tt.want)
+			}
+		})
+	}
+}